@@ -1,17 +1,23 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
 	"net/http"
+	"time"
+	// Calendars name IANA time zones; embed them for hosts without tzdata
+	_ "time/tzdata"
 
 	_ "github.com/lib/pq"
 	adapterdb "github.com/nickhildpac/ticket-management-app/internal/adapters/db"
 	sqldb "github.com/nickhildpac/ticket-management-app/internal/adapters/db/sqlc"
 	httpadapter "github.com/nickhildpac/ticket-management-app/internal/adapters/http"
 	httphandlers "github.com/nickhildpac/ticket-management-app/internal/adapters/http/handlers"
+	"github.com/nickhildpac/ticket-management-app/internal/adapters/mail"
 	"github.com/nickhildpac/ticket-management-app/internal/application/service"
+	"github.com/nickhildpac/ticket-management-app/internal/ports"
 	"github.com/nickhildpac/ticket-management-app/pkg/configs"
 )
 
@@ -29,13 +35,77 @@ func main() {
 	store := sqldb.NewStore(conn)
 	userRepo := adapterdb.NewUserRepository(store)
 	ticketRepo := adapterdb.NewTicketRepository(store)
+	ticketEventRepo := adapterdb.NewTicketEventRepository(store)
+	priorityMatrixRepo := adapterdb.NewPriorityMatrixRepository(store)
+	checklistRepo := adapterdb.NewChecklistRepository(store)
 	commentRepo := adapterdb.NewCommentRepository(store)
+	csatRepo := adapterdb.NewCSATRepository(store)
+	savedViewRepo := adapterdb.NewSavedViewRepository(store)
+	approvalRepo := adapterdb.NewApprovalRepository(store)
+	orgRepo := adapterdb.NewOrganizationRepository(store)
+	teamRepo := adapterdb.NewTeamRepository(store)
+	availabilityRepo := adapterdb.NewAvailabilityRepository(store)
+	calendarRepo := adapterdb.NewCalendarRepository(store)
+	transferRepo := adapterdb.NewTransferRepository(store)
+	mentionRepo := adapterdb.NewMentionRepository(store)
+	reactionRepo := adapterdb.NewReactionRepository(store)
+	macroRepo := adapterdb.NewMacroRepository(store)
+	userTokenRepo := adapterdb.NewUserTokenRepository(store)
+	refreshTokenRepo := adapterdb.NewRefreshTokenRepository(store)
+	transactor := adapterdb.NewTransactor(store)
 
-	userSvc := service.NewUserService(userRepo)
-	ticketSvc := service.NewTicketService(ticketRepo)
-	commentSvc := service.NewCommentService(commentRepo, ticketRepo)
+	var mailer ports.Mailer
+	switch conf.MailDriver {
+	case "smtp":
+		mailer = mail.NewSMTPMailer(conf.MailFrom, conf.SMTPHost, conf.SMTPPort, conf.SMTPUsername, conf.SMTPPassword)
+	case "file":
+		mailer, err = mail.NewFileMailer(conf.MailFrom, conf.MailDir)
+		if err != nil {
+			log.Fatal(err)
+		}
+	default:
+		mailer = mail.NewLogMailer(conf.MailFrom)
+	}
+
+	verificationSvc := service.NewVerificationService(userRepo, userTokenRepo, orgRepo, mailer, transactor, conf)
+	sessionSvc := service.NewSessionService(refreshTokenRepo, conf)
+	userSvc := service.NewUserService(userRepo, verificationSvc, sessionSvc, conf)
+	csatSvc := service.NewCSATService(csatRepo, ticketRepo, userRepo, mailer, conf)
+	availabilitySvc := service.NewAvailabilityService(availabilityRepo, userRepo, ticketEventRepo, conf)
+	mentionSvc := service.NewMentionService(mentionRepo, ticketRepo, userRepo, mailer, conf)
+	ticketSvc := service.NewTicketService(ticketRepo, ticketEventRepo, priorityMatrixRepo, checklistRepo, approvalRepo, teamRepo, availabilitySvc, csatSvc, mentionSvc, transactor, conf)
+	commentSvc := service.NewCommentService(commentRepo, ticketRepo, ticketEventRepo, mentionSvc, ticketSvc, userRepo, transactor, conf)
+	priorityMatrixSvc := service.NewPriorityMatrixService(priorityMatrixRepo)
+	checklistSvc := service.NewChecklistService(checklistRepo, ticketRepo)
+	savedViewSvc := service.NewSavedViewService(savedViewRepo, teamRepo, ticketSvc)
+	approvalSvc := service.NewApprovalService(approvalRepo, ticketRepo, ticketEventRepo)
+	orgSvc := service.NewOrganizationService(orgRepo, userRepo)
+	teamSvc := service.NewTeamService(teamRepo, userRepo)
+	calendarSvc := service.NewCalendarService(calendarRepo, orgRepo, teamRepo)
+	transferSvc := service.NewTransferService(transferRepo, ticketRepo, teamRepo, userRepo, ticketEventRepo)
+	reactionSvc := service.NewReactionService(reactionRepo, commentRepo, ticketRepo)
+	macroSvc := service.NewMacroService(macroRepo, userRepo, ticketSvc, commentSvc, transactor)
+	passwordSvc := service.NewPasswordService(userRepo, userTokenRepo, mailer, transactor, conf)
+
+	handler := httphandlers.NewHandler(conf, userSvc, ticketSvc, commentSvc, csatSvc, priorityMatrixSvc, checklistSvc, savedViewSvc, approvalSvc, orgSvc, teamSvc, availabilitySvc, calendarSvc, transferSvc, mentionSvc, reactionSvc, macroSvc, passwordSvc, verificationSvc, sessionSvc)
+
+	// Hand tickets of agents who are out of office to their delegates
+	go func() {
+		for range time.Tick(conf.ForwardInterval) {
+			if err := availabilitySvc.ForwardOutOfOffice(context.Background()); err != nil {
+				log.Printf("failed to forward out-of-office tickets: %v", err)
+			}
+		}
+	}()
 
-	handler := httphandlers.NewHandler(conf, userSvc, ticketSvc, commentSvc)
+	// Drop the records of refresh tokens that have expired
+	go func() {
+		for range time.Tick(time.Hour) {
+			if err := sessionSvc.PurgeExpired(context.Background()); err != nil {
+				log.Printf("failed to purge expired refresh tokens: %v", err)
+			}
+		}
+	}()
 
 	log.Printf("server is listening on port %d ", conf.ADDR)
 	err = http.ListenAndServe(fmt.Sprintf(":%d", conf.ADDR), httpadapter.Router(conf, handler))
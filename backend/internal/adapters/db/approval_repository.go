@@ -0,0 +1,99 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	sqlc "github.com/nickhildpac/ticket-management-app/internal/adapters/db/sqlc"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+)
+
+type ApprovalRepository struct {
+	store sqlc.Store
+}
+
+func NewApprovalRepository(store sqlc.Store) *ApprovalRepository {
+	return &ApprovalRepository{store: store}
+}
+
+func (r *ApprovalRepository) ListSteps(ctx context.Context, ticketType string) ([]domain.ApprovalStep, error) {
+	rows, err := r.store.ListApprovalSteps(ctx, ticketType)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]domain.ApprovalStep, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, mapApprovalStep(row))
+	}
+	return out, nil
+}
+
+func (r *ApprovalRepository) ListStepTypes(ctx context.Context) ([]string, error) {
+	return r.store.ListApprovalStepTypes(ctx)
+}
+
+func (r *ApprovalRepository) ReplaceSteps(ctx context.Context, ticketType string, steps []domain.ApprovalStep) error {
+	// A zero id or empty role stands for "not set" in the parallel arrays
+	ids := make([]uuid.UUID, len(steps))
+	roles := make([]string, len(steps))
+	for i, step := range steps {
+		if step.ApproverID != nil {
+			ids[i] = *step.ApproverID
+		}
+		if step.ApproverRole != nil {
+			roles[i] = string(*step.ApproverRole)
+		}
+	}
+	return r.store.ReplaceApprovalSteps(ctx, sqlc.ReplaceApprovalStepsParams{
+		TicketType:    ticketType,
+		ApproverIds:   ids,
+		ApproverRoles: roles,
+	})
+}
+
+func (r *ApprovalRepository) CreateForTicket(ctx context.Context, ticketID uuid.UUID, ticketType string) error {
+	return r.store.CreateTicketApprovals(ctx, sqlc.CreateTicketApprovalsParams{
+		TicketID:   ticketID,
+		TicketType: ticketType,
+	})
+}
+
+func (r *ApprovalRepository) ListByTicket(ctx context.Context, ticketID uuid.UUID) ([]domain.TicketApproval, error) {
+	rows, err := r.store.ListTicketApprovals(ctx, sqlc.ListTicketApprovalsParams{TicketID: ticketID, OrgIds: orgScope(ctx)})
+	if err != nil {
+		return nil, err
+	}
+	return mapTicketApprovals(rows), nil
+}
+
+func (r *ApprovalRepository) Decide(ctx context.Context, approval domain.TicketApproval) (*domain.TicketApproval, error) {
+	decided, err := r.store.DecideTicketApproval(ctx, sqlc.DecideTicketApprovalParams{
+		ID:        approval.ID,
+		Status:    string(approval.Status),
+		DecidedBy: toNullUUID(approval.DecidedBy),
+		Comment:   approval.Comment,
+		DecidedAt: toNullTime(approval.DecidedAt),
+		OrgIds:    orgScope(ctx),
+	})
+	if err == sql.ErrNoRows {
+		// Someone else decided the step first
+		return nil, domain.ErrNoPendingApproval
+	}
+	if err != nil {
+		return nil, err
+	}
+	return mapTicketApproval(decided), nil
+}
+
+func (r *ApprovalRepository) ListPending(ctx context.Context, userID uuid.UUID, role domain.UserRole) ([]domain.TicketApproval, error) {
+	rows, err := r.store.ListPendingApprovals(ctx, sqlc.ListPendingApprovalsParams{
+		ApproverID:   uuid.NullUUID{UUID: userID, Valid: true},
+		ApproverRole: sql.NullString{String: string(role), Valid: true},
+		OrgIds:       orgScope(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapTicketApprovals(rows), nil
+}
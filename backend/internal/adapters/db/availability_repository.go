@@ -0,0 +1,121 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	sqlc "github.com/nickhildpac/ticket-management-app/internal/adapters/db/sqlc"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+)
+
+type AvailabilityRepository struct {
+	store sqlc.Store
+}
+
+func NewAvailabilityRepository(store sqlc.Store) *AvailabilityRepository {
+	return &AvailabilityRepository{store: store}
+}
+
+// Get returns the agent's availability as of now. Agents who never set it
+// are available with no limit.
+func (r *AvailabilityRepository) Get(ctx context.Context, userID uuid.UUID) (*domain.Availability, error) {
+	out := &domain.Availability{UserID: userID, Status: domain.AvailabilityAvailable}
+	row, err := r.store.GetAgentAvailability(ctx, sqlc.GetAgentAvailabilityParams{UserID: userID, OrgIds: orgScope(ctx)})
+	switch {
+	case err == nil:
+		out.Status = domain.AvailabilityStatus(row.Status)
+		out.MaxOpenTickets = int(row.MaxOpenTickets)
+		out.UpdatedAt = row.UpdatedAt
+	case err != sql.ErrNoRows:
+		return nil, err
+	}
+
+	open, err := r.store.CountOpenAssignedTickets(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	out.OpenTickets = int(open)
+
+	ooo, err := r.store.GetActiveOutOfOffice(ctx, sqlc.GetActiveOutOfOfficeParams{
+		UserID: userID,
+		At:     time.Now(),
+		OrgIds: orgScope(ctx),
+	})
+	switch {
+	case err == nil:
+		out.OutOfOffice = mapOutOfOffice(ooo)
+	case err != sql.ErrNoRows:
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *AvailabilityRepository) Update(ctx context.Context, a domain.Availability) (*domain.Availability, error) {
+	_, err := r.store.UpsertAgentAvailability(ctx, sqlc.UpsertAgentAvailabilityParams{
+		UserID:         a.UserID,
+		Status:         string(a.Status),
+		MaxOpenTickets: int32(a.MaxOpenTickets),
+		UpdatedAt:      time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.Get(ctx, a.UserID)
+}
+
+func (r *AvailabilityRepository) ListOutOfOffice(ctx context.Context, userID uuid.UUID) ([]domain.OutOfOffice, error) {
+	rows, err := r.store.ListOutOfOffice(ctx, sqlc.ListOutOfOfficeParams{
+		UserID: userID,
+		Since:  time.Now(),
+		OrgIds: orgScope(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]domain.OutOfOffice, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, *mapOutOfOffice(row))
+	}
+	return out, nil
+}
+
+func (r *AvailabilityRepository) ListActiveOutOfOffice(ctx context.Context) ([]domain.OutOfOffice, error) {
+	rows, err := r.store.ListActiveOutOfOffice(ctx, sqlc.ListActiveOutOfOfficeParams{At: time.Now(), OrgIds: orgScope(ctx)})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]domain.OutOfOffice, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, *mapOutOfOffice(row))
+	}
+	return out, nil
+}
+
+func (r *AvailabilityRepository) CreateOutOfOffice(ctx context.Context, o domain.OutOfOffice) (*domain.OutOfOffice, error) {
+	created, err := r.store.CreateOutOfOffice(ctx, sqlc.CreateOutOfOfficeParams{
+		UserID:     o.UserID,
+		StartsAt:   o.StartsAt,
+		EndsAt:     o.EndsAt,
+		DelegateID: toNullUUID(o.DelegateID),
+		Note:       o.Note,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapOutOfOffice(created), nil
+}
+
+func (r *AvailabilityRepository) DeleteOutOfOffice(ctx context.Context, userID, id uuid.UUID) error {
+	return r.store.DeleteOutOfOffice(ctx, sqlc.DeleteOutOfOfficeParams{ID: id, UserID: userID, OrgIds: orgScope(ctx)})
+}
+
+func (r *AvailabilityRepository) ForwardTickets(ctx context.Context, userID, delegateID uuid.UUID) ([]uuid.UUID, error) {
+	return r.store.ForwardAssignedTickets(ctx, sqlc.ForwardAssignedTicketsParams{
+		DelegateID: delegateID,
+		UserID:     userID,
+		UpdatedAt:  time.Now(),
+		OrgIds:     orgScope(ctx),
+	})
+}
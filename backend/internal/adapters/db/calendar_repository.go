@@ -0,0 +1,124 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	sqlc "github.com/nickhildpac/ticket-management-app/internal/adapters/db/sqlc"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+)
+
+type CalendarRepository struct {
+	store sqlc.Store
+}
+
+func NewCalendarRepository(store sqlc.Store) *CalendarRepository {
+	return &CalendarRepository{store: store}
+}
+
+func (r *CalendarRepository) List(ctx context.Context) ([]domain.BusinessCalendar, error) {
+	rows, err := r.store.ListCalendars(ctx, orgScope(ctx))
+	if err != nil {
+		return nil, err
+	}
+	out := make([]domain.BusinessCalendar, 0, len(rows))
+	for _, row := range rows {
+		calendar, err := r.withSchedule(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *calendar)
+	}
+	return out, nil
+}
+
+func (r *CalendarRepository) Get(ctx context.Context, id uuid.UUID) (*domain.BusinessCalendar, error) {
+	calendar, err := r.store.GetCalendar(ctx, sqlc.GetCalendarParams{ID: id, OrgIds: orgScope(ctx)})
+	if err != nil {
+		return nil, err
+	}
+	return r.withSchedule(ctx, calendar)
+}
+
+func (r *CalendarRepository) Create(ctx context.Context, calendar domain.BusinessCalendar) (*domain.BusinessCalendar, error) {
+	created, err := r.store.CreateCalendar(ctx, sqlc.CreateCalendarParams{
+		OrgID:     calendar.OrgID,
+		Name:      calendar.Name,
+		TimeZone:  calendar.TimeZone,
+		UpdatedAt: time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := r.saveSchedule(ctx, created.ID, calendar); err != nil {
+		return nil, err
+	}
+	return r.withSchedule(ctx, created)
+}
+
+func (r *CalendarRepository) Update(ctx context.Context, calendar domain.BusinessCalendar) (*domain.BusinessCalendar, error) {
+	updated, err := r.store.UpdateCalendar(ctx, sqlc.UpdateCalendarParams{
+		Name:      calendar.Name,
+		TimeZone:  calendar.TimeZone,
+		UpdatedAt: time.Now(),
+		ID:        calendar.ID,
+		OrgIds:    orgScope(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := r.store.DeleteCalendarHours(ctx, updated.ID); err != nil {
+		return nil, err
+	}
+	if err := r.store.DeleteCalendarHolidays(ctx, updated.ID); err != nil {
+		return nil, err
+	}
+	if err := r.saveSchedule(ctx, updated.ID, calendar); err != nil {
+		return nil, err
+	}
+	return r.withSchedule(ctx, updated)
+}
+
+func (r *CalendarRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.store.DeleteCalendar(ctx, sqlc.DeleteCalendarParams{ID: id, OrgIds: orgScope(ctx)})
+}
+
+// saveSchedule stores the calendar's working hours and holidays. Holiday
+// dates have already been validated.
+func (r *CalendarRepository) saveSchedule(ctx context.Context, id uuid.UUID, calendar domain.BusinessCalendar) error {
+	for _, h := range calendar.Hours {
+		err := r.store.AddCalendarHours(ctx, sqlc.AddCalendarHoursParams{
+			CalendarID:  id,
+			Weekday:     int32(h.Weekday),
+			StartMinute: int32(h.StartMinute),
+			EndMinute:   int32(h.EndMinute),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	for _, h := range calendar.Holidays {
+		day, err := time.Parse(time.DateOnly, h.Date)
+		if err != nil {
+			return err
+		}
+		err = r.store.AddCalendarHoliday(ctx, sqlc.AddCalendarHolidayParams{CalendarID: id, Day: day, Name: h.Name})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *CalendarRepository) withSchedule(ctx context.Context, calendar sqlc.BusinessCalendar) (*domain.BusinessCalendar, error) {
+	hours, err := r.store.ListCalendarHours(ctx, calendar.ID)
+	if err != nil {
+		return nil, err
+	}
+	holidays, err := r.store.ListCalendarHolidays(ctx, calendar.ID)
+	if err != nil {
+		return nil, err
+	}
+	return mapCalendar(calendar, hours, holidays), nil
+}
@@ -0,0 +1,89 @@
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	sqlc "github.com/nickhildpac/ticket-management-app/internal/adapters/db/sqlc"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+)
+
+type ChecklistRepository struct {
+	store sqlc.Store
+}
+
+func NewChecklistRepository(store sqlc.Store) *ChecklistRepository {
+	return &ChecklistRepository{store: store}
+}
+
+func (r *ChecklistRepository) ListByTicket(ctx context.Context, ticketID uuid.UUID) ([]domain.ChecklistItem, error) {
+	rows, err := r.store.ListChecklistItems(ctx, sqlc.ListChecklistItemsParams{TicketID: ticketID, OrgIds: orgScope(ctx)})
+	if err != nil {
+		return nil, err
+	}
+	return mapChecklistItems(rows), nil
+}
+
+func (r *ChecklistRepository) Get(ctx context.Context, id uuid.UUID) (*domain.ChecklistItem, error) {
+	item, err := r.store.GetChecklistItem(ctx, sqlc.GetChecklistItemParams{ID: id, OrgIds: orgScope(ctx)})
+	if err != nil {
+		return nil, err
+	}
+	return mapChecklistItem(item), nil
+}
+
+func (r *ChecklistRepository) Create(ctx context.Context, item domain.ChecklistItem) (*domain.ChecklistItem, error) {
+	created, err := r.store.CreateChecklistItem(ctx, sqlc.CreateChecklistItemParams{
+		TicketID:   item.TicketID,
+		Text:       item.Text,
+		Required:   item.Required,
+		AssigneeID: toNullUUID(item.AssigneeID),
+		DueAt:      toNullTime(item.DueAt),
+		UpdatedAt:  item.UpdatedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapChecklistItem(created), nil
+}
+
+func (r *ChecklistRepository) Update(ctx context.Context, item domain.ChecklistItem) (*domain.ChecklistItem, error) {
+	updated, err := r.store.UpdateChecklistItem(ctx, sqlc.UpdateChecklistItemParams{
+		ID:         item.ID,
+		Text:       item.Text,
+		Done:       item.Done,
+		Required:   item.Required,
+		AssigneeID: toNullUUID(item.AssigneeID),
+		DueAt:      toNullTime(item.DueAt),
+		UpdatedAt:  item.UpdatedAt,
+		OrgIds:     orgScope(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapChecklistItem(updated), nil
+}
+
+func (r *ChecklistRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.store.DeleteChecklistItem(ctx, sqlc.DeleteChecklistItemParams{ID: id, OrgIds: orgScope(ctx)})
+}
+
+func (r *ChecklistRepository) Reorder(ctx context.Context, ticketID uuid.UUID, ids []uuid.UUID) error {
+	return r.store.ReorderChecklistItems(ctx, sqlc.ReorderChecklistItemsParams{
+		Ids:      ids,
+		TicketID: ticketID,
+		OrgIds:   orgScope(ctx),
+	})
+}
+
+func (r *ChecklistRepository) Progress(ctx context.Context, ticketID uuid.UUID) (*domain.ChecklistProgress, error) {
+	row, err := r.store.GetChecklistProgress(ctx, sqlc.GetChecklistProgressParams{TicketID: ticketID, OrgIds: orgScope(ctx)})
+	if err != nil {
+		return nil, err
+	}
+	return &domain.ChecklistProgress{
+		Total:        int(row.Total),
+		Done:         int(row.Done),
+		RequiredOpen: int(row.RequiredOpen),
+	}, nil
+}
@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"database/sql"
 
 	"github.com/google/uuid"
 	sqlc "github.com/nickhildpac/ticket-management-app/internal/adapters/db/sqlc"
@@ -16,11 +17,40 @@ func NewCommentRepository(store sqlc.Store) *CommentRepository {
 	return &CommentRepository{store: store}
 }
 
-func (r *CommentRepository) ListByTicket(ctx context.Context, ticketID uuid.UUID, limit, offset int32) ([]domain.Comment, error) {
+func (r *CommentRepository) ListByTicket(ctx context.Context, ticketID uuid.UUID, visibility *domain.CommentVisibility, limit, offset int32) ([]domain.Comment, error) {
 	rows, err := r.store.ListComment(ctx, sqlc.ListCommentParams{
-		TicketID: ticketID,
-		Offset:   offset,
-		Limit:    limit,
+		TicketID:   ticketID,
+		OrgIds:     orgScope(ctx),
+		Visibility: toNullVisibility(visibility),
+		Offset:     offset,
+		Limit:      limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapComments(rows), nil
+}
+
+func (r *CommentRepository) ListThreadReplies(ctx context.Context, threadIDs []uuid.UUID, visibility *domain.CommentVisibility) ([]domain.Comment, error) {
+	rows, err := r.store.ListCommentReplies(ctx, sqlc.ListCommentRepliesParams{
+		ThreadIds:      threadIDs,
+		IncludeDeleted: true,
+		OrgIds:         orgScope(ctx),
+		Visibility:     toNullVisibility(visibility),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapComments(rows), nil
+}
+
+func (r *CommentRepository) ListReplies(ctx context.Context, threadID uuid.UUID, visibility *domain.CommentVisibility, limit, offset int32) ([]domain.Comment, error) {
+	rows, err := r.store.ListCommentReplies(ctx, sqlc.ListCommentRepliesParams{
+		ThreadIds:  []uuid.UUID{threadID},
+		OrgIds:     orgScope(ctx),
+		Visibility: toNullVisibility(visibility),
+		Limit:      sql.NullInt32{Int32: limit, Valid: true},
+		Offset:     offset,
 	})
 	if err != nil {
 		return nil, err
@@ -29,7 +59,21 @@ func (r *CommentRepository) ListByTicket(ctx context.Context, ticketID uuid.UUID
 }
 
 func (r *CommentRepository) Get(ctx context.Context, id uuid.UUID) (*domain.Comment, error) {
-	comment, err := r.store.GetComment(ctx, id)
+	comment, err := r.store.GetComment(ctx, sqlc.GetCommentParams{ID: id, OrgIds: orgScope(ctx)})
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrCommentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return mapComment(comment), nil
+}
+
+func (r *CommentRepository) GetIncludingDeleted(ctx context.Context, id uuid.UUID) (*domain.Comment, error) {
+	comment, err := r.store.GetCommentIncludingDeleted(ctx, sqlc.GetCommentIncludingDeletedParams{ID: id, OrgIds: orgScope(ctx)})
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrCommentNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -42,9 +86,52 @@ func (r *CommentRepository) Create(ctx context.Context, comment domain.Comment)
 		Description: comment.Description,
 		CreatedBy:   comment.CreatedBy,
 		UpdatedAt:   comment.UpdatedAt,
+		Visibility:  string(comment.Visibility),
+		ParentID:    toNullUUID(comment.ParentID),
+		ThreadID:    toNullUUID(comment.ThreadID),
+		Depth:       int32(comment.Depth),
 	})
 	if err != nil {
 		return nil, err
 	}
 	return mapComment(created), nil
 }
+
+// Update changes the comment's text, keeping the previous text as a revision
+func (r *CommentRepository) Update(ctx context.Context, comment domain.Comment, editedBy uuid.UUID) (*domain.Comment, error) {
+	updated, err := r.store.UpdateComment(ctx, sqlc.UpdateCommentParams{
+		EditedBy:    uuid.NullUUID{UUID: editedBy, Valid: true},
+		ID:          comment.ID,
+		OrgIds:      orgScope(ctx),
+		Description: comment.Description,
+		EditedAt:    toNullTime(comment.EditedAt),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapComment(updated), nil
+}
+
+// Delete hides the comment, keeping its final text as a revision
+func (r *CommentRepository) Delete(ctx context.Context, id uuid.UUID, deletedBy uuid.UUID) error {
+	return r.store.DeleteComment(ctx, sqlc.DeleteCommentParams{
+		DeletedBy: uuid.NullUUID{UUID: deletedBy, Valid: true},
+		ID:        id,
+		OrgIds:    orgScope(ctx),
+	})
+}
+
+func (r *CommentRepository) ListRevisions(ctx context.Context, commentID uuid.UUID) ([]domain.CommentRevision, error) {
+	rows, err := r.store.ListCommentRevisions(ctx, sqlc.ListCommentRevisionsParams{
+		CommentID: commentID,
+		OrgIds:    orgScope(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]domain.CommentRevision, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, mapCommentRevision(row))
+	}
+	return out, nil
+}
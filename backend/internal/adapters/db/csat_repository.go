@@ -0,0 +1,70 @@
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	sqlc "github.com/nickhildpac/ticket-management-app/internal/adapters/db/sqlc"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+)
+
+type CSATRepository struct {
+	store sqlc.Store
+}
+
+func NewCSATRepository(store sqlc.Store) *CSATRepository {
+	return &CSATRepository{store: store}
+}
+
+func (r *CSATRepository) Upsert(ctx context.Context, response domain.CSATResponse) (*domain.CSATResponse, error) {
+	saved, err := r.store.UpsertCSATResponse(ctx, sqlc.UpsertCSATResponseParams{
+		TicketID:   response.TicketID,
+		AssignedTo: response.AssignedTo,
+		Rating:     int32(response.Rating),
+		Comment:    response.Comment,
+		UpdatedAt:  response.UpdatedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapCSATResponse(saved), nil
+}
+
+func (r *CSATRepository) GetByTicket(ctx context.Context, ticketID uuid.UUID) (*domain.CSATResponse, error) {
+	response, err := r.store.GetCSATResponseByTicket(ctx, sqlc.GetCSATResponseByTicketParams{TicketID: ticketID, OrgIds: orgScope(ctx)})
+	if err != nil {
+		return nil, err
+	}
+	return mapCSATResponse(response), nil
+}
+
+func (r *CSATRepository) Summary(ctx context.Context) (*domain.CSATSummary, error) {
+	row, err := r.store.GetCSATSummary(ctx, orgScope(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return &domain.CSATSummary{
+		Responses:     row.Responses,
+		AverageRating: row.AverageRating,
+		Satisfied:     row.Satisfied,
+	}, nil
+}
+
+func (r *CSATRepository) SummaryByAssignee(ctx context.Context) ([]domain.CSATAssigneeSummary, error) {
+	rows, err := r.store.ListCSATByAssignee(ctx, orgScope(ctx))
+	if err != nil {
+		return nil, err
+	}
+	out := make([]domain.CSATAssigneeSummary, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, domain.CSATAssigneeSummary{
+			AssigneeID: row.AssigneeID,
+			CSATSummary: domain.CSATSummary{
+				Responses:     row.Responses,
+				AverageRating: row.AverageRating,
+				Satisfied:     row.Satisfied,
+			},
+		})
+	}
+	return out, nil
+}
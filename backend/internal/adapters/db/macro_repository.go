@@ -0,0 +1,87 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	sqlc "github.com/nickhildpac/ticket-management-app/internal/adapters/db/sqlc"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+)
+
+type MacroRepository struct {
+	store sqlc.Store
+}
+
+func NewMacroRepository(store sqlc.Store) *MacroRepository {
+	return &MacroRepository{store: store}
+}
+
+func (r *MacroRepository) ListVisible(ctx context.Context, userID, orgID uuid.UUID) ([]domain.Macro, error) {
+	rows, err := r.store.ListMacros(ctx, sqlc.ListMacrosParams{
+		OwnerID: userID,
+		OrgID:   orgID,
+		OrgIds:  orgScope(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapMacros(rows), nil
+}
+
+func (r *MacroRepository) Get(ctx context.Context, id uuid.UUID) (*domain.Macro, error) {
+	macro, err := r.store.GetMacro(ctx, sqlc.GetMacroParams{ID: id, OrgIds: orgScope(ctx)})
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrMacroNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return mapMacro(macro), nil
+}
+
+func (r *MacroRepository) Create(ctx context.Context, macro domain.Macro) (*domain.Macro, error) {
+	created, err := r.store.CreateMacro(ctx, sqlc.CreateMacroParams{
+		OrgID:          macro.OrgID,
+		OwnerID:        toNullUUID(macro.OwnerID),
+		CreatedBy:      toNullUUID(macro.CreatedBy),
+		Name:           macro.Name,
+		Reply:          macro.Reply,
+		Visibility:     string(macro.Visibility),
+		State:          toNullInt32(macro.Actions.State),
+		ResolutionCode: string(macro.Actions.ResolutionCode),
+		Priority:       toNullInt32(macro.Actions.Priority),
+		AssignedTo:     macro.Actions.AssignedTo,
+		Labels:         domain.NormalizeLabels(macro.Actions.Labels),
+		UpdatedAt:      macro.UpdatedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapMacro(created), nil
+}
+
+func (r *MacroRepository) Update(ctx context.Context, macro domain.Macro) (*domain.Macro, error) {
+	updated, err := r.store.UpdateMacro(ctx, sqlc.UpdateMacroParams{
+		ID:             macro.ID,
+		OwnerID:        toNullUUID(macro.OwnerID),
+		Name:           macro.Name,
+		Reply:          macro.Reply,
+		Visibility:     string(macro.Visibility),
+		State:          toNullInt32(macro.Actions.State),
+		ResolutionCode: string(macro.Actions.ResolutionCode),
+		Priority:       toNullInt32(macro.Actions.Priority),
+		AssignedTo:     macro.Actions.AssignedTo,
+		Labels:         domain.NormalizeLabels(macro.Actions.Labels),
+		UpdatedAt:      macro.UpdatedAt,
+		OrgIds:         orgScope(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapMacro(updated), nil
+}
+
+func (r *MacroRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.store.DeleteMacro(ctx, sqlc.DeleteMacroParams{ID: id, OrgIds: orgScope(ctx)})
+}
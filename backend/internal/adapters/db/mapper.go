@@ -1,6 +1,10 @@
 package db
 
 import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
 	sqlc "github.com/nickhildpac/ticket-management-app/internal/adapters/db/sqlc"
 	"github.com/nickhildpac/ticket-management-app/internal/domain"
 )
@@ -14,28 +18,114 @@ func mapUser(u sqlc.User) *domain.User {
 	}
 
 	return &domain.User{
-		ID:             u.ID,
-		HashedPassword: u.HashedPassword,
-		FirstName:      u.FirstName,
-		LastName:       u.LastName,
-		Email:          u.Email,
-		Role:           role,
-		UpdatedAt:      u.UpdatedAt,
-		CreatedAt:      u.CreatedAt,
+		ID:              u.ID,
+		HashedPassword:  u.HashedPassword,
+		FirstName:       u.FirstName,
+		LastName:        u.LastName,
+		Email:           u.Email,
+		Role:            role,
+		OrgID:           u.OrgID,
+		SessionVersion:  u.SessionVersion,
+		EmailVerifiedAt: fromNullTime(u.EmailVerifiedAt),
+		UpdatedAt:       u.UpdatedAt,
+		CreatedAt:       u.CreatedAt,
+	}
+}
+
+func mapRefreshToken(t sqlc.RefreshToken) *domain.RefreshToken {
+	return &domain.RefreshToken{
+		ID:        t.ID,
+		UserID:    t.UserID,
+		FamilyID:  t.FamilyID,
+		TokenHash: t.TokenHash,
+		ExpiresAt: t.ExpiresAt,
+		UsedAt:    fromNullTime(t.UsedAt),
+		RevokedAt: fromNullTime(t.RevokedAt),
+		CreatedAt: t.CreatedAt,
 	}
 }
 
+func mapUserToken(t sqlc.UserToken) *domain.UserToken {
+	return &domain.UserToken{
+		ID:        t.ID,
+		UserID:    t.UserID,
+		Purpose:   domain.TokenPurpose(t.Purpose),
+		TokenHash: t.TokenHash,
+		ExpiresAt: t.ExpiresAt,
+		UsedAt:    fromNullTime(t.UsedAt),
+		CreatedAt: t.CreatedAt,
+	}
+}
+
+func mapOrganization(o sqlc.Organization) *domain.Organization {
+	return &domain.Organization{
+		ID:          o.ID,
+		Name:        o.Name,
+		EmailDomain: o.EmailDomain.String,
+		CalendarID:  fromNullUUID(o.CalendarID),
+		CreatedAt:   o.CreatedAt,
+	}
+}
+
+func mapTeam(t sqlc.Team, members []uuid.UUID) *domain.Team {
+	return &domain.Team{
+		ID:         t.ID,
+		OrgID:      t.OrgID,
+		Name:       t.Name,
+		LeadID:     fromNullUUID(t.LeadID),
+		Members:    members,
+		CalendarID: fromNullUUID(t.CalendarID),
+		CreatedAt:  t.CreatedAt,
+		UpdatedAt:  t.UpdatedAt,
+	}
+}
+
+func mapCalendar(c sqlc.BusinessCalendar, hours []sqlc.CalendarHour, holidays []sqlc.CalendarHoliday) *domain.BusinessCalendar {
+	out := &domain.BusinessCalendar{
+		ID:        c.ID,
+		OrgID:     c.OrgID,
+		Name:      c.Name,
+		TimeZone:  c.TimeZone,
+		Hours:     make([]domain.WorkingHours, len(hours)),
+		Holidays:  make([]domain.Holiday, len(holidays)),
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+	for i, h := range hours {
+		out.Hours[i] = domain.WorkingHours{
+			Weekday:     time.Weekday(h.Weekday),
+			StartMinute: int(h.StartMinute),
+			EndMinute:   int(h.EndMinute),
+		}
+	}
+	for i, h := range holidays {
+		out.Holidays[i] = domain.Holiday{Date: h.Day.Format(time.DateOnly), Name: h.Name}
+	}
+	return out
+}
+
 func mapTicket(t sqlc.Ticket) *domain.Ticket {
 	return &domain.Ticket{
-		ID:          t.ID,
-		CreatedBy:   t.CreatedBy,
-		AssignedTo:  t.AssignedTo,
-		Title:       t.Title,
-		Description: t.Description,
-		State:       domain.TicketState(t.State),
-		Priority:    domain.TicketPriority(t.Priority),
-		CreatedAt:   t.CreatedAt,
-		UpdatedAt:   t.UpdatedAt,
+		ID:                 t.ID,
+		OrgID:              t.OrgID,
+		CreatedBy:          t.CreatedBy,
+		AssignedTo:         t.AssignedTo,
+		TeamID:             fromNullUUID(t.TeamID),
+		Readers:            t.Readers,
+		Title:              t.Title,
+		Description:        t.Description,
+		Type:               t.Type,
+		State:              domain.TicketState(t.State),
+		Priority:           domain.TicketPriority(t.Priority),
+		ResolutionCode:     domain.ResolutionCode(t.ResolutionCode),
+		StateReason:        t.StateReason,
+		Impact:             domain.TicketImpact(t.Impact),
+		Urgency:            domain.TicketUrgency(t.Urgency),
+		PriorityOverridden: t.PriorityOverridden,
+		Rank:               t.Rank,
+		Labels:             t.Labels,
+		CreatedAt:          t.CreatedAt,
+		UpdatedAt:          t.UpdatedAt,
 	}
 }
 
@@ -53,8 +143,14 @@ func mapComment(c sqlc.Comment) *domain.Comment {
 		TicketID:    c.TicketID,
 		CreatedBy:   c.CreatedBy,
 		Description: c.Description,
+		Visibility:  domain.CommentVisibility(c.Visibility),
+		EditedAt:    fromNullTime(c.EditedAt),
 		CreatedAt:   c.CreatedAt,
 		UpdatedAt:   c.UpdatedAt,
+		ParentID:    fromNullUUID(c.ParentID),
+		ThreadID:    fromNullUUID(c.ThreadID),
+		Depth:       int(c.Depth),
+		DeletedAt:   fromNullTime(c.DeletedAt),
 	}
 }
 
@@ -65,3 +161,299 @@ func mapComments(rows []sqlc.Comment) []domain.Comment {
 	}
 	return out
 }
+
+func mapCommentRevision(r sqlc.CommentRevision) domain.CommentRevision {
+	return domain.CommentRevision{
+		ID:          r.ID,
+		CommentID:   r.CommentID,
+		Description: r.Description,
+		EditedBy:    fromNullUUID(r.EditedBy),
+		CreatedAt:   r.CreatedAt,
+	}
+}
+
+func mapMention(m sqlc.Mention) domain.Mention {
+	return domain.Mention{
+		ID:        m.ID,
+		TicketID:  m.TicketID,
+		CommentID: fromNullUUID(m.CommentID),
+		UserID:    m.UserID,
+		CreatedBy: fromNullUUID(m.CreatedBy),
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+func mapMentions(rows []sqlc.Mention) []domain.Mention {
+	out := make([]domain.Mention, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, mapMention(row))
+	}
+	return out
+}
+
+func mapCSATResponse(c sqlc.CsatResponse) *domain.CSATResponse {
+	return &domain.CSATResponse{
+		ID:         c.ID,
+		TicketID:   c.TicketID,
+		AssignedTo: c.AssignedTo,
+		Rating:     int(c.Rating),
+		Comment:    c.Comment,
+		CreatedAt:  c.CreatedAt,
+		UpdatedAt:  c.UpdatedAt,
+	}
+}
+
+func mapTicketEvent(e sqlc.TicketEvent) *domain.TicketEvent {
+	return &domain.TicketEvent{
+		ID:        e.ID,
+		TicketID:  e.TicketID,
+		ActorID:   e.ActorID,
+		Kind:      domain.TicketEventKind(e.Kind),
+		OldValue:  e.OldValue,
+		NewValue:  e.NewValue,
+		Reason:    e.Reason,
+		Note:      e.Note,
+		CreatedAt: e.CreatedAt,
+	}
+}
+
+func mapTicketEvents(rows []sqlc.TicketEvent) []domain.TicketEvent {
+	out := make([]domain.TicketEvent, 0, len(rows))
+	for _, e := range rows {
+		out = append(out, *mapTicketEvent(e))
+	}
+	return out
+}
+
+func mapChecklistItem(c sqlc.ChecklistItem) *domain.ChecklistItem {
+	return &domain.ChecklistItem{
+		ID:         c.ID,
+		TicketID:   c.TicketID,
+		Position:   int(c.Position),
+		Text:       c.Text,
+		Done:       c.Done,
+		Required:   c.Required,
+		AssigneeID: fromNullUUID(c.AssigneeID),
+		DueAt:      fromNullTime(c.DueAt),
+		CreatedAt:  c.CreatedAt,
+		UpdatedAt:  c.UpdatedAt,
+	}
+}
+
+func mapChecklistItems(rows []sqlc.ChecklistItem) []domain.ChecklistItem {
+	out := make([]domain.ChecklistItem, 0, len(rows))
+	for _, c := range rows {
+		out = append(out, *mapChecklistItem(c))
+	}
+	return out
+}
+
+func mapSavedView(v sqlc.SavedView) *domain.SavedView {
+	view := &domain.SavedView{
+		ID:      v.ID,
+		OwnerID: v.OwnerID,
+		OrgID:   v.OrgID,
+		Name:    v.Name,
+		Filter: domain.TicketFilter{
+			States:       make([]domain.TicketState, 0, len(v.States)),
+			Priorities:   make([]domain.TicketPriority, 0, len(v.Priorities)),
+			AssigneeID:   fromNullUUID(v.AssigneeID),
+			AssignedToMe: v.AssignedToMe,
+			Unassigned:   v.Unassigned,
+			CreatedBy:    fromNullUUID(v.CreatorID),
+			CreatedByMe:  v.CreatedByMe,
+			Search:       v.Search,
+			Sort:         domain.TicketSort(v.Sort),
+		},
+		Columns:      v.Columns,
+		SharedTeamID: fromNullUUID(v.SharedTeamID),
+		CreatedAt:    v.CreatedAt,
+		UpdatedAt:    v.UpdatedAt,
+	}
+	for _, st := range v.States {
+		view.Filter.States = append(view.Filter.States, domain.TicketState(st))
+	}
+	for _, p := range v.Priorities {
+		view.Filter.Priorities = append(view.Filter.Priorities, domain.TicketPriority(p))
+	}
+	view.SharedRole = fromNullRole(v.SharedRole)
+	return view
+}
+
+func mapSavedViews(rows []sqlc.SavedView) []domain.SavedView {
+	out := make([]domain.SavedView, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, *mapSavedView(row))
+	}
+	return out
+}
+
+func mapMacro(m sqlc.Macro) *domain.Macro {
+	macro := &domain.Macro{
+		ID:         m.ID,
+		OrgID:      m.OrgID,
+		OwnerID:    fromNullUUID(m.OwnerID),
+		CreatedBy:  fromNullUUID(m.CreatedBy),
+		Name:       m.Name,
+		Reply:      m.Reply,
+		Visibility: domain.CommentVisibility(m.Visibility),
+		Actions: domain.MacroActions{
+			ResolutionCode: domain.ResolutionCode(m.ResolutionCode),
+			AssignedTo:     m.AssignedTo,
+			Labels:         m.Labels,
+		},
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+	if m.State.Valid {
+		state := domain.TicketState(m.State.Int32)
+		macro.Actions.State = &state
+	}
+	if m.Priority.Valid {
+		priority := domain.TicketPriority(m.Priority.Int32)
+		macro.Actions.Priority = &priority
+	}
+	return macro
+}
+
+func mapMacros(rows []sqlc.Macro) []domain.Macro {
+	out := make([]domain.Macro, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, *mapMacro(row))
+	}
+	return out
+}
+
+func toNullInt32[T ~int](v *T) sql.NullInt32 {
+	if v == nil {
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: int32(*v), Valid: true}
+}
+
+func toInt32s[T ~int](values []T) []int32 {
+	out := make([]int32, 0, len(values))
+	for _, v := range values {
+		out = append(out, int32(v))
+	}
+	return out
+}
+
+func mapApprovalStep(s sqlc.ApprovalStep) domain.ApprovalStep {
+	return domain.ApprovalStep{
+		ID:           s.ID,
+		TicketType:   s.TicketType,
+		Position:     int(s.Position),
+		ApproverID:   fromNullUUID(s.ApproverID),
+		ApproverRole: fromNullRole(s.ApproverRole),
+	}
+}
+
+func mapTicketApproval(a sqlc.TicketApproval) *domain.TicketApproval {
+	return &domain.TicketApproval{
+		ID:           a.ID,
+		TicketID:     a.TicketID,
+		Position:     int(a.Position),
+		ApproverID:   fromNullUUID(a.ApproverID),
+		ApproverRole: fromNullRole(a.ApproverRole),
+		Status:       domain.ApprovalStatus(a.Status),
+		DecidedBy:    fromNullUUID(a.DecidedBy),
+		Comment:      a.Comment,
+		DecidedAt:    fromNullTime(a.DecidedAt),
+		CreatedAt:    a.CreatedAt,
+	}
+}
+
+func mapTicketApprovals(rows []sqlc.TicketApproval) []domain.TicketApproval {
+	out := make([]domain.TicketApproval, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, *mapTicketApproval(row))
+	}
+	return out
+}
+
+func mapOutOfOffice(o sqlc.OutOfOffice) *domain.OutOfOffice {
+	return &domain.OutOfOffice{
+		ID:         o.ID,
+		UserID:     o.UserID,
+		StartsAt:   o.StartsAt,
+		EndsAt:     o.EndsAt,
+		DelegateID: fromNullUUID(o.DelegateID),
+		Note:       o.Note,
+		CreatedAt:  o.CreatedAt,
+	}
+}
+
+func mapTransfer(t sqlc.TicketTransfer) *domain.Transfer {
+	return &domain.Transfer{
+		ID:         t.ID,
+		TicketID:   t.TicketID,
+		FromUserID: t.FromUserID,
+		ToUserID:   fromNullUUID(t.ToUserID),
+		ToTeamID:   fromNullUUID(t.ToTeamID),
+		Note:       t.Note,
+		Status:     domain.TransferStatus(t.Status),
+		DecidedBy:  fromNullUUID(t.DecidedBy),
+		Reason:     t.Reason,
+		DecidedAt:  fromNullTime(t.DecidedAt),
+		CreatedAt:  t.CreatedAt,
+	}
+}
+
+func mapTransfers(rows []sqlc.TicketTransfer) []domain.Transfer {
+	out := make([]domain.Transfer, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, *mapTransfer(row))
+	}
+	return out
+}
+
+func toNullUUID(id *uuid.UUID) uuid.NullUUID {
+	if id == nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: *id, Valid: true}
+}
+
+func fromNullUUID(id uuid.NullUUID) *uuid.UUID {
+	if !id.Valid {
+		return nil
+	}
+	return &id.UUID
+}
+
+func toNullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
+
+func fromNullTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+func toNullRole(role *domain.UserRole) sql.NullString {
+	if role == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: string(*role), Valid: true}
+}
+
+func fromNullRole(role sql.NullString) *domain.UserRole {
+	if !role.Valid {
+		return nil
+	}
+	r := domain.UserRole(role.String)
+	return &r
+}
+
+func toNullVisibility(visibility *domain.CommentVisibility) sql.NullString {
+	if visibility == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: string(*visibility), Valid: true}
+}
@@ -0,0 +1,43 @@
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	sqlc "github.com/nickhildpac/ticket-management-app/internal/adapters/db/sqlc"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+)
+
+type MentionRepository struct {
+	store sqlc.Store
+}
+
+func NewMentionRepository(store sqlc.Store) *MentionRepository {
+	return &MentionRepository{store: store}
+}
+
+func (r *MentionRepository) Create(ctx context.Context, ticketID uuid.UUID, commentID *uuid.UUID, userIDs []uuid.UUID, createdBy uuid.UUID) ([]domain.Mention, error) {
+	rows, err := r.store.CreateMentions(ctx, sqlc.CreateMentionsParams{
+		TicketID:  ticketID,
+		CommentID: toNullUUID(commentID),
+		UserIds:   userIDs,
+		CreatedBy: uuid.NullUUID{UUID: createdBy, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapMentions(rows), nil
+}
+
+func (r *MentionRepository) ListByUser(ctx context.Context, userID uuid.UUID, limit, offset int32) ([]domain.Mention, error) {
+	rows, err := r.store.ListMentionsByUser(ctx, sqlc.ListMentionsByUserParams{
+		UserID: userID,
+		OrgIds: orgScope(ctx),
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapMentions(rows), nil
+}
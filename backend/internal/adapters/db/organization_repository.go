@@ -0,0 +1,92 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	sqlc "github.com/nickhildpac/ticket-management-app/internal/adapters/db/sqlc"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+)
+
+type OrganizationRepository struct {
+	store sqlc.Store
+}
+
+func NewOrganizationRepository(store sqlc.Store) *OrganizationRepository {
+	return &OrganizationRepository{store: store}
+}
+
+func (r *OrganizationRepository) List(ctx context.Context) ([]domain.Organization, error) {
+	rows, err := r.store.ListOrganizations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]domain.Organization, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, *mapOrganization(row))
+	}
+	return out, nil
+}
+
+func (r *OrganizationRepository) Get(ctx context.Context, id uuid.UUID) (*domain.Organization, error) {
+	org, err := r.store.GetOrganization(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return mapOrganization(org), nil
+}
+
+func (r *OrganizationRepository) GetByDomain(ctx context.Context, domainName string) (*domain.Organization, error) {
+	org, err := r.store.GetOrganizationByDomain(ctx, sql.NullString{String: domainName, Valid: true})
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return mapOrganization(org), nil
+}
+
+func (r *OrganizationRepository) Create(ctx context.Context, org domain.Organization) (*domain.Organization, error) {
+	created, err := r.store.CreateOrganization(ctx, sqlc.CreateOrganizationParams{
+		Name:        org.Name,
+		EmailDomain: sql.NullString{String: org.EmailDomain, Valid: org.EmailDomain != ""},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapOrganization(created), nil
+}
+
+func (r *OrganizationRepository) MoveUser(ctx context.Context, userID, orgID uuid.UUID) (*domain.User, error) {
+	updated, err := r.store.UpdateUserOrg(ctx, sqlc.UpdateUserOrgParams{
+		ID:        userID,
+		OrgID:     orgID,
+		UpdatedAt: time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapUser(updated), nil
+}
+
+func (r *OrganizationRepository) GrantAccess(ctx context.Context, userID, orgID uuid.UUID) error {
+	return r.store.GrantOrgAccess(ctx, sqlc.GrantOrgAccessParams{UserID: userID, OrgID: orgID})
+}
+
+func (r *OrganizationRepository) RevokeAccess(ctx context.Context, userID, orgID uuid.UUID) error {
+	return r.store.RevokeOrgAccess(ctx, sqlc.RevokeOrgAccessParams{UserID: userID, OrgID: orgID})
+}
+
+func (r *OrganizationRepository) SetCalendar(ctx context.Context, orgID uuid.UUID, calendarID *uuid.UUID) (*domain.Organization, error) {
+	org, err := r.store.SetOrganizationCalendar(ctx, sqlc.SetOrganizationCalendarParams{
+		CalendarID: toNullUUID(calendarID),
+		ID:         orgID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapOrganization(org), nil
+}
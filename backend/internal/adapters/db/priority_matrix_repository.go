@@ -0,0 +1,50 @@
+package db
+
+import (
+	"context"
+
+	sqlc "github.com/nickhildpac/ticket-management-app/internal/adapters/db/sqlc"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+)
+
+type PriorityMatrixRepository struct {
+	store sqlc.Store
+}
+
+func NewPriorityMatrixRepository(store sqlc.Store) *PriorityMatrixRepository {
+	return &PriorityMatrixRepository{store: store}
+}
+
+func (r *PriorityMatrixRepository) Get(ctx context.Context) (domain.PriorityMatrix, error) {
+	rows, err := r.store.ListPriorityMatrix(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m := domain.PriorityMatrix{}
+	for _, row := range rows {
+		impact := domain.TicketImpact(row.Impact)
+		if m[impact] == nil {
+			m[impact] = map[domain.TicketUrgency]domain.TicketPriority{}
+		}
+		m[impact][domain.TicketUrgency(row.Urgency)] = domain.TicketPriority(row.Priority)
+	}
+	return m, nil
+}
+
+// Save stores the matrix and reprioritizes the tickets that follow it, all
+// or nothing
+func (r *PriorityMatrixRepository) Save(ctx context.Context, matrix domain.PriorityMatrix) error {
+	return r.store.ExecTx(ctx, func(ctx context.Context) error {
+		for _, entry := range matrix.Entries() {
+			err := r.store.UpsertPriorityMatrixEntry(ctx, sqlc.UpsertPriorityMatrixEntryParams{
+				Impact:   int32(entry.Impact),
+				Urgency:  int32(entry.Urgency),
+				Priority: int32(entry.Priority),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return r.store.ReapplyPriorityMatrix(ctx)
+	})
+}
@@ -0,0 +1,53 @@
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	sqlc "github.com/nickhildpac/ticket-management-app/internal/adapters/db/sqlc"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+)
+
+type ReactionRepository struct {
+	store sqlc.Store
+}
+
+func NewReactionRepository(store sqlc.Store) *ReactionRepository {
+	return &ReactionRepository{store: store}
+}
+
+func (r *ReactionRepository) Add(ctx context.Context, commentID, userID uuid.UUID, emoji string) error {
+	return r.store.AddCommentReaction(ctx, sqlc.AddCommentReactionParams{
+		CommentID: commentID,
+		UserID:    userID,
+		Emoji:     emoji,
+	})
+}
+
+func (r *ReactionRepository) Remove(ctx context.Context, commentID, userID uuid.UUID, emoji string) error {
+	return r.store.DeleteCommentReaction(ctx, sqlc.DeleteCommentReactionParams{
+		CommentID: commentID,
+		UserID:    userID,
+		Emoji:     emoji,
+	})
+}
+
+func (r *ReactionRepository) Summarize(ctx context.Context, commentIDs []uuid.UUID, userID uuid.UUID) (map[uuid.UUID][]domain.Reaction, error) {
+	rows, err := r.store.ListCommentReactions(ctx, sqlc.ListCommentReactionsParams{
+		UserID:     userID,
+		CommentIds: commentIDs,
+		OrgIds:     orgScope(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[uuid.UUID][]domain.Reaction, len(commentIDs))
+	for _, row := range rows {
+		out[row.CommentID] = append(out[row.CommentID], domain.Reaction{
+			Emoji:       row.Emoji,
+			Count:       int(row.Count),
+			ReactedByMe: row.Reacted,
+		})
+	}
+	return out, nil
+}
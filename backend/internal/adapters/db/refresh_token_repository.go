@@ -0,0 +1,65 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	sqlc "github.com/nickhildpac/ticket-management-app/internal/adapters/db/sqlc"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+)
+
+type RefreshTokenRepository struct {
+	store sqlc.Store
+}
+
+func NewRefreshTokenRepository(store sqlc.Store) *RefreshTokenRepository {
+	return &RefreshTokenRepository{store: store}
+}
+
+func (r *RefreshTokenRepository) Create(ctx context.Context, token domain.RefreshToken) (*domain.RefreshToken, error) {
+	created, err := r.store.CreateRefreshToken(ctx, sqlc.CreateRefreshTokenParams{
+		UserID:    token.UserID,
+		FamilyID:  token.FamilyID,
+		TokenHash: token.TokenHash,
+		ExpiresAt: token.ExpiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapRefreshToken(created), nil
+}
+
+func (r *RefreshTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+	token, err := r.store.GetRefreshTokenByHash(ctx, tokenHash)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	return mapRefreshToken(token), nil
+}
+
+func (r *RefreshTokenRepository) Use(ctx context.Context, id uuid.UUID) (*domain.RefreshToken, error) {
+	token, err := r.store.UseRefreshToken(ctx, id)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrRefreshTokenReused
+	}
+	if err != nil {
+		return nil, err
+	}
+	return mapRefreshToken(token), nil
+}
+
+func (r *RefreshTokenRepository) RevokeFamily(ctx context.Context, familyID uuid.UUID) error {
+	return r.store.RevokeRefreshTokenFamily(ctx, familyID)
+}
+
+func (r *RefreshTokenRepository) RevokeUser(ctx context.Context, userID uuid.UUID) error {
+	return r.store.RevokeUserRefreshTokens(ctx, userID)
+}
+
+func (r *RefreshTokenRepository) DeleteExpired(ctx context.Context) error {
+	return r.store.DeleteExpiredRefreshTokens(ctx)
+}
@@ -0,0 +1,94 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	sqlc "github.com/nickhildpac/ticket-management-app/internal/adapters/db/sqlc"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+)
+
+type SavedViewRepository struct {
+	store sqlc.Store
+}
+
+func NewSavedViewRepository(store sqlc.Store) *SavedViewRepository {
+	return &SavedViewRepository{store: store}
+}
+
+func (r *SavedViewRepository) ListVisible(ctx context.Context, userID, orgID uuid.UUID, role domain.UserRole, teamIDs []uuid.UUID) ([]domain.SavedView, error) {
+	rows, err := r.store.ListSavedViews(ctx, sqlc.ListSavedViewsParams{
+		OwnerID:    userID,
+		SharedRole: sql.NullString{String: string(role), Valid: true},
+		OrgID:      orgID,
+		TeamIds:    teamIDs,
+		OrgIds:     orgScope(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapSavedViews(rows), nil
+}
+
+func (r *SavedViewRepository) Get(ctx context.Context, id uuid.UUID) (*domain.SavedView, error) {
+	view, err := r.store.GetSavedView(ctx, sqlc.GetSavedViewParams{ID: id, OrgIds: orgScope(ctx)})
+	if err != nil {
+		return nil, err
+	}
+	return mapSavedView(view), nil
+}
+
+func (r *SavedViewRepository) Create(ctx context.Context, view domain.SavedView) (*domain.SavedView, error) {
+	created, err := r.store.CreateSavedView(ctx, sqlc.CreateSavedViewParams{
+		OwnerID:      view.OwnerID,
+		Name:         view.Name,
+		States:       toInt32s(view.Filter.States),
+		Priorities:   toInt32s(view.Filter.Priorities),
+		AssigneeID:   toNullUUID(view.Filter.AssigneeID),
+		AssignedToMe: view.Filter.AssignedToMe,
+		Unassigned:   view.Filter.Unassigned,
+		CreatorID:    toNullUUID(view.Filter.CreatedBy),
+		CreatedByMe:  view.Filter.CreatedByMe,
+		Search:       view.Filter.Search,
+		Sort:         string(view.Filter.Sort),
+		Columns:      view.Columns,
+		SharedRole:   toNullRole(view.SharedRole),
+		UpdatedAt:    view.UpdatedAt,
+		OrgID:        view.OrgID,
+		SharedTeamID: toNullUUID(view.SharedTeamID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapSavedView(created), nil
+}
+
+func (r *SavedViewRepository) Update(ctx context.Context, view domain.SavedView) (*domain.SavedView, error) {
+	updated, err := r.store.UpdateSavedView(ctx, sqlc.UpdateSavedViewParams{
+		ID:           view.ID,
+		Name:         view.Name,
+		States:       toInt32s(view.Filter.States),
+		Priorities:   toInt32s(view.Filter.Priorities),
+		AssigneeID:   toNullUUID(view.Filter.AssigneeID),
+		AssignedToMe: view.Filter.AssignedToMe,
+		Unassigned:   view.Filter.Unassigned,
+		CreatorID:    toNullUUID(view.Filter.CreatedBy),
+		CreatedByMe:  view.Filter.CreatedByMe,
+		Search:       view.Filter.Search,
+		Sort:         string(view.Filter.Sort),
+		Columns:      view.Columns,
+		SharedRole:   toNullRole(view.SharedRole),
+		SharedTeamID: toNullUUID(view.SharedTeamID),
+		UpdatedAt:    view.UpdatedAt,
+		OrgIds:       orgScope(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapSavedView(updated), nil
+}
+
+func (r *SavedViewRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.store.DeleteSavedView(ctx, sqlc.DeleteSavedViewParams{ID: id, OrgIds: orgScope(ctx)})
+}
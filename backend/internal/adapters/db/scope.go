@@ -0,0 +1,23 @@
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/nickhildpac/ticket-management-app/pkg/configs"
+)
+
+// orgScope returns the organizations the caller may access, for the org_ids
+// parameter every tenant-scoped query takes. It is nil, and so unscoped,
+// only outside an authenticated request: logging in, refreshing a token or
+// following a link mailed to the user, such as a survey or a password reset.
+func orgScope(ctx context.Context) []uuid.UUID {
+	orgs, ok := ctx.Value(configs.UserOrgsKey).([]uuid.UUID)
+	if !ok {
+		return nil
+	}
+	if orgs == nil {
+		return []uuid.UUID{}
+	}
+	return orgs
+}
@@ -7,20 +7,26 @@ package db
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 const createComment = `-- name: CreateComment :one
-INSERT INTO comments (description, ticket_id, created_by, updated_at ) VALUES ($1, $2, $3, $4) RETURNING id, ticket_id, created_by, description, created_at, updated_at
+INSERT INTO comments (description, ticket_id, created_by, updated_at, visibility, parent_id, thread_id, depth) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id, ticket_id, created_by, description, created_at, updated_at, edited_at, visibility, parent_id, thread_id, depth, deleted_at
 `
 
 type CreateCommentParams struct {
-	Description string    `json:"description"`
-	TicketID    uuid.UUID `json:"ticket_id"`
-	CreatedBy   uuid.UUID `json:"created_by"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	Description string        `json:"description"`
+	TicketID    uuid.UUID     `json:"ticket_id"`
+	CreatedBy   uuid.UUID     `json:"created_by"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+	Visibility  string        `json:"visibility"`
+	ParentID    uuid.NullUUID `json:"parent_id"`
+	ThreadID    uuid.NullUUID `json:"thread_id"`
+	Depth       int32         `json:"depth"`
 }
 
 func (q *Queries) CreateComment(ctx context.Context, arg CreateCommentParams) (Comment, error) {
@@ -29,6 +35,10 @@ func (q *Queries) CreateComment(ctx context.Context, arg CreateCommentParams) (C
 		arg.TicketID,
 		arg.CreatedBy,
 		arg.UpdatedAt,
+		arg.Visibility,
+		arg.ParentID,
+		arg.ThreadID,
+		arg.Depth,
 	)
 	var i Comment
 	err := row.Scan(
@@ -38,25 +48,89 @@ func (q *Queries) CreateComment(ctx context.Context, arg CreateCommentParams) (C
 		&i.Description,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.EditedAt,
+		&i.Visibility,
+		&i.ParentID,
+		&i.ThreadID,
+		&i.Depth,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
 const deleteComment = `-- name: DeleteComment :exec
-DELETE FROM comments WHERE id = $1
+WITH revision AS (
+    INSERT INTO comment_revisions (comment_id, description, edited_by)
+    SELECT id, description, $1 FROM comments WHERE id = $2 AND deleted_at IS NULL AND ($3::uuid[] IS NULL OR EXISTS (SELECT 1 FROM tickets t WHERE t.id = comments.ticket_id AND t.org_id = ANY($3::uuid[])))
+)
+UPDATE comments SET deleted_at = now() WHERE id = $2 AND deleted_at IS NULL AND ($3::uuid[] IS NULL OR EXISTS (SELECT 1 FROM tickets t WHERE t.id = comments.ticket_id AND t.org_id = ANY($3::uuid[])))
+`
+
+type DeleteCommentParams struct {
+	DeletedBy uuid.NullUUID `json:"deleted_by"`
+	ID        uuid.UUID     `json:"id"`
+	OrgIds    []uuid.UUID   `json:"org_ids"`
+}
+
+func (q *Queries) DeleteComment(ctx context.Context, arg DeleteCommentParams) error {
+	_, err := q.db.ExecContext(ctx, deleteComment, arg.DeletedBy, arg.ID, pq.Array(arg.OrgIds))
+	return err
+}
+
+const deleteTicketCommentRevisions = `-- name: DeleteTicketCommentRevisions :exec
+DELETE FROM comment_revisions WHERE comment_id IN (SELECT c.id FROM comments c JOIN tickets t ON t.id = c.ticket_id WHERE c.ticket_id = $1 AND ($2::uuid[] IS NULL OR t.org_id = ANY($2::uuid[])))
 `
 
-func (q *Queries) DeleteComment(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.ExecContext(ctx, deleteComment, id)
+type DeleteTicketCommentRevisionsParams struct {
+	TicketID uuid.UUID   `json:"ticket_id"`
+	OrgIds   []uuid.UUID `json:"org_ids"`
+}
+
+func (q *Queries) DeleteTicketCommentRevisions(ctx context.Context, arg DeleteTicketCommentRevisionsParams) error {
+	_, err := q.db.ExecContext(ctx, deleteTicketCommentRevisions, arg.TicketID, pq.Array(arg.OrgIds))
 	return err
 }
 
 const getComment = `-- name: GetComment :one
-SELECT id, ticket_id, created_by, description, created_at, updated_at FROM comments WHERE id = $1 LIMIT 1
+SELECT id, ticket_id, created_by, description, created_at, updated_at, edited_at, visibility, parent_id, thread_id, depth, deleted_at FROM comments WHERE id = $1 AND deleted_at IS NULL AND ($2::uuid[] IS NULL OR EXISTS (SELECT 1 FROM tickets t WHERE t.id = comments.ticket_id AND t.org_id = ANY($2::uuid[]))) LIMIT 1
+`
+
+type GetCommentParams struct {
+	ID     uuid.UUID   `json:"id"`
+	OrgIds []uuid.UUID `json:"org_ids"`
+}
+
+func (q *Queries) GetComment(ctx context.Context, arg GetCommentParams) (Comment, error) {
+	row := q.db.QueryRowContext(ctx, getComment, arg.ID, pq.Array(arg.OrgIds))
+	var i Comment
+	err := row.Scan(
+		&i.ID,
+		&i.TicketID,
+		&i.CreatedBy,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.EditedAt,
+		&i.Visibility,
+		&i.ParentID,
+		&i.ThreadID,
+		&i.Depth,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const getCommentIncludingDeleted = `-- name: GetCommentIncludingDeleted :one
+SELECT id, ticket_id, created_by, description, created_at, updated_at, edited_at, visibility, parent_id, thread_id, depth, deleted_at FROM comments WHERE id = $1 AND ($2::uuid[] IS NULL OR EXISTS (SELECT 1 FROM tickets t WHERE t.id = comments.ticket_id AND t.org_id = ANY($2::uuid[]))) LIMIT 1
 `
 
-func (q *Queries) GetComment(ctx context.Context, id uuid.UUID) (Comment, error) {
-	row := q.db.QueryRowContext(ctx, getComment, id)
+type GetCommentIncludingDeletedParams struct {
+	ID     uuid.UUID   `json:"id"`
+	OrgIds []uuid.UUID `json:"org_ids"`
+}
+
+func (q *Queries) GetCommentIncludingDeleted(ctx context.Context, arg GetCommentIncludingDeletedParams) (Comment, error) {
+	row := q.db.QueryRowContext(ctx, getCommentIncludingDeleted, arg.ID, pq.Array(arg.OrgIds))
 	var i Comment
 	err := row.Scan(
 		&i.ID,
@@ -65,22 +139,36 @@ func (q *Queries) GetComment(ctx context.Context, id uuid.UUID) (Comment, error)
 		&i.Description,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.EditedAt,
+		&i.Visibility,
+		&i.ParentID,
+		&i.ThreadID,
+		&i.Depth,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
 const listComment = `-- name: ListComment :many
-SELECT id, ticket_id, created_by, description, created_at, updated_at FROM comments WHERE ticket_id=$1 ORDER BY created_at LIMIT $2 OFFSET $3
+SELECT id, ticket_id, created_by, description, created_at, updated_at, edited_at, visibility, parent_id, thread_id, depth, deleted_at FROM comments WHERE ticket_id = $1 AND parent_id IS NULL AND (deleted_at IS NULL OR EXISTS (SELECT 1 FROM comments r WHERE r.thread_id = comments.id AND r.deleted_at IS NULL)) AND ($2::uuid[] IS NULL OR EXISTS (SELECT 1 FROM tickets t WHERE t.id = comments.ticket_id AND t.org_id = ANY($2::uuid[]))) AND ($3::varchar IS NULL OR visibility = $3::varchar) ORDER BY created_at LIMIT $4 OFFSET $5
 `
 
 type ListCommentParams struct {
-	TicketID uuid.UUID `json:"ticket_id"`
-	Limit    int32     `json:"limit"`
-	Offset   int32     `json:"offset"`
+	TicketID   uuid.UUID      `json:"ticket_id"`
+	OrgIds     []uuid.UUID    `json:"org_ids"`
+	Visibility sql.NullString `json:"visibility"`
+	Limit      int32          `json:"limit"`
+	Offset     int32          `json:"offset"`
 }
 
 func (q *Queries) ListComment(ctx context.Context, arg ListCommentParams) ([]Comment, error) {
-	rows, err := q.db.QueryContext(ctx, listComment, arg.TicketID, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listComment,
+		arg.TicketID,
+		pq.Array(arg.OrgIds),
+		arg.Visibility,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -95,6 +183,12 @@ func (q *Queries) ListComment(ctx context.Context, arg ListCommentParams) ([]Com
 			&i.Description,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.EditedAt,
+			&i.Visibility,
+			&i.ParentID,
+			&i.ThreadID,
+			&i.Depth,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -108,3 +202,145 @@ func (q *Queries) ListComment(ctx context.Context, arg ListCommentParams) ([]Com
 	}
 	return items, nil
 }
+
+const listCommentReplies = `-- name: ListCommentReplies :many
+SELECT id, ticket_id, created_by, description, created_at, updated_at, edited_at, visibility, parent_id, thread_id, depth, deleted_at FROM comments WHERE thread_id = ANY($1::uuid[]) AND (deleted_at IS NULL OR $2::bool) AND ($3::uuid[] IS NULL OR EXISTS (SELECT 1 FROM tickets t WHERE t.id = comments.ticket_id AND t.org_id = ANY($3::uuid[]))) AND ($4::varchar IS NULL OR visibility = $4::varchar) ORDER BY created_at LIMIT $5 OFFSET $6
+`
+
+type ListCommentRepliesParams struct {
+	ThreadIds      []uuid.UUID    `json:"thread_ids"`
+	IncludeDeleted bool           `json:"include_deleted"`
+	OrgIds         []uuid.UUID    `json:"org_ids"`
+	Visibility     sql.NullString `json:"visibility"`
+	Limit          sql.NullInt32  `json:"limit"`
+	Offset         int32          `json:"offset"`
+}
+
+func (q *Queries) ListCommentReplies(ctx context.Context, arg ListCommentRepliesParams) ([]Comment, error) {
+	rows, err := q.db.QueryContext(ctx, listCommentReplies,
+		pq.Array(arg.ThreadIds),
+		arg.IncludeDeleted,
+		pq.Array(arg.OrgIds),
+		arg.Visibility,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Comment{}
+	for rows.Next() {
+		var i Comment
+		if err := rows.Scan(
+			&i.ID,
+			&i.TicketID,
+			&i.CreatedBy,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.EditedAt,
+			&i.Visibility,
+			&i.ParentID,
+			&i.ThreadID,
+			&i.Depth,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCommentRevisions = `-- name: ListCommentRevisions :many
+SELECT r.id, r.comment_id, r.description, r.edited_by, r.created_at FROM comment_revisions r JOIN comments c ON c.id = r.comment_id WHERE r.comment_id = $1 AND ($2::uuid[] IS NULL OR EXISTS (SELECT 1 FROM tickets t WHERE t.id = c.ticket_id AND t.org_id = ANY($2::uuid[]))) ORDER BY r.created_at
+`
+
+type ListCommentRevisionsParams struct {
+	CommentID uuid.UUID   `json:"comment_id"`
+	OrgIds    []uuid.UUID `json:"org_ids"`
+}
+
+func (q *Queries) ListCommentRevisions(ctx context.Context, arg ListCommentRevisionsParams) ([]CommentRevision, error) {
+	rows, err := q.db.QueryContext(ctx, listCommentRevisions, arg.CommentID, pq.Array(arg.OrgIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CommentRevision{}
+	for rows.Next() {
+		var i CommentRevision
+		if err := rows.Scan(
+			&i.ID,
+			&i.CommentID,
+			&i.Description,
+			&i.EditedBy,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateComment = `-- name: UpdateComment :one
+WITH revision AS (
+    INSERT INTO comment_revisions (comment_id, description, edited_by)
+    SELECT id, description, $1 FROM comments WHERE id = $2 AND deleted_at IS NULL AND ($3::uuid[] IS NULL OR EXISTS (SELECT 1 FROM tickets t WHERE t.id = comments.ticket_id AND t.org_id = ANY($3::uuid[])))
+)
+UPDATE comments
+SET
+    description = $4,
+    edited_at = $5,
+    updated_at = $5
+WHERE id = $2 AND deleted_at IS NULL AND ($3::uuid[] IS NULL OR EXISTS (SELECT 1 FROM tickets t WHERE t.id = comments.ticket_id AND t.org_id = ANY($3::uuid[])))
+RETURNING id, ticket_id, created_by, description, created_at, updated_at, edited_at, visibility, parent_id, thread_id, depth, deleted_at
+`
+
+type UpdateCommentParams struct {
+	EditedBy    uuid.NullUUID `json:"edited_by"`
+	ID          uuid.UUID     `json:"id"`
+	OrgIds      []uuid.UUID   `json:"org_ids"`
+	Description string        `json:"description"`
+	EditedAt    sql.NullTime  `json:"edited_at"`
+}
+
+func (q *Queries) UpdateComment(ctx context.Context, arg UpdateCommentParams) (Comment, error) {
+	row := q.db.QueryRowContext(ctx, updateComment,
+		arg.EditedBy,
+		arg.ID,
+		pq.Array(arg.OrgIds),
+		arg.Description,
+		arg.EditedAt,
+	)
+	var i Comment
+	err := row.Scan(
+		&i.ID,
+		&i.TicketID,
+		&i.CreatedBy,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.EditedAt,
+		&i.Visibility,
+		&i.ParentID,
+		&i.ThreadID,
+		&i.Depth,
+		&i.DeletedAt,
+	)
+	return i, err
+}
@@ -11,34 +11,284 @@ import (
 	"github.com/google/uuid"
 )
 
+type AgentAvailability struct {
+	UserID         uuid.UUID `json:"user_id"`
+	Status         string    `json:"status"`
+	MaxOpenTickets int32     `json:"max_open_tickets"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type ApprovalStep struct {
+	ID           uuid.UUID      `json:"id"`
+	TicketType   string         `json:"ticket_type"`
+	Position     int32          `json:"position"`
+	ApproverID   uuid.NullUUID  `json:"approver_id"`
+	ApproverRole sql.NullString `json:"approver_role"`
+	CreatedAt    time.Time      `json:"created_at"`
+}
+
+type BusinessCalendar struct {
+	ID        uuid.UUID `json:"id"`
+	OrgID     uuid.UUID `json:"org_id"`
+	Name      string    `json:"name"`
+	TimeZone  string    `json:"time_zone"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type CalendarHoliday struct {
+	CalendarID uuid.UUID `json:"calendar_id"`
+	Day        time.Time `json:"day"`
+	Name       string    `json:"name"`
+}
+
+type CalendarHour struct {
+	CalendarID  uuid.UUID `json:"calendar_id"`
+	Weekday     int32     `json:"weekday"`
+	StartMinute int32     `json:"start_minute"`
+	EndMinute   int32     `json:"end_minute"`
+}
+
+type ChecklistItem struct {
+	ID         uuid.UUID     `json:"id"`
+	TicketID   uuid.UUID     `json:"ticket_id"`
+	Position   int32         `json:"position"`
+	Text       string        `json:"text"`
+	Done       bool          `json:"done"`
+	Required   bool          `json:"required"`
+	AssigneeID uuid.NullUUID `json:"assignee_id"`
+	DueAt      sql.NullTime  `json:"due_at"`
+	CreatedAt  time.Time     `json:"created_at"`
+	UpdatedAt  time.Time     `json:"updated_at"`
+}
+
 type Comment struct {
-	ID          uuid.UUID `json:"id"`
-	TicketID    uuid.UUID `json:"ticket_id"`
-	CreatedBy   uuid.UUID `json:"created_by"`
-	Description string    `json:"description"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          uuid.UUID     `json:"id"`
+	TicketID    uuid.UUID     `json:"ticket_id"`
+	CreatedBy   uuid.UUID     `json:"created_by"`
+	Description string        `json:"description"`
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+	EditedAt    sql.NullTime  `json:"edited_at"`
+	Visibility  string        `json:"visibility"`
+	ParentID    uuid.NullUUID `json:"parent_id"`
+	ThreadID    uuid.NullUUID `json:"thread_id"`
+	Depth       int32         `json:"depth"`
+	DeletedAt   sql.NullTime  `json:"deleted_at"`
+}
+
+type CommentReaction struct {
+	CommentID uuid.UUID `json:"comment_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Emoji     string    `json:"emoji"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CommentRevision struct {
+	ID          uuid.UUID     `json:"id"`
+	CommentID   uuid.UUID     `json:"comment_id"`
+	Description string        `json:"description"`
+	EditedBy    uuid.NullUUID `json:"edited_by"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+type CsatResponse struct {
+	ID         uuid.UUID   `json:"id"`
+	TicketID   uuid.UUID   `json:"ticket_id"`
+	AssignedTo []uuid.UUID `json:"assigned_to"`
+	Rating     int32       `json:"rating"`
+	Comment    string      `json:"comment"`
+	CreatedAt  time.Time   `json:"created_at"`
+	UpdatedAt  time.Time   `json:"updated_at"`
+}
+
+type Macro struct {
+	ID             uuid.UUID     `json:"id"`
+	OrgID          uuid.UUID     `json:"org_id"`
+	OwnerID        uuid.NullUUID `json:"owner_id"`
+	CreatedBy      uuid.NullUUID `json:"created_by"`
+	Name           string        `json:"name"`
+	Reply          string        `json:"reply"`
+	Visibility     string        `json:"visibility"`
+	State          sql.NullInt32 `json:"state"`
+	ResolutionCode string        `json:"resolution_code"`
+	Priority       sql.NullInt32 `json:"priority"`
+	AssignedTo     []uuid.UUID   `json:"assigned_to"`
+	Labels         []string      `json:"labels"`
+	CreatedAt      time.Time     `json:"created_at"`
+	UpdatedAt      time.Time     `json:"updated_at"`
+}
+
+type Mention struct {
+	ID        uuid.UUID     `json:"id"`
+	TicketID  uuid.UUID     `json:"ticket_id"`
+	CommentID uuid.NullUUID `json:"comment_id"`
+	UserID    uuid.UUID     `json:"user_id"`
+	CreatedBy uuid.NullUUID `json:"created_by"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+type Organization struct {
+	ID          uuid.UUID      `json:"id"`
+	Name        string         `json:"name"`
+	EmailDomain sql.NullString `json:"email_domain"`
+	CreatedAt   time.Time      `json:"created_at"`
+	CalendarID  uuid.NullUUID  `json:"calendar_id"`
+}
+
+type OutOfOffice struct {
+	ID         uuid.UUID     `json:"id"`
+	UserID     uuid.UUID     `json:"user_id"`
+	StartsAt   time.Time     `json:"starts_at"`
+	EndsAt     time.Time     `json:"ends_at"`
+	DelegateID uuid.NullUUID `json:"delegate_id"`
+	Note       string        `json:"note"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+type PriorityMatrix struct {
+	Impact    int32     `json:"impact"`
+	Urgency   int32     `json:"urgency"`
+	Priority  int32     `json:"priority"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type RefreshToken struct {
+	ID        uuid.UUID    `json:"id"`
+	UserID    uuid.UUID    `json:"user_id"`
+	FamilyID  uuid.UUID    `json:"family_id"`
+	TokenHash string       `json:"token_hash"`
+	ExpiresAt time.Time    `json:"expires_at"`
+	UsedAt    sql.NullTime `json:"used_at"`
+	RevokedAt sql.NullTime `json:"revoked_at"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+type SavedView struct {
+	ID           uuid.UUID      `json:"id"`
+	OwnerID      uuid.UUID      `json:"owner_id"`
+	Name         string         `json:"name"`
+	States       []int32        `json:"states"`
+	Priorities   []int32        `json:"priorities"`
+	AssigneeID   uuid.NullUUID  `json:"assignee_id"`
+	AssignedToMe bool           `json:"assigned_to_me"`
+	Unassigned   bool           `json:"unassigned"`
+	CreatorID    uuid.NullUUID  `json:"creator_id"`
+	CreatedByMe  bool           `json:"created_by_me"`
+	Search       string         `json:"search"`
+	Sort         string         `json:"sort"`
+	Columns      []string       `json:"columns"`
+	SharedRole   sql.NullString `json:"shared_role"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	OrgID        uuid.UUID      `json:"org_id"`
+	SharedTeamID uuid.NullUUID  `json:"shared_team_id"`
+}
+
+type Team struct {
+	ID         uuid.UUID     `json:"id"`
+	OrgID      uuid.UUID     `json:"org_id"`
+	Name       string        `json:"name"`
+	LeadID     uuid.NullUUID `json:"lead_id"`
+	CreatedAt  time.Time     `json:"created_at"`
+	UpdatedAt  time.Time     `json:"updated_at"`
+	CalendarID uuid.NullUUID `json:"calendar_id"`
+}
+
+type TeamMember struct {
+	TeamID    uuid.UUID `json:"team_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 type Ticket struct {
-	ID          uuid.UUID   `json:"id"`
-	CreatedBy   uuid.UUID   `json:"created_by"`
-	AssignedTo  []uuid.UUID `json:"assigned_to"`
-	Title       string      `json:"title"`
-	Description string      `json:"description"`
-	State       int32       `json:"state"`
-	Priority    int32       `json:"priority"`
-	CreatedAt   time.Time   `json:"created_at"`
-	UpdatedAt   time.Time   `json:"updated_at"`
+	ID                 uuid.UUID     `json:"id"`
+	CreatedBy          uuid.UUID     `json:"created_by"`
+	AssignedTo         []uuid.UUID   `json:"assigned_to"`
+	Title              string        `json:"title"`
+	Description        string        `json:"description"`
+	State              int32         `json:"state"`
+	Priority           int32         `json:"priority"`
+	CreatedAt          time.Time     `json:"created_at"`
+	UpdatedAt          time.Time     `json:"updated_at"`
+	ResolutionCode     string        `json:"resolution_code"`
+	StateReason        string        `json:"state_reason"`
+	Impact             int32         `json:"impact"`
+	Urgency            int32         `json:"urgency"`
+	PriorityOverridden bool          `json:"priority_overridden"`
+	Rank               string        `json:"rank"`
+	Type               string        `json:"type"`
+	OrgID              uuid.UUID     `json:"org_id"`
+	TeamID             uuid.NullUUID `json:"team_id"`
+	Readers            []uuid.UUID   `json:"readers"`
+	Labels             []string      `json:"labels"`
+}
+
+type TicketApproval struct {
+	ID           uuid.UUID      `json:"id"`
+	TicketID     uuid.UUID      `json:"ticket_id"`
+	Position     int32          `json:"position"`
+	ApproverID   uuid.NullUUID  `json:"approver_id"`
+	ApproverRole sql.NullString `json:"approver_role"`
+	Status       string         `json:"status"`
+	DecidedBy    uuid.NullUUID  `json:"decided_by"`
+	Comment      string         `json:"comment"`
+	DecidedAt    sql.NullTime   `json:"decided_at"`
+	CreatedAt    time.Time      `json:"created_at"`
+}
+
+type TicketEvent struct {
+	ID        uuid.UUID `json:"id"`
+	TicketID  uuid.UUID `json:"ticket_id"`
+	ActorID   uuid.UUID `json:"actor_id"`
+	Kind      string    `json:"kind"`
+	OldValue  string    `json:"old_value"`
+	NewValue  string    `json:"new_value"`
+	Reason    string    `json:"reason"`
+	Note      string    `json:"note"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type TicketTransfer struct {
+	ID         uuid.UUID     `json:"id"`
+	TicketID   uuid.UUID     `json:"ticket_id"`
+	FromUserID uuid.UUID     `json:"from_user_id"`
+	ToUserID   uuid.NullUUID `json:"to_user_id"`
+	ToTeamID   uuid.NullUUID `json:"to_team_id"`
+	Note       string        `json:"note"`
+	Status     string        `json:"status"`
+	DecidedBy  uuid.NullUUID `json:"decided_by"`
+	Reason     string        `json:"reason"`
+	DecidedAt  sql.NullTime  `json:"decided_at"`
+	CreatedAt  time.Time     `json:"created_at"`
 }
 
 type User struct {
-	ID             uuid.UUID      `json:"id"`
-	HashedPassword string         `json:"hashed_password"`
-	FirstName      string         `json:"first_name"`
-	LastName       string         `json:"last_name"`
-	Email          string         `json:"email"`
-	Role           sql.NullString `json:"role"`
-	UpdatedAt      time.Time      `json:"updated_at"`
-	CreatedAt      time.Time      `json:"created_at"`
+	ID              uuid.UUID      `json:"id"`
+	HashedPassword  string         `json:"hashed_password"`
+	FirstName       string         `json:"first_name"`
+	LastName        string         `json:"last_name"`
+	Email           string         `json:"email"`
+	Role            sql.NullString `json:"role"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	CreatedAt       time.Time      `json:"created_at"`
+	OrgID           uuid.UUID      `json:"org_id"`
+	SessionVersion  int32          `json:"session_version"`
+	EmailVerifiedAt sql.NullTime   `json:"email_verified_at"`
+}
+
+type UserOrgAccess struct {
+	UserID    uuid.UUID `json:"user_id"`
+	OrgID     uuid.UUID `json:"org_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type UserToken struct {
+	ID        uuid.UUID    `json:"id"`
+	UserID    uuid.UUID    `json:"user_id"`
+	Purpose   string       `json:"purpose"`
+	TokenHash string       `json:"token_hash"`
+	ExpiresAt time.Time    `json:"expires_at"`
+	UsedAt    sql.NullTime `json:"used_at"`
+	CreatedAt time.Time    `json:"created_at"`
 }
@@ -6,31 +6,140 @@ package db
 
 import (
 	"context"
+	"database/sql"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
+	AddCalendarHoliday(ctx context.Context, arg AddCalendarHolidayParams) error
+	AddCalendarHours(ctx context.Context, arg AddCalendarHoursParams) error
+	AddCommentReaction(ctx context.Context, arg AddCommentReactionParams) error
+	AddTeamMember(ctx context.Context, arg AddTeamMemberParams) error
+	AddTicketReaders(ctx context.Context, arg AddTicketReadersParams) error
+	ConsumeUserToken(ctx context.Context, arg ConsumeUserTokenParams) (UserToken, error)
+	CountOpenAssignedTickets(ctx context.Context, userID uuid.UUID) (int64, error)
+	CountTicketsByResolutionCode(ctx context.Context, orgIds []uuid.UUID) ([]CountTicketsByResolutionCodeRow, error)
+	CreateCalendar(ctx context.Context, arg CreateCalendarParams) (BusinessCalendar, error)
+	CreateChecklistItem(ctx context.Context, arg CreateChecklistItemParams) (ChecklistItem, error)
 	CreateComment(ctx context.Context, arg CreateCommentParams) (Comment, error)
+	CreateMacro(ctx context.Context, arg CreateMacroParams) (Macro, error)
+	CreateMentions(ctx context.Context, arg CreateMentionsParams) ([]Mention, error)
+	CreateOrganization(ctx context.Context, arg CreateOrganizationParams) (Organization, error)
+	CreateOutOfOffice(ctx context.Context, arg CreateOutOfOfficeParams) (OutOfOffice, error)
+	CreateRefreshToken(ctx context.Context, arg CreateRefreshTokenParams) (RefreshToken, error)
+	CreateSavedView(ctx context.Context, arg CreateSavedViewParams) (SavedView, error)
+	CreateTeam(ctx context.Context, arg CreateTeamParams) (Team, error)
 	CreateTicket(ctx context.Context, arg CreateTicketParams) (Ticket, error)
+	CreateTicketApprovals(ctx context.Context, arg CreateTicketApprovalsParams) error
+	CreateTicketEvent(ctx context.Context, arg CreateTicketEventParams) (TicketEvent, error)
+	CreateTicketTransfer(ctx context.Context, arg CreateTicketTransferParams) (TicketTransfer, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
-	DeleteComment(ctx context.Context, id uuid.UUID) error
-	DeleteTicket(ctx context.Context, id uuid.UUID) error
-	DeleteUser(ctx context.Context, id uuid.UUID) error
-	GetAllUsers(ctx context.Context) ([]GetAllUsersRow, error)
-	GetComment(ctx context.Context, id uuid.UUID) (Comment, error)
-	GetTicket(ctx context.Context, id uuid.UUID) (Ticket, error)
-	GetTicketsByAssignee(ctx context.Context, dollar_1 []uuid.UUID) ([]Ticket, error)
-	GetTicketsByCreator(ctx context.Context, createdBy uuid.UUID) ([]Ticket, error)
-	GetUser(ctx context.Context, id uuid.UUID) (User, error)
+	CreateUserToken(ctx context.Context, arg CreateUserTokenParams) (UserToken, error)
+	DecideTicketApproval(ctx context.Context, arg DecideTicketApprovalParams) (TicketApproval, error)
+	DecideTicketTransfer(ctx context.Context, arg DecideTicketTransferParams) (TicketTransfer, error)
+	DeleteCalendar(ctx context.Context, arg DeleteCalendarParams) error
+	DeleteCalendarHolidays(ctx context.Context, calendarID uuid.UUID) error
+	DeleteCalendarHours(ctx context.Context, calendarID uuid.UUID) error
+	DeleteChecklistItem(ctx context.Context, arg DeleteChecklistItemParams) error
+	DeleteComment(ctx context.Context, arg DeleteCommentParams) error
+	DeleteCommentReaction(ctx context.Context, arg DeleteCommentReactionParams) error
+	DeleteExpiredRefreshTokens(ctx context.Context) error
+	DeleteMacro(ctx context.Context, arg DeleteMacroParams) error
+	DeleteOutOfOffice(ctx context.Context, arg DeleteOutOfOfficeParams) error
+	DeleteSavedView(ctx context.Context, arg DeleteSavedViewParams) error
+	DeleteTeam(ctx context.Context, arg DeleteTeamParams) error
+	DeleteTicket(ctx context.Context, arg DeleteTicketParams) error
+	DeleteTicketCommentRevisions(ctx context.Context, arg DeleteTicketCommentRevisionsParams) error
+	DeleteUser(ctx context.Context, arg DeleteUserParams) error
+	FindSimilarTickets(ctx context.Context, arg FindSimilarTicketsParams) ([]FindSimilarTicketsRow, error)
+	ForwardAssignedTickets(ctx context.Context, arg ForwardAssignedTicketsParams) ([]uuid.UUID, error)
+	GetActiveOutOfOffice(ctx context.Context, arg GetActiveOutOfOfficeParams) (OutOfOffice, error)
+	GetAgentAvailability(ctx context.Context, arg GetAgentAvailabilityParams) (AgentAvailability, error)
+	GetAllUsers(ctx context.Context, orgIds []uuid.UUID) ([]GetAllUsersRow, error)
+	GetCSATResponseByTicket(ctx context.Context, arg GetCSATResponseByTicketParams) (CsatResponse, error)
+	GetCSATSummary(ctx context.Context, orgIds []uuid.UUID) (GetCSATSummaryRow, error)
+	GetCalendar(ctx context.Context, arg GetCalendarParams) (BusinessCalendar, error)
+	GetChecklistItem(ctx context.Context, arg GetChecklistItemParams) (ChecklistItem, error)
+	GetChecklistProgress(ctx context.Context, arg GetChecklistProgressParams) (GetChecklistProgressRow, error)
+	GetComment(ctx context.Context, arg GetCommentParams) (Comment, error)
+	GetCommentIncludingDeleted(ctx context.Context, arg GetCommentIncludingDeletedParams) (Comment, error)
+	GetLastTicketRank(ctx context.Context, arg GetLastTicketRankParams) (string, error)
+	GetMacro(ctx context.Context, arg GetMacroParams) (Macro, error)
+	GetOrganization(ctx context.Context, id uuid.UUID) (Organization, error)
+	GetOrganizationByDomain(ctx context.Context, emailDomain sql.NullString) (Organization, error)
+	GetPendingTicketTransfer(ctx context.Context, arg GetPendingTicketTransferParams) (TicketTransfer, error)
+	GetRefreshTokenByHash(ctx context.Context, tokenHash string) (RefreshToken, error)
+	GetSavedView(ctx context.Context, arg GetSavedViewParams) (SavedView, error)
+	GetTeam(ctx context.Context, arg GetTeamParams) (Team, error)
+	GetTicket(ctx context.Context, arg GetTicketParams) (Ticket, error)
+	GetTicketTransfer(ctx context.Context, arg GetTicketTransferParams) (TicketTransfer, error)
+	GetTicketsByAssignee(ctx context.Context, arg GetTicketsByAssigneeParams) ([]Ticket, error)
+	GetTicketsByCreator(ctx context.Context, arg GetTicketsByCreatorParams) ([]Ticket, error)
+	GetUser(ctx context.Context, arg GetUserParams) (User, error)
 	GetUserByEmail(ctx context.Context, email string) (User, error)
+	GrantOrgAccess(ctx context.Context, arg GrantOrgAccessParams) error
+	HandOffTicket(ctx context.Context, arg HandOffTicketParams) (Ticket, error)
+	ListActiveOutOfOffice(ctx context.Context, arg ListActiveOutOfOfficeParams) ([]OutOfOffice, error)
 	ListAllTickets(ctx context.Context, arg ListAllTicketsParams) ([]Ticket, error)
+	ListApprovalStepTypes(ctx context.Context) ([]string, error)
+	ListApprovalSteps(ctx context.Context, ticketType string) ([]ApprovalStep, error)
+	ListCSATByAssignee(ctx context.Context, orgIds []uuid.UUID) ([]ListCSATByAssigneeRow, error)
+	ListCalendarHolidays(ctx context.Context, calendarID uuid.UUID) ([]CalendarHoliday, error)
+	ListCalendarHours(ctx context.Context, calendarID uuid.UUID) ([]CalendarHour, error)
+	ListCalendars(ctx context.Context, orgIds []uuid.UUID) ([]BusinessCalendar, error)
+	ListChecklistItems(ctx context.Context, arg ListChecklistItemsParams) ([]ChecklistItem, error)
 	ListComment(ctx context.Context, arg ListCommentParams) ([]Comment, error)
+	ListCommentReactions(ctx context.Context, arg ListCommentReactionsParams) ([]ListCommentReactionsRow, error)
+	ListCommentReplies(ctx context.Context, arg ListCommentRepliesParams) ([]Comment, error)
+	ListCommentRevisions(ctx context.Context, arg ListCommentRevisionsParams) ([]CommentRevision, error)
+	ListFilteredTickets(ctx context.Context, arg ListFilteredTicketsParams) ([]Ticket, error)
+	ListIncomingTransfers(ctx context.Context, arg ListIncomingTransfersParams) ([]TicketTransfer, error)
+	ListMacros(ctx context.Context, arg ListMacrosParams) ([]Macro, error)
+	ListMentionableUsers(ctx context.Context, arg ListMentionableUsersParams) ([]User, error)
+	ListMentionsByUser(ctx context.Context, arg ListMentionsByUserParams) ([]Mention, error)
+	ListOrganizations(ctx context.Context) ([]Organization, error)
+	ListOutOfOffice(ctx context.Context, arg ListOutOfOfficeParams) ([]OutOfOffice, error)
+	ListPendingApprovals(ctx context.Context, arg ListPendingApprovalsParams) ([]TicketApproval, error)
+	ListPriorityMatrix(ctx context.Context) ([]PriorityMatrix, error)
+	ListSavedViews(ctx context.Context, arg ListSavedViewsParams) ([]SavedView, error)
+	ListTeamMembers(ctx context.Context, arg ListTeamMembersParams) ([]uuid.UUID, error)
+	ListTeamTickets(ctx context.Context, arg ListTeamTicketsParams) ([]Ticket, error)
+	ListTeams(ctx context.Context, orgIds []uuid.UUID) ([]Team, error)
+	ListTicketApprovals(ctx context.Context, arg ListTicketApprovalsParams) ([]TicketApproval, error)
+	ListTicketEvents(ctx context.Context, arg ListTicketEventsParams) ([]TicketEvent, error)
+	ListTicketTransfers(ctx context.Context, arg ListTicketTransfersParams) ([]TicketTransfer, error)
 	ListTickets(ctx context.Context, arg ListTicketsParams) ([]Ticket, error)
 	ListTicketsAssigned(ctx context.Context, arg ListTicketsAssignedParams) ([]Ticket, error)
+	ListUserOrgAccess(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+	ListUserTeams(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
 	ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error)
+	ReapplyPriorityMatrix(ctx context.Context) error
+	RemoveTeamMember(ctx context.Context, arg RemoveTeamMemberParams) error
+	ReorderChecklistItems(ctx context.Context, arg ReorderChecklistItemsParams) error
+	ReplaceApprovalSteps(ctx context.Context, arg ReplaceApprovalStepsParams) error
+	RevokeOrgAccess(ctx context.Context, arg RevokeOrgAccessParams) error
+	RevokeRefreshTokenFamily(ctx context.Context, familyID uuid.UUID) error
+	RevokeUserRefreshTokens(ctx context.Context, userID uuid.UUID) error
+	RevokeUserTokens(ctx context.Context, arg RevokeUserTokensParams) error
+	SearchUsers(ctx context.Context, arg SearchUsersParams) ([]SearchUsersRow, error)
+	SetOrganizationCalendar(ctx context.Context, arg SetOrganizationCalendarParams) (Organization, error)
+	SetTeamCalendar(ctx context.Context, arg SetTeamCalendarParams) (Team, error)
+	UpdateCalendar(ctx context.Context, arg UpdateCalendarParams) (BusinessCalendar, error)
+	UpdateChecklistItem(ctx context.Context, arg UpdateChecklistItemParams) (ChecklistItem, error)
+	UpdateComment(ctx context.Context, arg UpdateCommentParams) (Comment, error)
+	UpdateMacro(ctx context.Context, arg UpdateMacroParams) (Macro, error)
+	UpdateSavedView(ctx context.Context, arg UpdateSavedViewParams) (SavedView, error)
+	UpdateTeam(ctx context.Context, arg UpdateTeamParams) (Team, error)
 	UpdateTicket(ctx context.Context, arg UpdateTicketParams) (Ticket, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
+	UpdateUserOrg(ctx context.Context, arg UpdateUserOrgParams) (User, error)
+	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) (User, error)
+	UpsertAgentAvailability(ctx context.Context, arg UpsertAgentAvailabilityParams) (AgentAvailability, error)
+	UpsertCSATResponse(ctx context.Context, arg UpsertCSATResponseParams) (CsatResponse, error)
+	UpsertPriorityMatrixEntry(ctx context.Context, arg UpsertPriorityMatrixEntryParams) error
+	UseRefreshToken(ctx context.Context, id uuid.UUID) (RefreshToken, error)
+	VerifyUserEmail(ctx context.Context, arg VerifyUserEmailParams) (User, error)
 }
 
 var _ Querier = (*Queries)(nil)
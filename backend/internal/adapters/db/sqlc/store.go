@@ -1,11 +1,18 @@
 package db
 
 import (
+	"context"
 	"database/sql"
+	"fmt"
 )
 
 type Store interface {
 	Querier
+	// ExecTx runs fn in a transaction, committing it if fn succeeds. Every
+	// query made with the context fn is given joins the transaction, so
+	// repositories and services take part without knowing about it. Inside
+	// a transaction, ExecTx just runs fn.
+	ExecTx(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 type SQLStore struct {
@@ -16,7 +23,55 @@ type SQLStore struct {
 func NewStore(db *sql.DB) Store {
 	store := &SQLStore{
 		db:      db,
-		Queries: New(db),
+		Queries: New(ctxDB{db: db}),
 	}
 	return store
 }
+
+type txKey struct{}
+
+func (s *SQLStore) ExecTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rbErr)
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+// ctxDB runs each query in the transaction the context carries, if any
+type ctxDB struct {
+	db *sql.DB
+}
+
+func (c ctxDB) conn(ctx context.Context) DBTX {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return c.db
+}
+
+func (c ctxDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return c.conn(ctx).ExecContext(ctx, query, args...)
+}
+
+func (c ctxDB) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return c.conn(ctx).PrepareContext(ctx, query)
+}
+
+func (c ctxDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return c.conn(ctx).QueryContext(ctx, query, args...)
+}
+
+func (c ctxDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return c.conn(ctx).QueryRowContext(ctx, query, args...)
+}
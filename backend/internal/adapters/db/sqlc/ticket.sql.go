@@ -13,8 +13,63 @@ import (
 	"github.com/lib/pq"
 )
 
+const addTicketReaders = `-- name: AddTicketReaders :exec
+UPDATE tickets
+SET readers = ARRAY(SELECT DISTINCT unnest(readers || $1::uuid[]))
+WHERE id = $2 AND ($3::uuid[] IS NULL OR org_id = ANY($3::uuid[]))
+`
+
+type AddTicketReadersParams struct {
+	UserIds []uuid.UUID `json:"user_ids"`
+	ID      uuid.UUID   `json:"id"`
+	OrgIds  []uuid.UUID `json:"org_ids"`
+}
+
+func (q *Queries) AddTicketReaders(ctx context.Context, arg AddTicketReadersParams) error {
+	_, err := q.db.ExecContext(ctx, addTicketReaders, pq.Array(arg.UserIds), arg.ID, pq.Array(arg.OrgIds))
+	return err
+}
+
+const countTicketsByResolutionCode = `-- name: CountTicketsByResolutionCode :many
+SELECT resolution_code, COUNT(*)::bigint AS tickets
+FROM tickets
+WHERE resolution_code <> '' AND ($1::uuid[] IS NULL OR org_id = ANY($1::uuid[]))
+GROUP BY resolution_code
+ORDER BY tickets DESC
+`
+
+type CountTicketsByResolutionCodeRow struct {
+	ResolutionCode string `json:"resolution_code"`
+	Tickets        int64  `json:"tickets"`
+}
+
+func (q *Queries) CountTicketsByResolutionCode(ctx context.Context, orgIds []uuid.UUID) ([]CountTicketsByResolutionCodeRow, error) {
+	rows, err := q.db.QueryContext(ctx, countTicketsByResolutionCode, pq.Array(orgIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CountTicketsByResolutionCodeRow{}
+	for rows.Next() {
+		var i CountTicketsByResolutionCodeRow
+		if err := rows.Scan(&i.ResolutionCode, &i.Tickets); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const createTicket = `-- name: CreateTicket :one
-INSERT INTO tickets (title, description, created_by, updated_at ) VALUES ($1, $2, $3, $4) RETURNING id, created_by, assigned_to, title, description, state, priority, created_at, updated_at
+INSERT INTO tickets (title, description, created_by, updated_at, impact, urgency, priority, rank, type, org_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, (SELECT org_id FROM users WHERE id = $3))
+RETURNING id, created_by, assigned_to, title, description, state, priority, created_at, updated_at, resolution_code, state_reason, impact, urgency, priority_overridden, rank, type, org_id, team_id, readers, labels
 `
 
 type CreateTicketParams struct {
@@ -22,6 +77,11 @@ type CreateTicketParams struct {
 	Description string    `json:"description"`
 	CreatedBy   uuid.UUID `json:"created_by"`
 	UpdatedAt   time.Time `json:"updated_at"`
+	Impact      int32     `json:"impact"`
+	Urgency     int32     `json:"urgency"`
+	Priority    int32     `json:"priority"`
+	Rank        string    `json:"rank"`
+	Type        string    `json:"type"`
 }
 
 func (q *Queries) CreateTicket(ctx context.Context, arg CreateTicketParams) (Ticket, error) {
@@ -30,6 +90,11 @@ func (q *Queries) CreateTicket(ctx context.Context, arg CreateTicketParams) (Tic
 		arg.Description,
 		arg.CreatedBy,
 		arg.UpdatedAt,
+		arg.Impact,
+		arg.Urgency,
+		arg.Priority,
+		arg.Rank,
+		arg.Type,
 	)
 	var i Ticket
 	err := row.Scan(
@@ -42,25 +107,180 @@ func (q *Queries) CreateTicket(ctx context.Context, arg CreateTicketParams) (Tic
 		&i.Priority,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ResolutionCode,
+		&i.StateReason,
+		&i.Impact,
+		&i.Urgency,
+		&i.PriorityOverridden,
+		&i.Rank,
+		&i.Type,
+		&i.OrgID,
+		&i.TeamID,
+		pq.Array(&i.Readers),
+		pq.Array(&i.Labels),
 	)
 	return i, err
 }
 
 const deleteTicket = `-- name: DeleteTicket :exec
-DELETE FROM tickets WHERE id = $1
+DELETE FROM tickets WHERE id = $1 AND ($2::uuid[] IS NULL OR org_id = ANY($2::uuid[]))
 `
 
-func (q *Queries) DeleteTicket(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.ExecContext(ctx, deleteTicket, id)
+type DeleteTicketParams struct {
+	ID     uuid.UUID   `json:"id"`
+	OrgIds []uuid.UUID `json:"org_ids"`
+}
+
+func (q *Queries) DeleteTicket(ctx context.Context, arg DeleteTicketParams) error {
+	_, err := q.db.ExecContext(ctx, deleteTicket, arg.ID, pq.Array(arg.OrgIds))
 	return err
 }
 
+const findSimilarTickets = `-- name: FindSimilarTickets :many
+SELECT tickets.id, tickets.created_by, tickets.assigned_to, tickets.title, tickets.description, tickets.state, tickets.priority, tickets.created_at, tickets.updated_at, tickets.resolution_code, tickets.state_reason, tickets.impact, tickets.urgency, tickets.priority_overridden, tickets.rank, tickets.type, tickets.org_id, tickets.team_id, tickets.readers, tickets.labels,
+    GREATEST(similarity(title, $1::text), similarity(description, $2::text))::float8 AS score
+FROM tickets
+WHERE state IN (1, 2)
+  AND (title % $1::text OR description % $2::text)
+  AND ($3::uuid[] IS NULL OR org_id = ANY($3::uuid[]))
+ORDER BY score DESC
+LIMIT $4
+`
+
+type FindSimilarTicketsParams struct {
+	Title       string      `json:"title"`
+	Description string      `json:"description"`
+	OrgIds      []uuid.UUID `json:"org_ids"`
+	MaxResults  int32       `json:"max_results"`
+}
+
+type FindSimilarTicketsRow struct {
+	Ticket Ticket  `json:"ticket"`
+	Score  float64 `json:"score"`
+}
+
+func (q *Queries) FindSimilarTickets(ctx context.Context, arg FindSimilarTicketsParams) ([]FindSimilarTicketsRow, error) {
+	rows, err := q.db.QueryContext(ctx, findSimilarTickets,
+		arg.Title,
+		arg.Description,
+		pq.Array(arg.OrgIds),
+		arg.MaxResults,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []FindSimilarTicketsRow{}
+	for rows.Next() {
+		var i FindSimilarTicketsRow
+		if err := rows.Scan(
+			&i.Ticket.ID,
+			&i.Ticket.CreatedBy,
+			pq.Array(&i.Ticket.AssignedTo),
+			&i.Ticket.Title,
+			&i.Ticket.Description,
+			&i.Ticket.State,
+			&i.Ticket.Priority,
+			&i.Ticket.CreatedAt,
+			&i.Ticket.UpdatedAt,
+			&i.Ticket.ResolutionCode,
+			&i.Ticket.StateReason,
+			&i.Ticket.Impact,
+			&i.Ticket.Urgency,
+			&i.Ticket.PriorityOverridden,
+			&i.Ticket.Rank,
+			&i.Ticket.Type,
+			&i.Ticket.OrgID,
+			&i.Ticket.TeamID,
+			pq.Array(&i.Ticket.Readers),
+			pq.Array(&i.Ticket.Labels),
+			&i.Score,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const forwardAssignedTickets = `-- name: ForwardAssignedTickets :many
+UPDATE tickets
+SET
+    assigned_to = CASE WHEN $1::uuid = ANY(assigned_to) THEN array_remove(assigned_to, $2::uuid)
+                       ELSE array_replace(assigned_to, $2::uuid, $1::uuid) END,
+    updated_at = $3
+WHERE assigned_to @> ARRAY[$2::uuid] AND state IN (1, 2) AND ($4::uuid[] IS NULL OR org_id = ANY($4::uuid[]))
+RETURNING id
+`
+
+type ForwardAssignedTicketsParams struct {
+	DelegateID uuid.UUID   `json:"delegate_id"`
+	UserID     uuid.UUID   `json:"user_id"`
+	UpdatedAt  time.Time   `json:"updated_at"`
+	OrgIds     []uuid.UUID `json:"org_ids"`
+}
+
+func (q *Queries) ForwardAssignedTickets(ctx context.Context, arg ForwardAssignedTicketsParams) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, forwardAssignedTickets,
+		arg.DelegateID,
+		arg.UserID,
+		arg.UpdatedAt,
+		pq.Array(arg.OrgIds),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLastTicketRank = `-- name: GetLastTicketRank :one
+SELECT COALESCE(MAX(rank), '')::text FROM tickets WHERE state = $1 AND ($2::uuid[] IS NULL OR org_id = ANY($2::uuid[]))
+`
+
+type GetLastTicketRankParams struct {
+	State  int32       `json:"state"`
+	OrgIds []uuid.UUID `json:"org_ids"`
+}
+
+func (q *Queries) GetLastTicketRank(ctx context.Context, arg GetLastTicketRankParams) (string, error) {
+	row := q.db.QueryRowContext(ctx, getLastTicketRank, arg.State, pq.Array(arg.OrgIds))
+	var column_1 string
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
 const getTicket = `-- name: GetTicket :one
-SELECT id, created_by, assigned_to, title, description, state, priority, created_at, updated_at FROM tickets WHERE id = $1 LIMIT 1
+SELECT id, created_by, assigned_to, title, description, state, priority, created_at, updated_at, resolution_code, state_reason, impact, urgency, priority_overridden, rank, type, org_id, team_id, readers, labels FROM tickets WHERE id = $1 AND ($2::uuid[] IS NULL OR org_id = ANY($2::uuid[])) LIMIT 1
 `
 
-func (q *Queries) GetTicket(ctx context.Context, id uuid.UUID) (Ticket, error) {
-	row := q.db.QueryRowContext(ctx, getTicket, id)
+type GetTicketParams struct {
+	ID     uuid.UUID   `json:"id"`
+	OrgIds []uuid.UUID `json:"org_ids"`
+}
+
+func (q *Queries) GetTicket(ctx context.Context, arg GetTicketParams) (Ticket, error) {
+	row := q.db.QueryRowContext(ctx, getTicket, arg.ID, pq.Array(arg.OrgIds))
 	var i Ticket
 	err := row.Scan(
 		&i.ID,
@@ -72,18 +292,34 @@ func (q *Queries) GetTicket(ctx context.Context, id uuid.UUID) (Ticket, error) {
 		&i.Priority,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ResolutionCode,
+		&i.StateReason,
+		&i.Impact,
+		&i.Urgency,
+		&i.PriorityOverridden,
+		&i.Rank,
+		&i.Type,
+		&i.OrgID,
+		&i.TeamID,
+		pq.Array(&i.Readers),
+		pq.Array(&i.Labels),
 	)
 	return i, err
 }
 
 const getTicketsByAssignee = `-- name: GetTicketsByAssignee :many
-SELECT id, created_by, assigned_to, title, description, state, priority, created_at, updated_at FROM tickets
-WHERE assigned_to @> ARRAY[$1]::uuid[]
+SELECT id, created_by, assigned_to, title, description, state, priority, created_at, updated_at, resolution_code, state_reason, impact, urgency, priority_overridden, rank, type, org_id, team_id, readers, labels FROM tickets
+WHERE assigned_to @> ARRAY[$1::uuid] AND ($2::uuid[] IS NULL OR org_id = ANY($2::uuid[]))
 ORDER BY created_at DESC
 `
 
-func (q *Queries) GetTicketsByAssignee(ctx context.Context, dollar_1 []uuid.UUID) ([]Ticket, error) {
-	rows, err := q.db.QueryContext(ctx, getTicketsByAssignee, pq.Array(dollar_1))
+type GetTicketsByAssigneeParams struct {
+	Assignee uuid.UUID   `json:"assignee"`
+	OrgIds   []uuid.UUID `json:"org_ids"`
+}
+
+func (q *Queries) GetTicketsByAssignee(ctx context.Context, arg GetTicketsByAssigneeParams) ([]Ticket, error) {
+	rows, err := q.db.QueryContext(ctx, getTicketsByAssignee, arg.Assignee, pq.Array(arg.OrgIds))
 	if err != nil {
 		return nil, err
 	}
@@ -101,6 +337,17 @@ func (q *Queries) GetTicketsByAssignee(ctx context.Context, dollar_1 []uuid.UUID
 			&i.Priority,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ResolutionCode,
+			&i.StateReason,
+			&i.Impact,
+			&i.Urgency,
+			&i.PriorityOverridden,
+			&i.Rank,
+			&i.Type,
+			&i.OrgID,
+			&i.TeamID,
+			pq.Array(&i.Readers),
+			pq.Array(&i.Labels),
 		); err != nil {
 			return nil, err
 		}
@@ -116,13 +363,18 @@ func (q *Queries) GetTicketsByAssignee(ctx context.Context, dollar_1 []uuid.UUID
 }
 
 const getTicketsByCreator = `-- name: GetTicketsByCreator :many
-SELECT id, created_by, assigned_to, title, description, state, priority, created_at, updated_at FROM tickets
-WHERE created_by = $1
+SELECT id, created_by, assigned_to, title, description, state, priority, created_at, updated_at, resolution_code, state_reason, impact, urgency, priority_overridden, rank, type, org_id, team_id, readers, labels FROM tickets
+WHERE created_by = $1 AND ($2::uuid[] IS NULL OR org_id = ANY($2::uuid[]))
 ORDER BY created_at DESC
 `
 
-func (q *Queries) GetTicketsByCreator(ctx context.Context, createdBy uuid.UUID) ([]Ticket, error) {
-	rows, err := q.db.QueryContext(ctx, getTicketsByCreator, createdBy)
+type GetTicketsByCreatorParams struct {
+	CreatedBy uuid.UUID   `json:"created_by"`
+	OrgIds    []uuid.UUID `json:"org_ids"`
+}
+
+func (q *Queries) GetTicketsByCreator(ctx context.Context, arg GetTicketsByCreatorParams) ([]Ticket, error) {
+	rows, err := q.db.QueryContext(ctx, getTicketsByCreator, arg.CreatedBy, pq.Array(arg.OrgIds))
 	if err != nil {
 		return nil, err
 	}
@@ -140,6 +392,17 @@ func (q *Queries) GetTicketsByCreator(ctx context.Context, createdBy uuid.UUID)
 			&i.Priority,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ResolutionCode,
+			&i.StateReason,
+			&i.Impact,
+			&i.Urgency,
+			&i.PriorityOverridden,
+			&i.Rank,
+			&i.Type,
+			&i.OrgID,
+			&i.TeamID,
+			pq.Array(&i.Readers),
+			pq.Array(&i.Labels),
 		); err != nil {
 			return nil, err
 		}
@@ -154,17 +417,74 @@ func (q *Queries) GetTicketsByCreator(ctx context.Context, createdBy uuid.UUID)
 	return items, nil
 }
 
+const handOffTicket = `-- name: HandOffTicket :one
+UPDATE tickets
+SET
+    assigned_to = CASE WHEN $1::uuid = ANY(assigned_to) THEN array_remove(assigned_to, $2::uuid)
+                       WHEN $2::uuid = ANY(assigned_to) THEN array_replace(assigned_to, $2::uuid, $1::uuid)
+                       ELSE array_append(assigned_to, $1::uuid) END,
+    team_id = COALESCE($3, team_id),
+    updated_at = $4
+WHERE id = $5 AND ($6::uuid[] IS NULL OR org_id = ANY($6::uuid[]))
+RETURNING id, created_by, assigned_to, title, description, state, priority, created_at, updated_at, resolution_code, state_reason, impact, urgency, priority_overridden, rank, type, org_id, team_id, readers, labels
+`
+
+type HandOffTicketParams struct {
+	ToUserID   uuid.UUID     `json:"to_user_id"`
+	FromUserID uuid.UUID     `json:"from_user_id"`
+	TeamID     uuid.NullUUID `json:"team_id"`
+	UpdatedAt  time.Time     `json:"updated_at"`
+	ID         uuid.UUID     `json:"id"`
+	OrgIds     []uuid.UUID   `json:"org_ids"`
+}
+
+func (q *Queries) HandOffTicket(ctx context.Context, arg HandOffTicketParams) (Ticket, error) {
+	row := q.db.QueryRowContext(ctx, handOffTicket,
+		arg.ToUserID,
+		arg.FromUserID,
+		arg.TeamID,
+		arg.UpdatedAt,
+		arg.ID,
+		pq.Array(arg.OrgIds),
+	)
+	var i Ticket
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedBy,
+		pq.Array(&i.AssignedTo),
+		&i.Title,
+		&i.Description,
+		&i.State,
+		&i.Priority,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ResolutionCode,
+		&i.StateReason,
+		&i.Impact,
+		&i.Urgency,
+		&i.PriorityOverridden,
+		&i.Rank,
+		&i.Type,
+		&i.OrgID,
+		&i.TeamID,
+		pq.Array(&i.Readers),
+		pq.Array(&i.Labels),
+	)
+	return i, err
+}
+
 const listAllTickets = `-- name: ListAllTickets :many
-SELECT id, created_by, assigned_to, title, description, state, priority, created_at, updated_at FROM tickets ORDER BY id LIMIT $1 OFFSET $2
+SELECT id, created_by, assigned_to, title, description, state, priority, created_at, updated_at, resolution_code, state_reason, impact, urgency, priority_overridden, rank, type, org_id, team_id, readers, labels FROM tickets WHERE ($1::uuid[] IS NULL OR org_id = ANY($1::uuid[])) ORDER BY id LIMIT $2 OFFSET $3
 `
 
 type ListAllTicketsParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	OrgIds []uuid.UUID `json:"org_ids"`
+	Limit  int32       `json:"limit"`
+	Offset int32       `json:"offset"`
 }
 
 func (q *Queries) ListAllTickets(ctx context.Context, arg ListAllTicketsParams) ([]Ticket, error) {
-	rows, err := q.db.QueryContext(ctx, listAllTickets, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listAllTickets, pq.Array(arg.OrgIds), arg.Limit, arg.Offset)
 	if err != nil {
 		return nil, err
 	}
@@ -182,6 +502,177 @@ func (q *Queries) ListAllTickets(ctx context.Context, arg ListAllTicketsParams)
 			&i.Priority,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ResolutionCode,
+			&i.StateReason,
+			&i.Impact,
+			&i.Urgency,
+			&i.PriorityOverridden,
+			&i.Rank,
+			&i.Type,
+			&i.OrgID,
+			&i.TeamID,
+			pq.Array(&i.Readers),
+			pq.Array(&i.Labels),
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listFilteredTickets = `-- name: ListFilteredTickets :many
+SELECT id, created_by, assigned_to, title, description, state, priority, created_at, updated_at, resolution_code, state_reason, impact, urgency, priority_overridden, rank, type, org_id, team_id, readers, labels FROM tickets
+WHERE ($1::uuid IS NULL OR created_by = $1)
+  AND ($2::uuid IS NULL OR assigned_to @> ARRAY[$2::uuid] OR team_id = ANY($3::uuid[]))
+  AND ($4::uuid[] IS NULL OR org_id = ANY($4::uuid[]))
+  AND (cardinality($5::int[]) = 0 OR state = ANY($5::int[]))
+  AND (cardinality($6::int[]) = 0 OR priority = ANY($6::int[]))
+  AND ($7::uuid IS NULL OR assigned_to @> ARRAY[$7::uuid])
+  AND (NOT $8::bool OR cardinality(COALESCE(assigned_to, '{}')) = 0)
+  AND ($9::uuid IS NULL OR created_by = $9)
+  AND ($10::text = '' OR title ILIKE '%' || $10::text || '%' OR description ILIKE '%' || $10::text || '%')
+ORDER BY
+  CASE WHEN $11::text = 'priority' THEN priority END ASC,
+  CASE WHEN $11::text = '-priority' THEN priority END DESC,
+  CASE WHEN $11::text = 'created_at' THEN created_at END ASC,
+  CASE WHEN $11::text = '-created_at' THEN created_at END DESC,
+  CASE WHEN $11::text = 'updated_at' THEN updated_at END ASC,
+  CASE WHEN $11::text = '-updated_at' THEN updated_at END DESC,
+  CASE WHEN $11::text = 'rank' THEN rank END ASC,
+  id
+LIMIT $12 OFFSET $13
+`
+
+type ListFilteredTicketsParams struct {
+	ScopeCreator  uuid.NullUUID `json:"scope_creator"`
+	ScopeAssignee uuid.NullUUID `json:"scope_assignee"`
+	ScopeTeams    []uuid.UUID   `json:"scope_teams"`
+	OrgIds        []uuid.UUID   `json:"org_ids"`
+	States        []int32       `json:"states"`
+	Priorities    []int32       `json:"priorities"`
+	Assignee      uuid.NullUUID `json:"assignee"`
+	Unassigned    bool          `json:"unassigned"`
+	Creator       uuid.NullUUID `json:"creator"`
+	Search        string        `json:"search"`
+	Sort          string        `json:"sort"`
+	Limit         int32         `json:"limit"`
+	Offset        int32         `json:"offset"`
+}
+
+func (q *Queries) ListFilteredTickets(ctx context.Context, arg ListFilteredTicketsParams) ([]Ticket, error) {
+	rows, err := q.db.QueryContext(ctx, listFilteredTickets,
+		arg.ScopeCreator,
+		arg.ScopeAssignee,
+		pq.Array(arg.ScopeTeams),
+		pq.Array(arg.OrgIds),
+		pq.Array(arg.States),
+		pq.Array(arg.Priorities),
+		arg.Assignee,
+		arg.Unassigned,
+		arg.Creator,
+		arg.Search,
+		arg.Sort,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Ticket{}
+	for rows.Next() {
+		var i Ticket
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedBy,
+			pq.Array(&i.AssignedTo),
+			&i.Title,
+			&i.Description,
+			&i.State,
+			&i.Priority,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ResolutionCode,
+			&i.StateReason,
+			&i.Impact,
+			&i.Urgency,
+			&i.PriorityOverridden,
+			&i.Rank,
+			&i.Type,
+			&i.OrgID,
+			&i.TeamID,
+			pq.Array(&i.Readers),
+			pq.Array(&i.Labels),
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTeamTickets = `-- name: ListTeamTickets :many
+SELECT id, created_by, assigned_to, title, description, state, priority, created_at, updated_at, resolution_code, state_reason, impact, urgency, priority_overridden, rank, type, org_id, team_id, readers, labels FROM tickets
+WHERE team_id = ANY($1::uuid[]) AND ($2::uuid[] IS NULL OR org_id = ANY($2::uuid[]))
+ORDER BY priority, created_at
+LIMIT $3 OFFSET $4
+`
+
+type ListTeamTicketsParams struct {
+	TeamIds []uuid.UUID `json:"team_ids"`
+	OrgIds  []uuid.UUID `json:"org_ids"`
+	Limit   int32       `json:"limit"`
+	Offset  int32       `json:"offset"`
+}
+
+func (q *Queries) ListTeamTickets(ctx context.Context, arg ListTeamTicketsParams) ([]Ticket, error) {
+	rows, err := q.db.QueryContext(ctx, listTeamTickets,
+		pq.Array(arg.TeamIds),
+		pq.Array(arg.OrgIds),
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Ticket{}
+	for rows.Next() {
+		var i Ticket
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedBy,
+			pq.Array(&i.AssignedTo),
+			&i.Title,
+			&i.Description,
+			&i.State,
+			&i.Priority,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ResolutionCode,
+			&i.StateReason,
+			&i.Impact,
+			&i.Urgency,
+			&i.PriorityOverridden,
+			&i.Rank,
+			&i.Type,
+			&i.OrgID,
+			&i.TeamID,
+			pq.Array(&i.Readers),
+			pq.Array(&i.Labels),
 		); err != nil {
 			return nil, err
 		}
@@ -197,17 +688,23 @@ func (q *Queries) ListAllTickets(ctx context.Context, arg ListAllTicketsParams)
 }
 
 const listTickets = `-- name: ListTickets :many
-SELECT id, created_by, assigned_to, title, description, state, priority, created_at, updated_at FROM tickets WHERE created_by=$1 ORDER BY id LIMIT $2 OFFSET $3
+SELECT id, created_by, assigned_to, title, description, state, priority, created_at, updated_at, resolution_code, state_reason, impact, urgency, priority_overridden, rank, type, org_id, team_id, readers, labels FROM tickets WHERE created_by = $1 AND ($2::uuid[] IS NULL OR org_id = ANY($2::uuid[])) ORDER BY id LIMIT $3 OFFSET $4
 `
 
 type ListTicketsParams struct {
-	CreatedBy uuid.UUID `json:"created_by"`
-	Limit     int32     `json:"limit"`
-	Offset    int32     `json:"offset"`
+	CreatedBy uuid.UUID   `json:"created_by"`
+	OrgIds    []uuid.UUID `json:"org_ids"`
+	Limit     int32       `json:"limit"`
+	Offset    int32       `json:"offset"`
 }
 
 func (q *Queries) ListTickets(ctx context.Context, arg ListTicketsParams) ([]Ticket, error) {
-	rows, err := q.db.QueryContext(ctx, listTickets, arg.CreatedBy, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listTickets,
+		arg.CreatedBy,
+		pq.Array(arg.OrgIds),
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -225,6 +722,17 @@ func (q *Queries) ListTickets(ctx context.Context, arg ListTicketsParams) ([]Tic
 			&i.Priority,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ResolutionCode,
+			&i.StateReason,
+			&i.Impact,
+			&i.Urgency,
+			&i.PriorityOverridden,
+			&i.Rank,
+			&i.Type,
+			&i.OrgID,
+			&i.TeamID,
+			pq.Array(&i.Readers),
+			pq.Array(&i.Labels),
 		); err != nil {
 			return nil, err
 		}
@@ -240,17 +748,23 @@ func (q *Queries) ListTickets(ctx context.Context, arg ListTicketsParams) ([]Tic
 }
 
 const listTicketsAssigned = `-- name: ListTicketsAssigned :many
-SELECT id, created_by, assigned_to, title, description, state, priority, created_at, updated_at FROM tickets WHERE assigned_to @> ARRAY[$1]::uuid[] ORDER BY id LIMIT $2 OFFSET $3
+SELECT id, created_by, assigned_to, title, description, state, priority, created_at, updated_at, resolution_code, state_reason, impact, urgency, priority_overridden, rank, type, org_id, team_id, readers, labels FROM tickets WHERE assigned_to @> ARRAY[$1::uuid] AND ($2::uuid[] IS NULL OR org_id = ANY($2::uuid[])) ORDER BY id LIMIT $3 OFFSET $4
 `
 
 type ListTicketsAssignedParams struct {
-	Column1 []uuid.UUID `json:"column_1"`
-	Limit   int32       `json:"limit"`
-	Offset  int32       `json:"offset"`
+	Assignee uuid.UUID   `json:"assignee"`
+	OrgIds   []uuid.UUID `json:"org_ids"`
+	Limit    int32       `json:"limit"`
+	Offset   int32       `json:"offset"`
 }
 
 func (q *Queries) ListTicketsAssigned(ctx context.Context, arg ListTicketsAssignedParams) ([]Ticket, error) {
-	rows, err := q.db.QueryContext(ctx, listTicketsAssigned, pq.Array(arg.Column1), arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listTicketsAssigned,
+		arg.Assignee,
+		pq.Array(arg.OrgIds),
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -268,6 +782,17 @@ func (q *Queries) ListTicketsAssigned(ctx context.Context, arg ListTicketsAssign
 			&i.Priority,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ResolutionCode,
+			&i.StateReason,
+			&i.Impact,
+			&i.Urgency,
+			&i.PriorityOverridden,
+			&i.Rank,
+			&i.Type,
+			&i.OrgID,
+			&i.TeamID,
+			pq.Array(&i.Readers),
+			pq.Array(&i.Labels),
 		); err != nil {
 			return nil, err
 		}
@@ -284,36 +809,62 @@ func (q *Queries) ListTicketsAssigned(ctx context.Context, arg ListTicketsAssign
 
 const updateTicket = `-- name: UpdateTicket :one
 UPDATE tickets
-SET 
-    title = $2,
-    description = $3,
-    state = $4,
-    priority = $5,
-    assigned_to = $6,
-    updated_at = $7
-WHERE id = $1
-RETURNING id, created_by, assigned_to, title, description, state, priority, created_at, updated_at
+SET
+    title = $1,
+    description = $2,
+    state = $3,
+    priority = $4,
+    assigned_to = $5,
+    updated_at = $6,
+    resolution_code = $7,
+    state_reason = $8,
+    impact = $9,
+    urgency = $10,
+    priority_overridden = $11,
+    rank = $12,
+    team_id = $13,
+    labels = $14
+WHERE id = $15 AND ($16::uuid[] IS NULL OR org_id = ANY($16::uuid[]))
+RETURNING id, created_by, assigned_to, title, description, state, priority, created_at, updated_at, resolution_code, state_reason, impact, urgency, priority_overridden, rank, type, org_id, team_id, readers, labels
 `
 
 type UpdateTicketParams struct {
-	ID          uuid.UUID   `json:"id"`
-	Title       string      `json:"title"`
-	Description string      `json:"description"`
-	State       int32       `json:"state"`
-	Priority    int32       `json:"priority"`
-	AssignedTo  []uuid.UUID `json:"assigned_to"`
-	UpdatedAt   time.Time   `json:"updated_at"`
+	Title              string        `json:"title"`
+	Description        string        `json:"description"`
+	State              int32         `json:"state"`
+	Priority           int32         `json:"priority"`
+	AssignedTo         []uuid.UUID   `json:"assigned_to"`
+	UpdatedAt          time.Time     `json:"updated_at"`
+	ResolutionCode     string        `json:"resolution_code"`
+	StateReason        string        `json:"state_reason"`
+	Impact             int32         `json:"impact"`
+	Urgency            int32         `json:"urgency"`
+	PriorityOverridden bool          `json:"priority_overridden"`
+	Rank               string        `json:"rank"`
+	TeamID             uuid.NullUUID `json:"team_id"`
+	Labels             []string      `json:"labels"`
+	ID                 uuid.UUID     `json:"id"`
+	OrgIds             []uuid.UUID   `json:"org_ids"`
 }
 
 func (q *Queries) UpdateTicket(ctx context.Context, arg UpdateTicketParams) (Ticket, error) {
 	row := q.db.QueryRowContext(ctx, updateTicket,
-		arg.ID,
 		arg.Title,
 		arg.Description,
 		arg.State,
 		arg.Priority,
 		pq.Array(arg.AssignedTo),
 		arg.UpdatedAt,
+		arg.ResolutionCode,
+		arg.StateReason,
+		arg.Impact,
+		arg.Urgency,
+		arg.PriorityOverridden,
+		arg.Rank,
+		arg.TeamID,
+		pq.Array(arg.Labels),
+		arg.ID,
+		pq.Array(arg.OrgIds),
 	)
 	var i Ticket
 	err := row.Scan(
@@ -326,6 +877,17 @@ func (q *Queries) UpdateTicket(ctx context.Context, arg UpdateTicketParams) (Tic
 		&i.Priority,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ResolutionCode,
+		&i.StateReason,
+		&i.Impact,
+		&i.Urgency,
+		&i.PriorityOverridden,
+		&i.Rank,
+		&i.Type,
+		&i.OrgID,
+		&i.TeamID,
+		pq.Array(&i.Readers),
+		pq.Array(&i.Labels),
 	)
 	return i, err
 }
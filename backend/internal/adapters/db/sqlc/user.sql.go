@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 const createUser = `-- name: CreateUser :one
@@ -19,10 +20,11 @@ INSERT INTO users (
     first_name,
     last_name,
     email,
-    updated_at
+    updated_at,
+    org_id
 ) VALUES (
-    $1, $2, $3, $4, $5
-) RETURNING id, hashed_password, first_name, last_name, email, role, updated_at, created_at
+    $1, $2, $3, $4, $5, $6
+) RETURNING id, hashed_password, first_name, last_name, email, role, updated_at, created_at, org_id, session_version, email_verified_at
 `
 
 type CreateUserParams struct {
@@ -31,6 +33,7 @@ type CreateUserParams struct {
 	LastName       string    `json:"last_name"`
 	Email          string    `json:"email"`
 	UpdatedAt      time.Time `json:"updated_at"`
+	OrgID          uuid.UUID `json:"org_id"`
 }
 
 func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, error) {
@@ -40,6 +43,7 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		arg.LastName,
 		arg.Email,
 		arg.UpdatedAt,
+		arg.OrgID,
 	)
 	var i User
 	err := row.Scan(
@@ -51,22 +55,31 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.Role,
 		&i.UpdatedAt,
 		&i.CreatedAt,
+		&i.OrgID,
+		&i.SessionVersion,
+		&i.EmailVerifiedAt,
 	)
 	return i, err
 }
 
 const deleteUser = `-- name: DeleteUser :exec
 DELETE FROM users
-WHERE id = $1
+WHERE id = $1 AND ($2::uuid[] IS NULL OR org_id = ANY($2::uuid[]) OR EXISTS (SELECT 1 FROM user_org_access a WHERE a.user_id = users.id AND a.org_id = ANY($2::uuid[])))
 `
 
-func (q *Queries) DeleteUser(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.ExecContext(ctx, deleteUser, id)
+type DeleteUserParams struct {
+	ID     uuid.UUID   `json:"id"`
+	OrgIds []uuid.UUID `json:"org_ids"`
+}
+
+func (q *Queries) DeleteUser(ctx context.Context, arg DeleteUserParams) error {
+	_, err := q.db.ExecContext(ctx, deleteUser, arg.ID, pq.Array(arg.OrgIds))
 	return err
 }
 
 const getAllUsers = `-- name: GetAllUsers :many
 SELECT id, first_name, last_name, email FROM users
+WHERE ($1::uuid[] IS NULL OR org_id = ANY($1::uuid[]) OR EXISTS (SELECT 1 FROM user_org_access a WHERE a.user_id = users.id AND a.org_id = ANY($1::uuid[])))
 ORDER BY created_at DESC
 `
 
@@ -77,8 +90,8 @@ type GetAllUsersRow struct {
 	Email     string    `json:"email"`
 }
 
-func (q *Queries) GetAllUsers(ctx context.Context) ([]GetAllUsersRow, error) {
-	rows, err := q.db.QueryContext(ctx, getAllUsers)
+func (q *Queries) GetAllUsers(ctx context.Context, orgIds []uuid.UUID) ([]GetAllUsersRow, error) {
+	rows, err := q.db.QueryContext(ctx, getAllUsers, pq.Array(orgIds))
 	if err != nil {
 		return nil, err
 	}
@@ -106,12 +119,18 @@ func (q *Queries) GetAllUsers(ctx context.Context) ([]GetAllUsersRow, error) {
 }
 
 const getUser = `-- name: GetUser :one
-SELECT id, hashed_password, first_name, last_name, email, role, updated_at, created_at FROM users
-WHERE id = $1 LIMIT 1
+SELECT id, hashed_password, first_name, last_name, email, role, updated_at, created_at, org_id, session_version, email_verified_at FROM users
+WHERE id = $1 AND ($2::uuid[] IS NULL OR org_id = ANY($2::uuid[]) OR EXISTS (SELECT 1 FROM user_org_access a WHERE a.user_id = users.id AND a.org_id = ANY($2::uuid[])))
+LIMIT 1
 `
 
-func (q *Queries) GetUser(ctx context.Context, id uuid.UUID) (User, error) {
-	row := q.db.QueryRowContext(ctx, getUser, id)
+type GetUserParams struct {
+	ID     uuid.UUID   `json:"id"`
+	OrgIds []uuid.UUID `json:"org_ids"`
+}
+
+func (q *Queries) GetUser(ctx context.Context, arg GetUserParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUser, arg.ID, pq.Array(arg.OrgIds))
 	var i User
 	err := row.Scan(
 		&i.ID,
@@ -122,12 +141,15 @@ func (q *Queries) GetUser(ctx context.Context, id uuid.UUID) (User, error) {
 		&i.Role,
 		&i.UpdatedAt,
 		&i.CreatedAt,
+		&i.OrgID,
+		&i.SessionVersion,
+		&i.EmailVerifiedAt,
 	)
 	return i, err
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, hashed_password, first_name, last_name, email, role, updated_at, created_at FROM users
+SELECT id, hashed_password, first_name, last_name, email, role, updated_at, created_at, org_id, session_version, email_verified_at FROM users
 WHERE email = $1 LIMIT 1
 `
 
@@ -143,23 +165,75 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.Role,
 		&i.UpdatedAt,
 		&i.CreatedAt,
+		&i.OrgID,
+		&i.SessionVersion,
+		&i.EmailVerifiedAt,
 	)
 	return i, err
 }
 
+const listMentionableUsers = `-- name: ListMentionableUsers :many
+SELECT id, hashed_password, first_name, last_name, email, role, updated_at, created_at, org_id, session_version, email_verified_at FROM users
+WHERE (org_id = $1 OR (role IN ('agent', 'admin') AND EXISTS (SELECT 1 FROM user_org_access a WHERE a.user_id = users.id AND a.org_id = $1)))
+  AND (lower(email) = ANY($2::text[]) OR lower(split_part(email, '@', 1)) = ANY($3::text[]))
+`
+
+type ListMentionableUsersParams struct {
+	OrgID  uuid.UUID `json:"org_id"`
+	Emails []string  `json:"emails"`
+	Names  []string  `json:"names"`
+}
+
+func (q *Queries) ListMentionableUsers(ctx context.Context, arg ListMentionableUsersParams) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, listMentionableUsers, arg.OrgID, pq.Array(arg.Emails), pq.Array(arg.Names))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.HashedPassword,
+			&i.FirstName,
+			&i.LastName,
+			&i.Email,
+			&i.Role,
+			&i.UpdatedAt,
+			&i.CreatedAt,
+			&i.OrgID,
+			&i.SessionVersion,
+			&i.EmailVerifiedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listUsers = `-- name: ListUsers :many
-SELECT id, hashed_password, first_name, last_name, email, role, updated_at, created_at FROM users
+SELECT id, hashed_password, first_name, last_name, email, role, updated_at, created_at, org_id, session_version, email_verified_at FROM users
+WHERE ($1::uuid[] IS NULL OR org_id = ANY($1::uuid[]) OR EXISTS (SELECT 1 FROM user_org_access a WHERE a.user_id = users.id AND a.org_id = ANY($1::uuid[])))
 ORDER BY created_at DESC
-LIMIT $1 OFFSET $2
+LIMIT $2 OFFSET $3
 `
 
 type ListUsersParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	OrgIds []uuid.UUID `json:"org_ids"`
+	Limit  int32       `json:"limit"`
+	Offset int32       `json:"offset"`
 }
 
 func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error) {
-	rows, err := q.db.QueryContext(ctx, listUsers, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listUsers, pq.Array(arg.OrgIds), arg.Limit, arg.Offset)
 	if err != nil {
 		return nil, err
 	}
@@ -176,6 +250,58 @@ func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, e
 			&i.Role,
 			&i.UpdatedAt,
 			&i.CreatedAt,
+			&i.OrgID,
+			&i.SessionVersion,
+			&i.EmailVerifiedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchUsers = `-- name: SearchUsers :many
+SELECT id, first_name, last_name, email FROM users
+WHERE ($1::uuid[] IS NULL OR org_id = ANY($1::uuid[]) OR EXISTS (SELECT 1 FROM user_org_access a WHERE a.user_id = users.id AND a.org_id = ANY($1::uuid[])))
+  AND (email ILIKE $2 OR first_name ILIKE $2 OR last_name ILIKE $2 OR first_name || ' ' || last_name ILIKE $2)
+ORDER BY first_name, last_name
+LIMIT $3
+`
+
+type SearchUsersParams struct {
+	OrgIds     []uuid.UUID `json:"org_ids"`
+	Pattern    string      `json:"pattern"`
+	MaxResults int32       `json:"max_results"`
+}
+
+type SearchUsersRow struct {
+	ID        uuid.UUID `json:"id"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+	Email     string    `json:"email"`
+}
+
+func (q *Queries) SearchUsers(ctx context.Context, arg SearchUsersParams) ([]SearchUsersRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchUsers, pq.Array(arg.OrgIds), arg.Pattern, arg.MaxResults)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchUsersRow{}
+	for rows.Next() {
+		var i SearchUsersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.FirstName,
+			&i.LastName,
+			&i.Email,
 		); err != nil {
 			return nil, err
 		}
@@ -192,29 +318,126 @@ func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, e
 
 const updateUser = `-- name: UpdateUser :one
 UPDATE users
-SET email = $2, first_name = $3, last_name = $4, role = $5, updated_at = $6
-WHERE id = $1
-RETURNING id, hashed_password, first_name, last_name, email, role, updated_at, created_at
+SET email = $1, first_name = $2, last_name = $3, role = $4, updated_at = $5
+WHERE id = $6 AND ($7::uuid[] IS NULL OR org_id = ANY($7::uuid[]) OR EXISTS (SELECT 1 FROM user_org_access a WHERE a.user_id = users.id AND a.org_id = ANY($7::uuid[])))
+RETURNING id, hashed_password, first_name, last_name, email, role, updated_at, created_at, org_id, session_version, email_verified_at
 `
 
 type UpdateUserParams struct {
-	ID        uuid.UUID      `json:"id"`
 	Email     string         `json:"email"`
 	FirstName string         `json:"first_name"`
 	LastName  string         `json:"last_name"`
 	Role      sql.NullString `json:"role"`
 	UpdatedAt time.Time      `json:"updated_at"`
+	ID        uuid.UUID      `json:"id"`
+	OrgIds    []uuid.UUID    `json:"org_ids"`
 }
 
 func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error) {
 	row := q.db.QueryRowContext(ctx, updateUser,
-		arg.ID,
 		arg.Email,
 		arg.FirstName,
 		arg.LastName,
 		arg.Role,
 		arg.UpdatedAt,
+		arg.ID,
+		pq.Array(arg.OrgIds),
+	)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.HashedPassword,
+		&i.FirstName,
+		&i.LastName,
+		&i.Email,
+		&i.Role,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+		&i.OrgID,
+		&i.SessionVersion,
+		&i.EmailVerifiedAt,
+	)
+	return i, err
+}
+
+const updateUserOrg = `-- name: UpdateUserOrg :one
+UPDATE users
+SET org_id = $2, updated_at = $3
+WHERE id = $1
+RETURNING id, hashed_password, first_name, last_name, email, role, updated_at, created_at, org_id, session_version, email_verified_at
+`
+
+type UpdateUserOrgParams struct {
+	ID        uuid.UUID `json:"id"`
+	OrgID     uuid.UUID `json:"org_id"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (q *Queries) UpdateUserOrg(ctx context.Context, arg UpdateUserOrgParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateUserOrg, arg.ID, arg.OrgID, arg.UpdatedAt)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.HashedPassword,
+		&i.FirstName,
+		&i.LastName,
+		&i.Email,
+		&i.Role,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+		&i.OrgID,
+		&i.SessionVersion,
+		&i.EmailVerifiedAt,
 	)
+	return i, err
+}
+
+const updateUserPassword = `-- name: UpdateUserPassword :one
+UPDATE users
+SET hashed_password = $1, session_version = session_version + 1, updated_at = $2
+WHERE id = $3
+RETURNING id, hashed_password, first_name, last_name, email, role, updated_at, created_at, org_id, session_version, email_verified_at
+`
+
+type UpdateUserPasswordParams struct {
+	HashedPassword string    `json:"hashed_password"`
+	UpdatedAt      time.Time `json:"updated_at"`
+	ID             uuid.UUID `json:"id"`
+}
+
+func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateUserPassword, arg.HashedPassword, arg.UpdatedAt, arg.ID)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.HashedPassword,
+		&i.FirstName,
+		&i.LastName,
+		&i.Email,
+		&i.Role,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+		&i.OrgID,
+		&i.SessionVersion,
+		&i.EmailVerifiedAt,
+	)
+	return i, err
+}
+
+const verifyUserEmail = `-- name: VerifyUserEmail :one
+UPDATE users
+SET email_verified_at = $1
+WHERE id = $2
+RETURNING id, hashed_password, first_name, last_name, email, role, updated_at, created_at, org_id, session_version, email_verified_at
+`
+
+type VerifyUserEmailParams struct {
+	EmailVerifiedAt sql.NullTime `json:"email_verified_at"`
+	ID              uuid.UUID    `json:"id"`
+}
+
+func (q *Queries) VerifyUserEmail(ctx context.Context, arg VerifyUserEmailParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, verifyUserEmail, arg.EmailVerifiedAt, arg.ID)
 	var i User
 	err := row.Scan(
 		&i.ID,
@@ -225,6 +448,9 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.Role,
 		&i.UpdatedAt,
 		&i.CreatedAt,
+		&i.OrgID,
+		&i.SessionVersion,
+		&i.EmailVerifiedAt,
 	)
 	return i, err
 }
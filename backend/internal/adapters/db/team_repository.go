@@ -0,0 +1,106 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	sqlc "github.com/nickhildpac/ticket-management-app/internal/adapters/db/sqlc"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+)
+
+type TeamRepository struct {
+	store sqlc.Store
+}
+
+func NewTeamRepository(store sqlc.Store) *TeamRepository {
+	return &TeamRepository{store: store}
+}
+
+func (r *TeamRepository) List(ctx context.Context) ([]domain.Team, error) {
+	rows, err := r.store.ListTeams(ctx, orgScope(ctx))
+	if err != nil {
+		return nil, err
+	}
+	out := make([]domain.Team, 0, len(rows))
+	for _, row := range rows {
+		team, err := r.withMembers(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *team)
+	}
+	return out, nil
+}
+
+func (r *TeamRepository) Get(ctx context.Context, id uuid.UUID) (*domain.Team, error) {
+	team, err := r.store.GetTeam(ctx, sqlc.GetTeamParams{ID: id, OrgIds: orgScope(ctx)})
+	if err != nil {
+		return nil, err
+	}
+	return r.withMembers(ctx, team)
+}
+
+func (r *TeamRepository) Create(ctx context.Context, team domain.Team) (*domain.Team, error) {
+	created, err := r.store.CreateTeam(ctx, sqlc.CreateTeamParams{
+		OrgID:     team.OrgID,
+		Name:      team.Name,
+		LeadID:    toNullUUID(team.LeadID),
+		UpdatedAt: time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapTeam(created, []uuid.UUID{}), nil
+}
+
+func (r *TeamRepository) Update(ctx context.Context, team domain.Team) (*domain.Team, error) {
+	updated, err := r.store.UpdateTeam(ctx, sqlc.UpdateTeamParams{
+		Name:      team.Name,
+		LeadID:    toNullUUID(team.LeadID),
+		UpdatedAt: time.Now(),
+		ID:        team.ID,
+		OrgIds:    orgScope(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.withMembers(ctx, updated)
+}
+
+func (r *TeamRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.store.DeleteTeam(ctx, sqlc.DeleteTeamParams{ID: id, OrgIds: orgScope(ctx)})
+}
+
+func (r *TeamRepository) AddMember(ctx context.Context, teamID, userID uuid.UUID) error {
+	return r.store.AddTeamMember(ctx, sqlc.AddTeamMemberParams{TeamID: teamID, UserID: userID})
+}
+
+func (r *TeamRepository) RemoveMember(ctx context.Context, teamID, userID uuid.UUID) error {
+	return r.store.RemoveTeamMember(ctx, sqlc.RemoveTeamMemberParams{
+		TeamID: teamID,
+		UserID: userID,
+		OrgIds: orgScope(ctx),
+	})
+}
+
+func (r *TeamRepository) SetCalendar(ctx context.Context, teamID uuid.UUID, calendarID *uuid.UUID) (*domain.Team, error) {
+	updated, err := r.store.SetTeamCalendar(ctx, sqlc.SetTeamCalendarParams{
+		CalendarID: toNullUUID(calendarID),
+		UpdatedAt:  time.Now(),
+		ID:         teamID,
+		OrgIds:     orgScope(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.withMembers(ctx, updated)
+}
+
+func (r *TeamRepository) withMembers(ctx context.Context, team sqlc.Team) (*domain.Team, error) {
+	members, err := r.store.ListTeamMembers(ctx, sqlc.ListTeamMembersParams{TeamID: team.ID, OrgIds: orgScope(ctx)})
+	if err != nil {
+		return nil, err
+	}
+	return mapTeam(team, members), nil
+}
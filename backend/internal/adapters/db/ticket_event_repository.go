@@ -0,0 +1,46 @@
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	sqlc "github.com/nickhildpac/ticket-management-app/internal/adapters/db/sqlc"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+)
+
+type TicketEventRepository struct {
+	store sqlc.Store
+}
+
+func NewTicketEventRepository(store sqlc.Store) *TicketEventRepository {
+	return &TicketEventRepository{store: store}
+}
+
+func (r *TicketEventRepository) Create(ctx context.Context, event domain.TicketEvent) (*domain.TicketEvent, error) {
+	created, err := r.store.CreateTicketEvent(ctx, sqlc.CreateTicketEventParams{
+		TicketID: event.TicketID,
+		ActorID:  event.ActorID,
+		Kind:     string(event.Kind),
+		OldValue: event.OldValue,
+		NewValue: event.NewValue,
+		Reason:   event.Reason,
+		Note:     event.Note,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapTicketEvent(created), nil
+}
+
+func (r *TicketEventRepository) ListByTicket(ctx context.Context, ticketID uuid.UUID, limit, offset int32) ([]domain.TicketEvent, error) {
+	rows, err := r.store.ListTicketEvents(ctx, sqlc.ListTicketEventsParams{
+		TicketID: ticketID,
+		OrgIds:   orgScope(ctx),
+		Limit:    limit,
+		Offset:   offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapTicketEvents(rows), nil
+}
@@ -2,6 +2,8 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 	sqlc "github.com/nickhildpac/ticket-management-app/internal/adapters/db/sqlc"
@@ -17,7 +19,7 @@ func NewTicketRepository(store sqlc.Store) *TicketRepository {
 }
 
 func (r *TicketRepository) ListAll(ctx context.Context, limit, offset int32) ([]domain.Ticket, error) {
-	rows, err := r.store.ListAllTickets(ctx, sqlc.ListAllTicketsParams{Limit: limit, Offset: offset})
+	rows, err := r.store.ListAllTickets(ctx, sqlc.ListAllTicketsParams{OrgIds: orgScope(ctx), Limit: limit, Offset: offset})
 	if err != nil {
 		return nil, err
 	}
@@ -25,12 +27,13 @@ func (r *TicketRepository) ListAll(ctx context.Context, limit, offset int32) ([]
 }
 
 func (r *TicketRepository) ListByCreator(ctx context.Context, id uuid.UUID, limit, offset int32) ([]domain.Ticket, error) {
-	user, err := r.store.GetUser(ctx, id)
+	user, err := r.store.GetUser(ctx, sqlc.GetUserParams{ID: id, OrgIds: orgScope(ctx)})
 	if err != nil {
 		return nil, err
 	}
 	rows, err := r.store.ListTickets(ctx, sqlc.ListTicketsParams{
 		CreatedBy: user.ID,
+		OrgIds:    orgScope(ctx),
 		Limit:     limit,
 		Offset:    offset,
 	})
@@ -41,14 +44,15 @@ func (r *TicketRepository) ListByCreator(ctx context.Context, id uuid.UUID, limi
 }
 
 func (r *TicketRepository) ListByAssignee(ctx context.Context, id uuid.UUID, limit, offset int32) ([]domain.Ticket, error) {
-	user, err := r.store.GetUser(ctx, id)
+	user, err := r.store.GetUser(ctx, sqlc.GetUserParams{ID: id, OrgIds: orgScope(ctx)})
 	if err != nil {
 		return nil, err
 	}
 	rows, err := r.store.ListTicketsAssigned(ctx, sqlc.ListTicketsAssignedParams{
-		Column1: []uuid.UUID{user.ID},
-		Limit:   limit,
-		Offset:  offset,
+		Assignee: user.ID,
+		OrgIds:   orgScope(ctx),
+		Limit:    limit,
+		Offset:   offset,
 	})
 	if err != nil {
 		return nil, err
@@ -57,7 +61,10 @@ func (r *TicketRepository) ListByAssignee(ctx context.Context, id uuid.UUID, lim
 }
 
 func (r *TicketRepository) Get(ctx context.Context, id uuid.UUID) (*domain.Ticket, error) {
-	ticket, err := r.store.GetTicket(ctx, id)
+	ticket, err := r.store.GetTicket(ctx, sqlc.GetTicketParams{ID: id, OrgIds: orgScope(ctx)})
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrTicketNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -70,6 +77,11 @@ func (r *TicketRepository) Create(ctx context.Context, ticket domain.Ticket) (*d
 		Description: ticket.Description,
 		CreatedBy:   ticket.CreatedBy,
 		UpdatedAt:   ticket.UpdatedAt,
+		Impact:      int32(ticket.Impact),
+		Urgency:     int32(ticket.Urgency),
+		Priority:    int32(ticket.Priority),
+		Rank:        ticket.Rank,
+		Type:        ticket.Type,
 	})
 	if err != nil {
 		return nil, err
@@ -79,13 +91,22 @@ func (r *TicketRepository) Create(ctx context.Context, ticket domain.Ticket) (*d
 
 func (r *TicketRepository) Update(ctx context.Context, ticket domain.Ticket) (*domain.Ticket, error) {
 	updated, err := r.store.UpdateTicket(ctx, sqlc.UpdateTicketParams{
-		ID:          ticket.ID,
-		Title:       ticket.Title,
-		Description: ticket.Description,
-		State:       int32(ticket.State),
-		Priority:    int32(ticket.Priority),
-		AssignedTo:  ticket.AssignedTo,
-		UpdatedAt:   ticket.UpdatedAt,
+		ID:                 ticket.ID,
+		Title:              ticket.Title,
+		Description:        ticket.Description,
+		State:              int32(ticket.State),
+		Priority:           int32(ticket.Priority),
+		AssignedTo:         ticket.AssignedTo,
+		UpdatedAt:          ticket.UpdatedAt,
+		ResolutionCode:     string(ticket.ResolutionCode),
+		StateReason:        ticket.StateReason,
+		Impact:             int32(ticket.Impact),
+		Urgency:            int32(ticket.Urgency),
+		PriorityOverridden: ticket.PriorityOverridden,
+		Rank:               ticket.Rank,
+		TeamID:             toNullUUID(ticket.TeamID),
+		Labels:             domain.NormalizeLabels(ticket.Labels),
+		OrgIds:             orgScope(ctx),
 	})
 	if err != nil {
 		return nil, err
@@ -93,6 +114,107 @@ func (r *TicketRepository) Update(ctx context.Context, ticket domain.Ticket) (*d
 	return mapTicket(updated), nil
 }
 
+func (r *TicketRepository) HandOff(ctx context.Context, id, fromUserID, toUserID uuid.UUID, teamID *uuid.UUID) (*domain.Ticket, error) {
+	updated, err := r.store.HandOffTicket(ctx, sqlc.HandOffTicketParams{
+		ToUserID:   toUserID,
+		FromUserID: fromUserID,
+		TeamID:     toNullUUID(teamID),
+		UpdatedAt:  time.Now(),
+		ID:         id,
+		OrgIds:     orgScope(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapTicket(updated), nil
+}
+
+func (r *TicketRepository) AddReaders(ctx context.Context, id uuid.UUID, userIDs []uuid.UUID) error {
+	return r.store.AddTicketReaders(ctx, sqlc.AddTicketReadersParams{UserIds: userIDs, ID: id, OrgIds: orgScope(ctx)})
+}
+
+func (r *TicketRepository) LastRank(ctx context.Context, state domain.TicketState) (string, error) {
+	return r.store.GetLastTicketRank(ctx, sqlc.GetLastTicketRankParams{State: int32(state), OrgIds: orgScope(ctx)})
+}
+
+// Delete removes the ticket with its comments. Comment revisions don't go
+// with a comment on their own, so they are removed first.
 func (r *TicketRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.store.DeleteTicket(ctx, id)
+	return r.store.ExecTx(ctx, func(ctx context.Context) error {
+		err := r.store.DeleteTicketCommentRevisions(ctx, sqlc.DeleteTicketCommentRevisionsParams{TicketID: id, OrgIds: orgScope(ctx)})
+		if err != nil {
+			return err
+		}
+		return r.store.DeleteTicket(ctx, sqlc.DeleteTicketParams{ID: id, OrgIds: orgScope(ctx)})
+	})
+}
+
+func (r *TicketRepository) CountByResolution(ctx context.Context) ([]domain.ResolutionCount, error) {
+	rows, err := r.store.CountTicketsByResolutionCode(ctx, orgScope(ctx))
+	if err != nil {
+		return nil, err
+	}
+	out := make([]domain.ResolutionCount, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, domain.ResolutionCount{
+			ResolutionCode: domain.ResolutionCode(row.ResolutionCode),
+			Tickets:        row.Tickets,
+		})
+	}
+	return out, nil
+}
+
+func (r *TicketRepository) FindSimilar(ctx context.Context, title, description string, limit int32) ([]domain.TicketMatch, error) {
+	rows, err := r.store.FindSimilarTickets(ctx, sqlc.FindSimilarTicketsParams{
+		Title:       title,
+		Description: description,
+		OrgIds:      orgScope(ctx),
+		MaxResults:  limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]domain.TicketMatch, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, domain.TicketMatch{
+			Ticket: *mapTicket(row.Ticket),
+			Score:  row.Score,
+		})
+	}
+	return out, nil
+}
+
+func (r *TicketRepository) List(ctx context.Context, scope domain.TicketScope, filter domain.TicketFilter, limit, offset int32) ([]domain.Ticket, error) {
+	rows, err := r.store.ListFilteredTickets(ctx, sqlc.ListFilteredTicketsParams{
+		ScopeCreator:  toNullUUID(scope.CreatedBy),
+		ScopeAssignee: toNullUUID(scope.AssignedTo),
+		ScopeTeams:    scope.TeamIDs,
+		OrgIds:        orgScope(ctx),
+		States:        toInt32s(filter.States),
+		Priorities:    toInt32s(filter.Priorities),
+		Assignee:      toNullUUID(filter.AssigneeID),
+		Unassigned:    filter.Unassigned,
+		Creator:       toNullUUID(filter.CreatedBy),
+		Search:        filter.Search,
+		Sort:          string(filter.Sort),
+		Limit:         limit,
+		Offset:        offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapTickets(rows), nil
+}
+
+func (r *TicketRepository) ListByTeams(ctx context.Context, teamIDs []uuid.UUID, limit, offset int32) ([]domain.Ticket, error) {
+	rows, err := r.store.ListTeamTickets(ctx, sqlc.ListTeamTicketsParams{
+		TeamIds: teamIDs,
+		OrgIds:  orgScope(ctx),
+		Limit:   limit,
+		Offset:  offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapTickets(rows), nil
 }
@@ -0,0 +1,45 @@
+package db
+
+import (
+	"context"
+
+	sqlc "github.com/nickhildpac/ticket-management-app/internal/adapters/db/sqlc"
+)
+
+// Transactor runs work in a database transaction. Repositories sharing its
+// store join the transaction through the context.
+type Transactor struct {
+	store sqlc.Store
+}
+
+func NewTransactor(store sqlc.Store) *Transactor {
+	return &Transactor{store: store}
+}
+
+// afterCommitKey holds the work queued by AfterCommit for the outermost
+// WithinTx
+type afterCommitKey struct{}
+
+func (t *Transactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(afterCommitKey{}).(*[]func(context.Context)); ok {
+		return t.store.ExecTx(ctx, fn)
+	}
+
+	var hooks []func(context.Context)
+	if err := t.store.ExecTx(context.WithValue(ctx, afterCommitKey{}, &hooks), fn); err != nil {
+		return err
+	}
+	// The transaction is over, so the hooks get the context it started with
+	for _, hook := range hooks {
+		hook(ctx)
+	}
+	return nil
+}
+
+func (t *Transactor) AfterCommit(ctx context.Context, fn func(ctx context.Context)) {
+	if hooks, ok := ctx.Value(afterCommitKey{}).(*[]func(context.Context)); ok {
+		*hooks = append(*hooks, fn)
+		return
+	}
+	fn(ctx)
+}
@@ -0,0 +1,96 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	sqlc "github.com/nickhildpac/ticket-management-app/internal/adapters/db/sqlc"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+)
+
+type TransferRepository struct {
+	store sqlc.Store
+}
+
+func NewTransferRepository(store sqlc.Store) *TransferRepository {
+	return &TransferRepository{store: store}
+}
+
+func (r *TransferRepository) Create(ctx context.Context, t domain.Transfer) (*domain.Transfer, error) {
+	created, err := r.store.CreateTicketTransfer(ctx, sqlc.CreateTicketTransferParams{
+		TicketID:   t.TicketID,
+		FromUserID: t.FromUserID,
+		ToUserID:   toNullUUID(t.ToUserID),
+		ToTeamID:   toNullUUID(t.ToTeamID),
+		Note:       t.Note,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapTransfer(created), nil
+}
+
+func (r *TransferRepository) Get(ctx context.Context, id uuid.UUID) (*domain.Transfer, error) {
+	t, err := r.store.GetTicketTransfer(ctx, sqlc.GetTicketTransferParams{ID: id, OrgIds: orgScope(ctx)})
+	if err != nil {
+		return nil, err
+	}
+	return mapTransfer(t), nil
+}
+
+func (r *TransferRepository) GetPending(ctx context.Context, ticketID uuid.UUID) (*domain.Transfer, error) {
+	t, err := r.store.GetPendingTicketTransfer(ctx, sqlc.GetPendingTicketTransferParams{
+		TicketID: ticketID,
+		OrgIds:   orgScope(ctx),
+	})
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return mapTransfer(t), nil
+}
+
+func (r *TransferRepository) ListByTicket(ctx context.Context, ticketID uuid.UUID) ([]domain.Transfer, error) {
+	rows, err := r.store.ListTicketTransfers(ctx, sqlc.ListTicketTransfersParams{
+		TicketID: ticketID,
+		OrgIds:   orgScope(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapTransfers(rows), nil
+}
+
+func (r *TransferRepository) ListIncoming(ctx context.Context, userID uuid.UUID, teamIDs []uuid.UUID) ([]domain.Transfer, error) {
+	rows, err := r.store.ListIncomingTransfers(ctx, sqlc.ListIncomingTransfersParams{
+		UserID:  uuid.NullUUID{UUID: userID, Valid: true},
+		TeamIds: teamIDs,
+		OrgIds:  orgScope(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapTransfers(rows), nil
+}
+
+func (r *TransferRepository) Decide(ctx context.Context, t domain.Transfer) (*domain.Transfer, error) {
+	decided, err := r.store.DecideTicketTransfer(ctx, sqlc.DecideTicketTransferParams{
+		Status:    string(t.Status),
+		DecidedBy: toNullUUID(t.DecidedBy),
+		Reason:    t.Reason,
+		DecidedAt: toNullTime(t.DecidedAt),
+		ID:        t.ID,
+		OrgIds:    orgScope(ctx),
+	})
+	if err == sql.ErrNoRows {
+		// The transfer was accepted, declined or cancelled in the meantime
+		return nil, domain.ErrNoPendingTransfer
+	}
+	if err != nil {
+		return nil, err
+	}
+	return mapTransfer(decided), nil
+}
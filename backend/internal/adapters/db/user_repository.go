@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"log"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	sqlc "github.com/nickhildpac/ticket-management-app/internal/adapters/db/sqlc"
@@ -20,13 +22,19 @@ func NewUserRepository(store sqlc.Store) *UserRepository {
 
 func (r *UserRepository) GetUser(ctx context.Context, email string) (*domain.User, error) {
 	user, err := r.store.GetUserByEmail(ctx, email)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrUserNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
 	return mapUser(user), nil
 }
 func (r *UserRepository) GetUserByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
-	user, err := r.store.GetUser(ctx, id)
+	user, err := r.store.GetUser(ctx, sqlc.GetUserParams{ID: id, OrgIds: orgScope(ctx)})
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrUserNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -40,6 +48,7 @@ func (r *UserRepository) CreateUser(ctx context.Context, user domain.User) (*dom
 		Email:          user.Email,
 		HashedPassword: user.HashedPassword,
 		UpdatedAt:      user.UpdatedAt,
+		OrgID:          user.OrgID,
 	})
 	if err != nil {
 		log.Println("Error creating userrepo:", err)
@@ -49,7 +58,7 @@ func (r *UserRepository) CreateUser(ctx context.Context, user domain.User) (*dom
 }
 
 func (r *UserRepository) GetAllUsers(ctx context.Context) ([]domain.User, error) {
-	users, err := r.store.GetAllUsers(ctx)
+	users, err := r.store.GetAllUsers(ctx, orgScope(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -74,6 +83,31 @@ func (r *UserRepository) UpdateUser(ctx context.Context, user *domain.User) (*do
 		LastName:  user.LastName,
 		Role:      sql.NullString{String: string(user.Role), Valid: user.Role != ""},
 		UpdatedAt: user.UpdatedAt,
+		OrgIds:    orgScope(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapUser(updated), nil
+}
+
+func (r *UserRepository) UpdatePassword(ctx context.Context, id uuid.UUID, hashedPassword string) (*domain.User, error) {
+	updated, err := r.store.UpdateUserPassword(ctx, sqlc.UpdateUserPasswordParams{
+		ID:             id,
+		HashedPassword: hashedPassword,
+		UpdatedAt:      time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapUser(updated), nil
+}
+
+func (r *UserRepository) VerifyEmail(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	now := time.Now()
+	updated, err := r.store.VerifyUserEmail(ctx, sqlc.VerifyUserEmailParams{
+		ID:              id,
+		EmailVerifiedAt: toNullTime(&now),
 	})
 	if err != nil {
 		return nil, err
@@ -82,5 +116,52 @@ func (r *UserRepository) UpdateUser(ctx context.Context, user *domain.User) (*do
 }
 
 func (r *UserRepository) DeleteUser(ctx context.Context, id uuid.UUID) error {
-	return r.store.DeleteUser(ctx, id)
+	return r.store.DeleteUser(ctx, sqlc.DeleteUserParams{ID: id, OrgIds: orgScope(ctx)})
+}
+
+func (r *UserRepository) ListOrgAccess(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	return r.store.ListUserOrgAccess(ctx, id)
+}
+
+func (r *UserRepository) ListTeamIDs(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	return r.store.ListUserTeams(ctx, id)
+}
+
+func (r *UserRepository) ListMentionable(ctx context.Context, orgID uuid.UUID, emails, names []string) ([]domain.User, error) {
+	users, err := r.store.ListMentionableUsers(ctx, sqlc.ListMentionableUsersParams{OrgID: orgID, Emails: emails, Names: names})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]domain.User, 0, len(users))
+	for _, u := range users {
+		out = append(out, *mapUser(u))
+	}
+	return out, nil
+}
+
+func (r *UserRepository) Search(ctx context.Context, query string, limit int32) ([]domain.User, error) {
+	users, err := r.store.SearchUsers(ctx, sqlc.SearchUsersParams{
+		OrgIds:     orgScope(ctx),
+		Pattern:    likePrefix(query),
+		MaxResults: limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]domain.User, 0, len(users))
+	for _, u := range users {
+		out = append(out, domain.User{
+			ID:        u.ID,
+			FirstName: u.FirstName,
+			LastName:  u.LastName,
+			Email:     u.Email,
+		})
+	}
+	return out, nil
+}
+
+// likePrefix turns text into a LIKE pattern matching strings that start
+// with it
+func likePrefix(text string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(text) + "%"
 }
@@ -0,0 +1,52 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	sqlc "github.com/nickhildpac/ticket-management-app/internal/adapters/db/sqlc"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+)
+
+type UserTokenRepository struct {
+	store sqlc.Store
+}
+
+func NewUserTokenRepository(store sqlc.Store) *UserTokenRepository {
+	return &UserTokenRepository{store: store}
+}
+
+func (r *UserTokenRepository) Create(ctx context.Context, token domain.UserToken) (*domain.UserToken, error) {
+	created, err := r.store.CreateUserToken(ctx, sqlc.CreateUserTokenParams{
+		UserID:    token.UserID,
+		Purpose:   string(token.Purpose),
+		TokenHash: token.TokenHash,
+		ExpiresAt: token.ExpiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapUserToken(created), nil
+}
+
+func (r *UserTokenRepository) Consume(ctx context.Context, tokenHash string, purpose domain.TokenPurpose) (*domain.UserToken, error) {
+	token, err := r.store.ConsumeUserToken(ctx, sqlc.ConsumeUserTokenParams{
+		TokenHash: tokenHash,
+		Purpose:   string(purpose),
+	})
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrInvalidUserToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	return mapUserToken(token), nil
+}
+
+func (r *UserTokenRepository) Revoke(ctx context.Context, userID uuid.UUID, purpose domain.TokenPurpose) error {
+	return r.store.RevokeUserTokens(ctx, sqlc.RevokeUserTokensParams{
+		UserID:  userID,
+		Purpose: string(purpose),
+	})
+}
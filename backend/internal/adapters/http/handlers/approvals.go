@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/nickhildpac/ticket-management-app/internal/application/authorization"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+	"github.com/nickhildpac/ticket-management-app/pkg/util"
+)
+
+type ApprovalDecisionPayload struct {
+	Comment string `json:"comment"`
+}
+
+// ApprovalStepPayload names either an approver user or a role
+type ApprovalStepPayload struct {
+	ApproverID   *uuid.UUID `json:"approver_id"`
+	ApproverRole *string    `json:"approver_role"`
+}
+
+func approvalError(w http.ResponseWriter, err error) {
+	if err == authorization.ErrAccessDenied {
+		util.ErrorResponse(w, http.StatusForbidden, err)
+		return
+	}
+	if errors.Is(err, domain.ErrInvalidTicketType) ||
+		errors.Is(err, domain.ErrInvalidApprover) ||
+		errors.Is(err, domain.ErrRejectionNeedsComment) {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+	if errors.Is(err, domain.ErrNoPendingApproval) || errors.Is(err, domain.ErrApprovalTicketNotOpen) {
+		util.ErrorResponse(w, http.StatusConflict, err)
+		return
+	}
+	util.ErrorResponse(w, http.StatusInternalServerError, err)
+}
+
+func (h *Handler) GetTicketApprovals(w http.ResponseWriter, r *http.Request) {
+	tid, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	approvals, err := h.approvalService.ListForTicket(r.Context(), tid)
+	if err != nil {
+		approvalError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, approvals)
+}
+
+func (h *Handler) ApproveTicket(w http.ResponseWriter, r *http.Request) {
+	h.decideApproval(w, r, true)
+}
+
+func (h *Handler) RejectTicket(w http.ResponseWriter, r *http.Request) {
+	h.decideApproval(w, r, false)
+}
+
+func (h *Handler) decideApproval(w http.ResponseWriter, r *http.Request, approve bool) {
+	tid, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var payload ApprovalDecisionPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	decided, err := h.approvalService.Decide(r.Context(), tid, approve, payload.Comment)
+	if err != nil {
+		approvalError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, decided)
+}
+
+func (h *Handler) GetPendingApprovals(w http.ResponseWriter, r *http.Request) {
+	approvals, err := h.approvalService.ListPending(r.Context())
+	if err != nil {
+		approvalError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, approvals)
+}
+
+func (h *Handler) GetApprovalStepTypes(w http.ResponseWriter, r *http.Request) {
+	types, err := h.approvalService.ListStepTypes(r.Context())
+	if err != nil {
+		approvalError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, types)
+}
+
+func (h *Handler) GetApprovalSteps(w http.ResponseWriter, r *http.Request) {
+	steps, err := h.approvalService.ListSteps(r.Context(), chi.URLParam(r, "type"))
+	if err != nil {
+		approvalError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, steps)
+}
+
+// UpdateApprovalSteps replaces the steps of a ticket type; an empty list
+// removes the approval requirement
+func (h *Handler) UpdateApprovalSteps(w http.ResponseWriter, r *http.Request) {
+	var payload []ApprovalStepPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	steps := make([]domain.ApprovalStep, len(payload))
+	for i, p := range payload {
+		steps[i].ApproverID = p.ApproverID
+		if p.ApproverRole != nil {
+			role, err := domain.GetRole(*p.ApproverRole)
+			if err != nil {
+				util.ErrorResponse(w, http.StatusBadRequest, err)
+				return
+			}
+			steps[i].ApproverRole = &role
+		}
+	}
+
+	updated, err := h.approvalService.UpdateSteps(r.Context(), chi.URLParam(r, "type"), steps)
+	if err != nil {
+		approvalError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, updated)
+}
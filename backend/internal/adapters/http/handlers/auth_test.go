@@ -0,0 +1,147 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	httpadapter "github.com/nickhildpac/ticket-management-app/internal/adapters/http"
+	"github.com/nickhildpac/ticket-management-app/internal/adapters/http/handlers"
+	"github.com/nickhildpac/ticket-management-app/internal/application/service"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+	"github.com/nickhildpac/ticket-management-app/internal/ports"
+	"github.com/nickhildpac/ticket-management-app/pkg/configs"
+	"github.com/nickhildpac/ticket-management-app/pkg/util"
+)
+
+// fakeSessionService notes which refresh tokens got past verification and
+// turns them all down
+type fakeSessionService struct {
+	ports.SessionService
+	rotated []string
+}
+
+func (s *fakeSessionService) Rotate(_ context.Context, token string) (*domain.RefreshToken, error) {
+	s.rotated = append(s.rotated, token)
+	return nil, domain.ErrInvalidRefreshToken
+}
+
+// TestTokenTypes checks that every kind of token signed with the JWT secret
+// is only accepted where it was meant to be used
+func TestTokenTypes(t *testing.T) {
+	conf := &configs.Config{
+		JWTSecret:     "secret",
+		JWTIssuer:     "example.com",
+		JWTAudience:   "example.com",
+		TokenExpiry:   time.Minute,
+		RefreshExpiry: time.Hour,
+		CSATExpiry:    time.Hour,
+		CookieName:    "refresh_token",
+	}
+
+	org := uuid.New()
+	ticket := domain.Ticket{ID: uuid.New(), OrgID: org, State: domain.TicketStateResolved}
+	tickets := &fakeTicketRepo{tickets: map[uuid.UUID]domain.Ticket{ticket.ID: ticket}}
+	comments := &fakeCommentRepo{comments: map[uuid.UUID]domain.Comment{}}
+	csat := &fakeCSATRepo{ratings: map[uuid.UUID]int{}}
+	sessions := &fakeSessionService{}
+	h := handlers.NewHandler(conf, nil, nil, service.NewCommentService(comments, tickets, nil, nil, nil, nil, nil, conf), service.NewCSATService(csat, tickets, nil, nil, conf), nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, sessions)
+	router := httpadapter.Router(conf, h)
+
+	pair, err := util.GenerateTokenPair(conf, &util.JWTUser{ID: uuid.New(), Role: domain.RoleAdmin, OrgID: org, OrgIDs: []uuid.UUID{org}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	survey, err := util.GenerateActionToken(conf, ticket.ID.String(), "csat", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherAudience := *conf
+	otherAudience.JWTAudience = "other.example.com"
+	foreign, err := util.GenerateTokenPair(&otherAudience, &util.JWTUser{ID: uuid.New(), Role: domain.RoleAdmin, OrgID: org, OrgIDs: []uuid.UUID{org}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Bearer token", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			token    string
+			expected int
+		}{
+			{"Access token", pair.Token, http.StatusNotFound},
+			{"Refresh token", pair.RefreshToken, http.StatusUnauthorized},
+			{"Survey token", survey, http.StatusUnauthorized},
+			{"Access token for another audience", foreign.Token, http.StatusUnauthorized},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				req := httptest.NewRequest(http.MethodGet, "/api/v1/comment/"+uuid.NewString(), nil)
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+				rec := httptest.NewRecorder()
+				router.ServeHTTP(rec, req)
+
+				if rec.Code != tt.expected {
+					t.Errorf("GET /comment = %d; want %d (%s)", rec.Code, tt.expected, rec.Body)
+				}
+			})
+		}
+	})
+
+	t.Run("Refresh cookie", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			token   string
+			rotated bool
+		}{
+			{"Refresh token", pair.RefreshToken, true},
+			{"Access token", pair.Token, false},
+			{"Survey token", survey, false},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				sessions.rotated = nil
+				req := httptest.NewRequest(http.MethodGet, "/api/v1/refresh", nil)
+				req.AddCookie(&http.Cookie{Name: conf.CookieName, Value: tt.token})
+				rec := httptest.NewRecorder()
+				router.ServeHTTP(rec, req)
+
+				if rec.Code != http.StatusUnauthorized {
+					t.Errorf("GET /refresh = %d; want %d", rec.Code, http.StatusUnauthorized)
+				}
+				if rotated := len(sessions.rotated) > 0; rotated != tt.rotated {
+					t.Errorf("GET /refresh rotated the token = %v; want %v", rotated, tt.rotated)
+				}
+			})
+		}
+	})
+
+	t.Run("Survey link", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			token    string
+			expected int
+		}{
+			{"Survey token", survey, http.StatusOK},
+			{"Access token", pair.Token, http.StatusUnauthorized},
+			{"Refresh token", pair.RefreshToken, http.StatusUnauthorized},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				req := httptest.NewRequest(http.MethodGet, "/api/v1/csat/"+tt.token, nil)
+				rec := httptest.NewRecorder()
+				router.ServeHTTP(rec, req)
+
+				if rec.Code != tt.expected {
+					t.Errorf("GET /csat = %d; want %d", rec.Code, tt.expected)
+				}
+			})
+		}
+	})
+}
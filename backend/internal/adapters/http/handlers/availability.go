@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/nickhildpac/ticket-management-app/internal/application/authorization"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+	"github.com/nickhildpac/ticket-management-app/pkg/util"
+)
+
+// AvailabilityPayload sets an agent's status and capacity. A max of zero
+// removes the limit.
+type AvailabilityPayload struct {
+	Status         string `json:"status"`
+	MaxOpenTickets int    `json:"max_open_tickets"`
+}
+
+type OutOfOfficePayload struct {
+	StartsAt   time.Time  `json:"starts_at"`
+	EndsAt     time.Time  `json:"ends_at"`
+	DelegateID *uuid.UUID `json:"delegate_id"`
+	Note       string     `json:"note"`
+}
+
+func availabilityError(w http.ResponseWriter, err error) {
+	if err == authorization.ErrAccessDenied {
+		util.ErrorResponse(w, http.StatusForbidden, err)
+		return
+	}
+	if errors.Is(err, domain.ErrNotAgent) ||
+		errors.Is(err, domain.ErrInvalidCapacity) ||
+		errors.Is(err, domain.ErrInvalidOutOfOffice) ||
+		errors.Is(err, domain.ErrSelfDelegate) {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+	util.ErrorResponse(w, http.StatusInternalServerError, err)
+}
+
+func (h *Handler) GetAvailability(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	a, err := h.availabilityService.GetAvailability(r.Context(), userID)
+	if err != nil {
+		availabilityError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, a)
+}
+
+func (h *Handler) UpdateAvailability(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var payload AvailabilityPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+	status, err := domain.GetAvailabilityStatus(payload.Status)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	a, err := h.availabilityService.UpdateAvailability(r.Context(), domain.Availability{
+		UserID:         userID,
+		Status:         status,
+		MaxOpenTickets: payload.MaxOpenTickets,
+	})
+	if err != nil {
+		availabilityError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, a)
+}
+
+func (h *Handler) GetOutOfOffice(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	absences, err := h.availabilityService.ListOutOfOffice(r.Context(), userID)
+	if err != nil {
+		availabilityError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, absences)
+}
+
+func (h *Handler) CreateOutOfOffice(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var payload OutOfOfficePayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	created, err := h.availabilityService.CreateOutOfOffice(r.Context(), domain.OutOfOffice{
+		UserID:     userID,
+		StartsAt:   payload.StartsAt,
+		EndsAt:     payload.EndsAt,
+		DelegateID: payload.DelegateID,
+		Note:       payload.Note,
+	})
+	if err != nil {
+		availabilityError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusCreated, created)
+}
+
+func (h *Handler) DeleteOutOfOffice(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "oooID"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.availabilityService.DeleteOutOfOffice(r.Context(), userID, id); err != nil {
+		availabilityError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusNoContent, nil)
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/nickhildpac/ticket-management-app/internal/application/authorization"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+	"github.com/nickhildpac/ticket-management-app/pkg/util"
+)
+
+// MoveTicketPayload drops a ticket into a column. AfterID and BeforeID are
+// the tickets directly above and below the drop point.
+type MoveTicketPayload struct {
+	TicketID       uuid.UUID  `json:"ticket_id"`
+	State          string     `json:"state"`
+	AfterID        *uuid.UUID `json:"after_id"`
+	BeforeID       *uuid.UUID `json:"before_id"`
+	ResolutionCode string     `json:"resolution_code"`
+	Reason         string     `json:"reason"`
+}
+
+type BoardColumnResponse struct {
+	State   string          `json:"state"`
+	Tickets []domain.Ticket `json:"tickets"`
+}
+
+// GetBoard returns the caller's tickets grouped by state. limit applies to
+// each column.
+func (h *Handler) GetBoard(w http.ResponseWriter, r *http.Request) {
+	limit, _, err := pagination(r)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	board, err := h.ticketService.Board(r.Context(), limit)
+	if err != nil {
+		if err == authorization.ErrAccessDenied {
+			util.ErrorResponse(w, http.StatusForbidden, err)
+			return
+		}
+		util.ErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	resp := make([]BoardColumnResponse, len(board))
+	for i, col := range board {
+		resp[i] = BoardColumnResponse{State: col.State.String(), Tickets: col.Tickets}
+	}
+	util.WriteResponse(w, http.StatusOK, resp)
+}
+
+func (h *Handler) MoveTicket(w http.ResponseWriter, r *http.Request) {
+	var payload MoveTicketPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	state, err := domain.GetTicketState(payload.State)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+	var code domain.ResolutionCode
+	if payload.ResolutionCode != "" {
+		if code, err = domain.GetResolutionCode(payload.ResolutionCode); err != nil {
+			util.ErrorResponse(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	moved, err := h.ticketService.MoveTicket(r.Context(), domain.TicketMove{
+		TicketID:       payload.TicketID,
+		State:          state,
+		AfterID:        payload.AfterID,
+		BeforeID:       payload.BeforeID,
+		ResolutionCode: code,
+		Reason:         payload.Reason,
+	})
+	if err != nil {
+		if err == authorization.ErrAccessDenied {
+			util.ErrorResponse(w, http.StatusForbidden, err)
+			return
+		}
+		if errors.Is(err, domain.ErrTicketNotFound) {
+			util.ErrorResponse(w, http.StatusNotFound, err)
+			return
+		}
+		if errors.Is(err, domain.ErrInvalidPosition) ||
+			errors.Is(err, domain.ErrInvalidRank) ||
+			errors.Is(err, domain.ErrResolutionCodeRequired) ||
+			errors.Is(err, domain.ErrReopenReasonRequired) {
+			util.ErrorResponse(w, http.StatusBadRequest, err)
+			return
+		}
+		if errors.Is(err, domain.ErrInvalidStatusTransition) ||
+			errors.Is(err, domain.ErrChecklistIncomplete) ||
+			errors.Is(err, domain.ErrApprovalRequired) ||
+			errors.Is(err, domain.ErrApprovalRejected) {
+			util.ErrorResponse(w, http.StatusConflict, err)
+			return
+		}
+		util.ErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	util.WriteResponse(w, http.StatusOK, moved)
+}
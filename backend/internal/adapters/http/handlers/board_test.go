@@ -0,0 +1,67 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	httpadapter "github.com/nickhildpac/ticket-management-app/internal/adapters/http"
+	"github.com/nickhildpac/ticket-management-app/internal/adapters/http/handlers"
+	"github.com/nickhildpac/ticket-management-app/internal/application/service"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+	"github.com/nickhildpac/ticket-management-app/pkg/configs"
+	"github.com/nickhildpac/ticket-management-app/pkg/util"
+)
+
+func TestMoveTicketNeighbours(t *testing.T) {
+	conf := &configs.Config{
+		JWTSecret:   "secret",
+		JWTIssuer:   "example.com",
+		JWTAudience: "example.com",
+		TokenExpiry: time.Minute,
+	}
+
+	org := uuid.New()
+	agent, otherAgent := uuid.New(), uuid.New()
+	moved := domain.Ticket{ID: uuid.New(), OrgID: org, AssignedTo: []uuid.UUID{agent}, State: domain.TicketStateOpen, Rank: "m"}
+	mine := domain.Ticket{ID: uuid.New(), OrgID: org, AssignedTo: []uuid.UUID{agent}, State: domain.TicketStatePending, Rank: "m"}
+	hidden := domain.Ticket{ID: uuid.New(), OrgID: org, AssignedTo: []uuid.UUID{otherAgent}, State: domain.TicketStatePending, Rank: "m"}
+	tickets := &fakeTicketRepo{tickets: map[uuid.UUID]domain.Ticket{moved.ID: moved, mine.ID: mine, hidden.ID: hidden}}
+	ticketService := service.NewTicketService(tickets, nil, nil, nil, nil, nil, nil, nil, nil, nil, conf)
+	h := handlers.NewHandler(conf, nil, ticketService, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	router := httpadapter.Router(conf, h)
+
+	tokens, err := util.GenerateTokenPair(conf, &util.JWTUser{ID: agent, Role: domain.RoleAgent, OrgID: org, OrgIDs: []uuid.UUID{org}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		after    uuid.UUID
+		state    string
+		expected int
+	}{
+		{"Next to a ticket in another column", mine.ID, "open", http.StatusBadRequest},
+		{"Next to a ticket the caller can't see", hidden.ID, "pending", http.StatusNotFound},
+		{"Next to a ticket the caller can't see, in another column", hidden.ID, "open", http.StatusNotFound},
+		{"Next to an unknown ticket", uuid.New(), "pending", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := `{"ticket_id":"` + moved.ID.String() + `","state":"` + tt.state + `","after_id":"` + tt.after.String() + `"}`
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/board/move", strings.NewReader(body))
+			req.Header.Set("Authorization", "Bearer "+tokens.Token)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expected {
+				t.Fatalf("POST /board/move = %d; want %d (%s)", rec.Code, tt.expected, rec.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/nickhildpac/ticket-management-app/internal/application/authorization"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+	"github.com/nickhildpac/ticket-management-app/pkg/util"
+)
+
+// CalendarPayload creates or updates a calendar. OrgID defaults to the
+// caller's own organization and is ignored on update. Hours and holidays
+// replace the existing ones.
+type CalendarPayload struct {
+	Name     string                `json:"name"`
+	TimeZone string                `json:"time_zone"`
+	Hours    []domain.WorkingHours `json:"hours"`
+	Holidays []domain.Holiday      `json:"holidays"`
+	OrgID    uuid.UUID             `json:"org_id"`
+}
+
+// SetCalendarPayload picks the calendar of an organization or team. A null
+// calendar_id goes back to round-the-clock timing.
+type SetCalendarPayload struct {
+	CalendarID *uuid.UUID `json:"calendar_id"`
+}
+
+func calendarError(w http.ResponseWriter, err error) {
+	if err == authorization.ErrAccessDenied {
+		util.ErrorResponse(w, http.StatusForbidden, err)
+		return
+	}
+	if errors.Is(err, domain.ErrEmptyCalendarName) ||
+		errors.Is(err, domain.ErrInvalidTimeZone) ||
+		errors.Is(err, domain.ErrNoWorkingHours) ||
+		errors.Is(err, domain.ErrInvalidWorkingHour) ||
+		errors.Is(err, domain.ErrOverlappingHours) ||
+		errors.Is(err, domain.ErrInvalidHoliday) ||
+		errors.Is(err, domain.ErrCalendarOrgMismatch) {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+	util.ErrorResponse(w, http.StatusInternalServerError, err)
+}
+
+func (h *Handler) GetCalendars(w http.ResponseWriter, r *http.Request) {
+	calendars, err := h.calendarService.ListCalendars(r.Context())
+	if err != nil {
+		calendarError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, calendars)
+}
+
+func (h *Handler) GetCalendar(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	calendar, err := h.calendarService.GetCalendar(r.Context(), id)
+	if err != nil {
+		calendarError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, calendar)
+}
+
+func (h *Handler) CreateCalendar(w http.ResponseWriter, r *http.Request) {
+	var payload CalendarPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	calendar, err := h.calendarService.CreateCalendar(r.Context(), domain.BusinessCalendar{
+		OrgID:    payload.OrgID,
+		Name:     payload.Name,
+		TimeZone: payload.TimeZone,
+		Hours:    payload.Hours,
+		Holidays: payload.Holidays,
+	})
+	if err != nil {
+		calendarError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusCreated, calendar)
+}
+
+func (h *Handler) UpdateCalendar(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var payload CalendarPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	calendar, err := h.calendarService.UpdateCalendar(r.Context(), domain.BusinessCalendar{
+		ID:       id,
+		Name:     payload.Name,
+		TimeZone: payload.TimeZone,
+		Hours:    payload.Hours,
+		Holidays: payload.Holidays,
+	})
+	if err != nil {
+		calendarError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, calendar)
+}
+
+func (h *Handler) DeleteCalendar(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.calendarService.DeleteCalendar(r.Context(), id); err != nil {
+		calendarError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusNoContent, nil)
+}
+
+func (h *Handler) SetOrganizationCalendar(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var payload SetCalendarPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	org, err := h.calendarService.SetOrganizationCalendar(r.Context(), id, payload.CalendarID)
+	if err != nil {
+		calendarError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, org)
+}
+
+func (h *Handler) SetTeamCalendar(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var payload SetCalendarPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	team, err := h.calendarService.SetTeamCalendar(r.Context(), id, payload.CalendarID)
+	if err != nil {
+		calendarError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, team)
+}
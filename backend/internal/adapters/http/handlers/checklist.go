@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/nickhildpac/ticket-management-app/internal/application/authorization"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+	"github.com/nickhildpac/ticket-management-app/pkg/util"
+)
+
+// ChecklistItemPayload is used for both create and update. On update a nil
+// field is left unchanged; send a zero UUID or zero time to clear the
+// assignee or due date.
+type ChecklistItemPayload struct {
+	Text       *string    `json:"text"`
+	Done       *bool      `json:"done"`
+	Required   *bool      `json:"required"`
+	AssigneeID *uuid.UUID `json:"assignee_id"`
+	DueAt      *time.Time `json:"due_at"`
+}
+
+type ChecklistOrderPayload struct {
+	IDs []uuid.UUID `json:"ids"`
+}
+
+func (p ChecklistItemPayload) apply(item *domain.ChecklistItem) {
+	if p.Text != nil {
+		item.Text = *p.Text
+	}
+	if p.Done != nil {
+		item.Done = *p.Done
+	}
+	if p.Required != nil {
+		item.Required = *p.Required
+	}
+	if p.AssigneeID != nil {
+		item.AssigneeID = p.AssigneeID
+		if *p.AssigneeID == uuid.Nil {
+			item.AssigneeID = nil
+		}
+	}
+	if p.DueAt != nil {
+		item.DueAt = p.DueAt
+		if p.DueAt.IsZero() {
+			item.DueAt = nil
+		}
+	}
+}
+
+func checklistError(w http.ResponseWriter, err error) {
+	if err == authorization.ErrAccessDenied {
+		util.ErrorResponse(w, http.StatusForbidden, err)
+		return
+	}
+	if errors.Is(err, domain.ErrChecklistItemNotFound) {
+		util.ErrorResponse(w, http.StatusNotFound, err)
+		return
+	}
+	if errors.Is(err, domain.ErrEmptyChecklistItem) || errors.Is(err, domain.ErrInvalidChecklistOrder) {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+	util.ErrorResponse(w, http.StatusInternalServerError, err)
+}
+
+func (h *Handler) GetChecklist(w http.ResponseWriter, r *http.Request) {
+	tid, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	items, err := h.checklistService.ListItems(r.Context(), tid)
+	if err != nil {
+		checklistError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, items)
+}
+
+func (h *Handler) CreateChecklistItem(w http.ResponseWriter, r *http.Request) {
+	tid, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var payload ChecklistItemPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Items are required unless stated otherwise
+	item := domain.ChecklistItem{TicketID: tid, Required: true}
+	payload.apply(&item)
+
+	created, err := h.checklistService.CreateItem(r.Context(), item)
+	if err != nil {
+		checklistError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusCreated, created)
+}
+
+func (h *Handler) UpdateChecklistItem(w http.ResponseWriter, r *http.Request) {
+	tid, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+	itemID, err := uuid.Parse(chi.URLParam(r, "itemID"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var payload ChecklistItemPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	item, err := h.checklistService.GetItem(r.Context(), tid, itemID)
+	if err != nil {
+		checklistError(w, err)
+		return
+	}
+	payload.apply(item)
+
+	updated, err := h.checklistService.UpdateItem(r.Context(), *item)
+	if err != nil {
+		checklistError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, updated)
+}
+
+func (h *Handler) DeleteChecklistItem(w http.ResponseWriter, r *http.Request) {
+	tid, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+	itemID, err := uuid.Parse(chi.URLParam(r, "itemID"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.checklistService.DeleteItem(r.Context(), tid, itemID); err != nil {
+		checklistError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusNoContent, nil)
+}
+
+func (h *Handler) ReorderChecklist(w http.ResponseWriter, r *http.Request) {
+	tid, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var payload ChecklistOrderPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	items, err := h.checklistService.Reorder(r.Context(), tid, payload.IDs)
+	if err != nil {
+		checklistError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, items)
+}
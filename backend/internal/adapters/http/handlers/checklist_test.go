@@ -0,0 +1,117 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	httpadapter "github.com/nickhildpac/ticket-management-app/internal/adapters/http"
+	"github.com/nickhildpac/ticket-management-app/internal/adapters/http/handlers"
+	"github.com/nickhildpac/ticket-management-app/internal/application/service"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+	"github.com/nickhildpac/ticket-management-app/internal/ports"
+	"github.com/nickhildpac/ticket-management-app/pkg/configs"
+	"github.com/nickhildpac/ticket-management-app/pkg/util"
+)
+
+// fakeChecklistRepo keeps items in memory
+type fakeChecklistRepo struct {
+	ports.ChecklistRepository
+	items map[uuid.UUID]domain.ChecklistItem
+}
+
+func (r *fakeChecklistRepo) Get(_ context.Context, id uuid.UUID) (*domain.ChecklistItem, error) {
+	item, ok := r.items[id]
+	if !ok {
+		return nil, domain.ErrChecklistItemNotFound
+	}
+	return &item, nil
+}
+
+func (r *fakeChecklistRepo) Create(_ context.Context, item domain.ChecklistItem) (*domain.ChecklistItem, error) {
+	item.ID = uuid.New()
+	r.items[item.ID] = item
+	return &item, nil
+}
+
+func (r *fakeChecklistRepo) Update(_ context.Context, item domain.ChecklistItem) (*domain.ChecklistItem, error) {
+	r.items[item.ID] = item
+	return &item, nil
+}
+
+func (r *fakeChecklistRepo) Delete(_ context.Context, id uuid.UUID) error {
+	delete(r.items, id)
+	return nil
+}
+
+func TestChecklistPermissions(t *testing.T) {
+	conf := &configs.Config{
+		JWTSecret:   "secret",
+		JWTIssuer:   "example.com",
+		JWTAudience: "example.com",
+		TokenExpiry: time.Minute,
+	}
+
+	org := uuid.New()
+	creator, assignee := uuid.New(), uuid.New()
+	ticket := domain.Ticket{ID: uuid.New(), OrgID: org, CreatedBy: creator, AssignedTo: []uuid.UUID{assignee}}
+	tickets := &fakeTicketRepo{tickets: map[uuid.UUID]domain.Ticket{ticket.ID: ticket}}
+
+	tests := []struct {
+		name     string
+		caller   uuid.UUID
+		role     domain.UserRole
+		method   string
+		item     bool
+		body     string
+		expected int
+		required bool
+	}{
+		{"Creator ticks an item", creator, domain.RoleUser, http.MethodPatch, true, `{"done":true}`, http.StatusOK, true},
+		{"Creator rewords an item", creator, domain.RoleUser, http.MethodPatch, true, `{"text":"Reboot twice"}`, http.StatusOK, true},
+		{"Creator un-requires an item", creator, domain.RoleUser, http.MethodPatch, true, `{"required":false}`, http.StatusForbidden, true},
+		{"Creator deletes an item", creator, domain.RoleUser, http.MethodDelete, true, ``, http.StatusForbidden, true},
+		{"Creator adds an item", creator, domain.RoleUser, http.MethodPost, false, `{"text":"Skip it"}`, http.StatusForbidden, true},
+		{"Assignee un-requires an item", assignee, domain.RoleAgent, http.MethodPatch, true, `{"required":false}`, http.StatusOK, false},
+		{"Assignee deletes an item", assignee, domain.RoleAgent, http.MethodDelete, true, ``, http.StatusNoContent, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := domain.ChecklistItem{ID: uuid.New(), TicketID: ticket.ID, Text: "Reboot", Required: true}
+			checklist := &fakeChecklistRepo{items: map[uuid.UUID]domain.ChecklistItem{item.ID: item}}
+			h := handlers.NewHandler(conf, nil, nil, nil, nil, nil, service.NewChecklistService(checklist, tickets), nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+			router := httpadapter.Router(conf, h)
+
+			path := "/api/v1/ticket/" + ticket.ID.String() + "/checklist"
+			if tt.item {
+				path += "/" + item.ID.String()
+			}
+			req := httptest.NewRequest(tt.method, path, strings.NewReader(tt.body))
+			tokens, err := util.GenerateTokenPair(conf, &util.JWTUser{
+				ID:     tt.caller,
+				Role:   tt.role,
+				OrgID:  org,
+				OrgIDs: []uuid.UUID{org},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Authorization", "Bearer "+tokens.Token)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expected {
+				t.Fatalf("%s %s = %d; want %d (%s)", tt.method, path, rec.Code, tt.expected, rec.Body)
+			}
+			if saved, ok := checklist.items[item.ID]; ok && saved.Required != tt.required {
+				t.Errorf("item required = %v; want %v", saved.Required, tt.required)
+			}
+		})
+	}
+}
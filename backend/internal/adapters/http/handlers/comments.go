@@ -1,22 +1,70 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
+	"net/url"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	"github.com/nickhildpac/ticket-management-app/internal/application/authorization"
 	"github.com/nickhildpac/ticket-management-app/internal/domain"
 	"github.com/nickhildpac/ticket-management-app/pkg/configs"
+	"github.com/nickhildpac/ticket-management-app/pkg/markdown"
 	"github.com/nickhildpac/ticket-management-app/pkg/util"
 )
 
 type CommentPayload struct {
 	TicketID    string `json:"ticket_id"`
 	Description string `json:"description"`
+	// Visibility is "public" (the default) or "internal"
+	Visibility string `json:"visibility"`
+	// ParentID is the comment being replied to, if any
+	ParentID string `json:"parent_id"`
 }
 
+type UpdateCommentPayload struct {
+	Description string `json:"description"`
+}
+
+func commentError(w http.ResponseWriter, err error) {
+	if err == authorization.ErrAccessDenied {
+		util.ErrorResponse(w, http.StatusForbidden, err)
+		return
+	}
+	if errors.Is(err, domain.ErrCommentNotFound) {
+		util.ErrorResponse(w, http.StatusNotFound, err)
+		return
+	}
+	if errors.Is(err, domain.ErrEmptyComment) || errors.Is(err, domain.ErrCommentTooLong) ||
+		errors.Is(err, domain.ErrInvalidCommentVisibility) ||
+		errors.Is(err, domain.ErrInvalidCommentParent) || errors.Is(err, domain.ErrCommentTooDeep) ||
+		errors.Is(err, domain.ErrInvalidReaction) || errors.Is(err, domain.ErrInvalidCommand) ||
+		errors.Is(err, domain.ErrUnknownAssignee) || errors.Is(err, domain.ErrResolutionCodeRequired) ||
+		errors.Is(err, domain.ErrReopenReasonRequired) {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+	// Slash commands fail the way the ticket update they make would
+	if errors.Is(err, domain.ErrCommentEditExpired) ||
+		errors.Is(err, domain.ErrInvalidStatusTransition) ||
+		errors.Is(err, domain.ErrChecklistIncomplete) ||
+		errors.Is(err, domain.ErrApprovalRequired) ||
+		errors.Is(err, domain.ErrApprovalRejected) ||
+		errors.Is(err, domain.ErrAgentOutOfOffice) ||
+		errors.Is(err, domain.ErrAgentAway) ||
+		errors.Is(err, domain.ErrAgentAtCapacity) {
+		util.ErrorResponse(w, http.StatusConflict, err)
+		return
+	}
+	util.ErrorResponse(w, http.StatusInternalServerError, err)
+}
+
+// GetComments returns a page of the ticket's threads with their replies,
+// nested under the comments they answer. With format=flat the comments are
+// listed instead, each reply after its parent and pointing at it.
 func (h *Handler) GetComments(w http.ResponseWriter, r *http.Request) {
 	idParam := chi.URLParam(r, "id")
 	tid, err := uuid.Parse(idParam)
@@ -25,7 +73,18 @@ func (h *Handler) GetComments(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	comments, err := h.commentService.ListByTicket(r.Context(), tid, 10, 0)
+	limit, offset, err := pagination(r)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "tree" && format != "flat" {
+		util.ErrorResponse(w, http.StatusBadRequest, errors.New("format must be tree or flat"))
+		return
+	}
+
+	comments, err := h.commentService.ListByTicket(r.Context(), tid, limit, offset)
 	if err != nil {
 		if err == authorization.ErrAccessDenied {
 			util.ErrorResponse(w, http.StatusForbidden, err)
@@ -35,13 +94,57 @@ func (h *Handler) GetComments(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Fetch creator details for each comment
+	response, err := h.commentResponses(r.Context(), comments)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+	if format == "flat" {
+		util.WriteResponse(w, http.StatusOK, response)
+		return
+	}
+
+	byID := make(map[uuid.UUID]CommentResponse, len(response))
+	for _, c := range response {
+		byID[c.ID] = c
+	}
+	var nest func([]*domain.CommentNode) []CommentResponse
+	nest = func(nodes []*domain.CommentNode) []CommentResponse {
+		out := make([]CommentResponse, len(nodes))
+		for i, n := range nodes {
+			out[i] = byID[n.ID]
+			out[i].Replies = nest(n.Replies)
+		}
+		return out
+	}
+	util.WriteResponse(w, http.StatusOK, nest(domain.BuildCommentTree(comments)))
+}
+
+// commentResponses adds each comment's creator, looking every creator up
+// once, and its reactions
+func (h *Handler) commentResponses(ctx context.Context, comments []domain.Comment) ([]CommentResponse, error) {
+	ids := make([]uuid.UUID, len(comments))
+	for i, comment := range comments {
+		ids[i] = comment.ID
+	}
+	reactions, err := h.reactionService.Summarize(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	creators := map[uuid.UUID]*domain.User{}
 	response := make([]CommentResponse, len(comments))
 	for i, comment := range comments {
-		creator, err := h.userService.GetUserByID(r.Context(), comment.CreatedBy)
-		if err != nil {
-			util.ErrorResponse(w, http.StatusInternalServerError, err)
-			return
+		creator, ok := creators[comment.CreatedBy]
+		if !ok {
+			var err error
+			if creator, err = h.userService.GetUserByID(ctx, comment.CreatedBy); err != nil {
+				return nil, err
+			}
+			creators[comment.CreatedBy] = creator
+		}
+		if reactions[comment.ID] == nil {
+			reactions[comment.ID] = []domain.Reaction{}
 		}
 
 		response[i] = CommentResponse{
@@ -54,11 +157,43 @@ func (h *Handler) GetComments(w http.ResponseWriter, r *http.Request) {
 				LastName:  creator.LastName,
 				Email:     creator.Email,
 			},
-			Description: comment.Description,
-			CreatedAt:   comment.CreatedAt,
+			Description:     comment.Description,
+			DescriptionHTML: markdown.Render(comment.Description),
+			Visibility:      comment.Visibility,
+			ParentID:        comment.ParentID,
+			Depth:           comment.Depth,
+			Reactions:       reactions[comment.ID],
+			EditedAt:        comment.EditedAt,
+			CreatedAt:       comment.CreatedAt,
 		}
 	}
+	return response, nil
+}
 
+// GetCommentReplies returns a page of the replies in the comment's thread,
+// oldest first
+func (h *Handler) GetCommentReplies(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+	limit, offset, err := pagination(r)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	replies, err := h.commentService.ListReplies(r.Context(), id, limit, offset)
+	if err != nil {
+		commentError(w, err)
+		return
+	}
+	response, err := h.commentResponses(r.Context(), replies)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
 	util.WriteResponse(w, http.StatusOK, response)
 }
 
@@ -71,10 +206,10 @@ func (h *Handler) GetComment(w http.ResponseWriter, r *http.Request) {
 	}
 	comment, err := h.commentService.GetComment(r.Context(), tid)
 	if err != nil {
-		util.ErrorResponse(w, http.StatusInternalServerError, err)
+		commentError(w, err)
 		return
 	}
-	util.WriteResponse(w, http.StatusOK, comment)
+	util.WriteResponse(w, http.StatusOK, commentDetail(comment))
 }
 
 func (h *Handler) CreateComment(w http.ResponseWriter, r *http.Request) {
@@ -97,18 +232,113 @@ func (h *Handler) CreateComment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	comment, err := h.commentService.CreateComment(r.Context(), domain.Comment{
+	visibility, err := domain.GetCommentVisibility(payload.Visibility)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	comment := domain.Comment{
 		TicketID:    ticketID,
 		Description: payload.Description,
+		Visibility:  visibility,
 		CreatedBy:   userID,
-	})
-	if err != nil {
-		if err == authorization.ErrAccessDenied {
-			util.ErrorResponse(w, http.StatusForbidden, err)
+	}
+	if payload.ParentID != "" {
+		parentID, err := uuid.Parse(payload.ParentID)
+		if err != nil {
+			util.ErrorResponse(w, http.StatusBadRequest, err)
 			return
 		}
-		util.ErrorResponse(w, http.StatusInternalServerError, err)
+		comment.ParentID = &parentID
+	}
+
+	created, err := h.commentService.CreateComment(r.Context(), comment)
+	if err != nil {
+		commentError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusAccepted, commentDetail(created))
+}
+
+func (h *Handler) UpdateComment(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var payload UpdateCommentPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	comment, err := h.commentService.UpdateComment(r.Context(), id, payload.Description)
+	if err != nil {
+		commentError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, commentDetail(comment))
+}
+
+func (h *Handler) DeleteComment(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.commentService.DeleteComment(r.Context(), id); err != nil {
+		commentError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusNoContent, nil)
+}
+
+func (h *Handler) GetCommentRevisions(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	revisions, err := h.commentService.ListRevisions(r.Context(), id)
+	if err != nil {
+		commentError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, revisions)
+}
+
+// ReactToComment adds the caller's emoji reaction to a comment and returns
+// the comment's reactions
+func (h *Handler) ReactToComment(w http.ResponseWriter, r *http.Request) {
+	h.changeReaction(w, r, h.reactionService.React)
+}
+
+// UnreactToComment removes the caller's emoji reaction from a comment and
+// returns the comment's reactions
+func (h *Handler) UnreactToComment(w http.ResponseWriter, r *http.Request) {
+	h.changeReaction(w, r, h.reactionService.Unreact)
+}
+
+func (h *Handler) changeReaction(w http.ResponseWriter, r *http.Request, change func(context.Context, uuid.UUID, string) ([]domain.Reaction, error)) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+	emoji, err := url.PathUnescape(chi.URLParam(r, "emoji"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	reactions, err := change(r.Context(), id, emoji)
+	if err != nil {
+		commentError(w, err)
 		return
 	}
-	util.WriteResponse(w, http.StatusAccepted, comment)
+	util.WriteResponse(w, http.StatusOK, reactions)
 }
@@ -0,0 +1,235 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	httpadapter "github.com/nickhildpac/ticket-management-app/internal/adapters/http"
+	"github.com/nickhildpac/ticket-management-app/internal/adapters/http/handlers"
+	"github.com/nickhildpac/ticket-management-app/internal/application/service"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+	"github.com/nickhildpac/ticket-management-app/internal/ports"
+	"github.com/nickhildpac/ticket-management-app/pkg/configs"
+	"github.com/nickhildpac/ticket-management-app/pkg/util"
+)
+
+// fakeCommentRepo and fakeTicketRepo keep rows in memory. Like the real
+// repositories called without an organization scope, they find rows in any
+// organization, which leaves the checks to the service.
+type fakeCommentRepo struct {
+	ports.CommentRepository
+	comments  map[uuid.UUID]domain.Comment
+	revisions map[uuid.UUID][]domain.CommentRevision
+}
+
+func (r *fakeCommentRepo) Get(ctx context.Context, id uuid.UUID) (*domain.Comment, error) {
+	c, err := r.GetIncludingDeleted(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if c.DeletedAt != nil {
+		return nil, domain.ErrCommentNotFound
+	}
+	return c, nil
+}
+
+func (r *fakeCommentRepo) GetIncludingDeleted(_ context.Context, id uuid.UUID) (*domain.Comment, error) {
+	c, ok := r.comments[id]
+	if !ok {
+		return nil, domain.ErrCommentNotFound
+	}
+	return &c, nil
+}
+
+func (r *fakeCommentRepo) ListRevisions(_ context.Context, commentID uuid.UUID) ([]domain.CommentRevision, error) {
+	return r.revisions[commentID], nil
+}
+
+type fakeTicketRepo struct {
+	ports.TicketRepository
+	tickets map[uuid.UUID]domain.Ticket
+}
+
+func (r *fakeTicketRepo) Get(_ context.Context, id uuid.UUID) (*domain.Ticket, error) {
+	t, ok := r.tickets[id]
+	if !ok {
+		return nil, domain.ErrTicketNotFound
+	}
+	return &t, nil
+}
+
+func TestGetComment(t *testing.T) {
+	conf := &configs.Config{
+		JWTSecret:   "secret",
+		JWTIssuer:   "example.com",
+		JWTAudience: "example.com",
+		TokenExpiry: time.Minute,
+	}
+
+	org, otherOrg := uuid.New(), uuid.New()
+	team := uuid.New()
+	creator, assignee, teammate, stranger := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	ticket := domain.Ticket{
+		ID:         uuid.New(),
+		OrgID:      org,
+		CreatedBy:  creator,
+		AssignedTo: []uuid.UUID{assignee},
+		TeamID:     &team,
+	}
+	public := domain.Comment{ID: uuid.New(), TicketID: ticket.ID, CreatedBy: creator, Description: "it broke", Visibility: domain.CommentPublic}
+	internal := domain.Comment{ID: uuid.New(), TicketID: ticket.ID, CreatedBy: assignee, Description: "known issue", Visibility: domain.CommentInternal}
+
+	comments := &fakeCommentRepo{comments: map[uuid.UUID]domain.Comment{public.ID: public, internal.ID: internal}}
+	tickets := &fakeTicketRepo{tickets: map[uuid.UUID]domain.Ticket{ticket.ID: ticket}}
+	h := handlers.NewHandler(conf, nil, nil, service.NewCommentService(comments, tickets, nil, nil, nil, nil, nil, conf), nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	router := httpadapter.Router(conf, h)
+
+	type caller struct {
+		id    uuid.UUID
+		role  domain.UserRole
+		orgs  []uuid.UUID
+		teams []uuid.UUID
+	}
+	admin := &caller{uuid.New(), domain.RoleAdmin, []uuid.UUID{org}, nil}
+	otherAdmin := &caller{uuid.New(), domain.RoleAdmin, []uuid.UUID{otherOrg}, nil}
+	assignedAgent := &caller{assignee, domain.RoleAgent, []uuid.UUID{org}, nil}
+	teamAgent := &caller{teammate, domain.RoleAgent, []uuid.UUID{org}, []uuid.UUID{team}}
+	otherAgent := &caller{uuid.New(), domain.RoleAgent, []uuid.UUID{org}, nil}
+	ticketCreator := &caller{creator, domain.RoleUser, []uuid.UUID{org}, nil}
+	otherUser := &caller{stranger, domain.RoleUser, []uuid.UUID{org}, nil}
+
+	tests := []struct {
+		name     string
+		caller   *caller
+		id       string
+		expected int
+	}{
+		{"Anonymous", nil, public.ID.String(), http.StatusUnauthorized},
+		{"Malformed ID", admin, "not-a-uuid", http.StatusBadRequest},
+		{"Unknown comment", admin, uuid.NewString(), http.StatusNotFound},
+		{"Admin reads public reply", admin, public.ID.String(), http.StatusOK},
+		{"Admin reads internal note", admin, internal.ID.String(), http.StatusOK},
+		{"Admin of another organization", otherAdmin, public.ID.String(), http.StatusNotFound},
+		{"Assigned agent reads internal note", assignedAgent, internal.ID.String(), http.StatusOK},
+		{"Team agent reads internal note", teamAgent, internal.ID.String(), http.StatusOK},
+		{"Unrelated agent", otherAgent, public.ID.String(), http.StatusNotFound},
+		{"Creator reads public reply", ticketCreator, public.ID.String(), http.StatusOK},
+		{"Creator reads internal note", ticketCreator, internal.ID.String(), http.StatusNotFound},
+		{"Other user", otherUser, public.ID.String(), http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/comment/"+tt.id, nil)
+			if tt.caller != nil {
+				tokens, err := util.GenerateTokenPair(conf, &util.JWTUser{
+					ID:      tt.caller.id,
+					Role:    tt.caller.role,
+					OrgID:   tt.caller.orgs[0],
+					OrgIDs:  tt.caller.orgs,
+					TeamIDs: tt.caller.teams,
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+				req.Header.Set("Authorization", "Bearer "+tokens.Token)
+			}
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expected {
+				t.Fatalf("GET /comment/%s = %d; want %d (%s)", tt.id, rec.Code, tt.expected, rec.Body)
+			}
+			if rec.Code != http.StatusOK {
+				return
+			}
+			var got domain.Comment
+			if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+				t.Fatal(err)
+			}
+			if got.ID.String() != tt.id {
+				t.Errorf("GET /comment/%s returned comment %s", tt.id, got.ID)
+			}
+		})
+	}
+}
+
+func TestGetCommentRevisions(t *testing.T) {
+	conf := &configs.Config{
+		JWTSecret:   "secret",
+		JWTIssuer:   "example.com",
+		JWTAudience: "example.com",
+		TokenExpiry: time.Minute,
+	}
+
+	org := uuid.New()
+	creator, adminID := uuid.New(), uuid.New()
+	ticket := domain.Ticket{ID: uuid.New(), OrgID: org, CreatedBy: creator}
+	deletedAt := time.Now()
+	live := domain.Comment{ID: uuid.New(), TicketID: ticket.ID, CreatedBy: creator, Description: "it broke again", Visibility: domain.CommentPublic}
+	deleted := domain.Comment{ID: uuid.New(), TicketID: ticket.ID, CreatedBy: creator, Description: "my password is hunter2", Visibility: domain.CommentPublic, DeletedAt: &deletedAt}
+
+	comments := &fakeCommentRepo{
+		comments: map[uuid.UUID]domain.Comment{live.ID: live, deleted.ID: deleted},
+		revisions: map[uuid.UUID][]domain.CommentRevision{
+			live.ID:    {{ID: uuid.New(), CommentID: live.ID, Description: "it broke", EditedBy: &creator}},
+			deleted.ID: {{ID: uuid.New(), CommentID: deleted.ID, Description: deleted.Description, EditedBy: &adminID, CreatedAt: deletedAt}},
+		},
+	}
+	tickets := &fakeTicketRepo{tickets: map[uuid.UUID]domain.Ticket{ticket.ID: ticket}}
+	h := handlers.NewHandler(conf, nil, nil, service.NewCommentService(comments, tickets, nil, nil, nil, nil, nil, conf), nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	router := httpadapter.Router(conf, h)
+
+	tests := []struct {
+		name     string
+		caller   uuid.UUID
+		role     domain.UserRole
+		comment  domain.Comment
+		expected int
+	}{
+		{"Creator reads revisions", creator, domain.RoleUser, live, http.StatusOK},
+		{"Admin reads revisions of a deleted comment", adminID, domain.RoleAdmin, deleted, http.StatusOK},
+		{"Creator reads revisions of a deleted comment", creator, domain.RoleUser, deleted, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := "/api/v1/comment/" + tt.comment.ID.String() + "/revisions"
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			tokens, err := util.GenerateTokenPair(conf, &util.JWTUser{
+				ID:     tt.caller,
+				Role:   tt.role,
+				OrgID:  org,
+				OrgIDs: []uuid.UUID{org},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Authorization", "Bearer "+tokens.Token)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expected {
+				t.Fatalf("GET %s = %d; want %d (%s)", path, rec.Code, tt.expected, rec.Body)
+			}
+			if rec.Code != http.StatusOK {
+				return
+			}
+			var got []domain.CommentRevision
+			if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+				t.Fatal(err)
+			}
+			want := comments.revisions[tt.comment.ID]
+			if len(got) != len(want) || got[len(got)-1].Description != want[len(want)-1].Description {
+				t.Errorf("GET %s = %+v; want %+v", path, got, want)
+			}
+		})
+	}
+}
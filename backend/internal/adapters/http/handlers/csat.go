@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"html/template"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/nickhildpac/ticket-management-app/internal/application/authorization"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+	"github.com/nickhildpac/ticket-management-app/pkg/util"
+)
+
+type CSATPayload struct {
+	Rating  int    `json:"rating"`
+	Comment string `json:"comment"`
+}
+
+// surveyPage is what someone following a survey link sees: the form, with
+// the rating from the link picked, or the outcome of posting it
+var surveyPage = template.Must(template.New("survey").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head><meta charset="utf-8"><title>How did we do?</title></head>
+<body>
+{{- if .Error}}
+<p>{{.Error}}</p>
+{{- else if .Response}}
+<p>Thanks for rating your ticket.</p>
+{{- else}}
+<h1>How did we do?</h1>
+<p>How satisfied are you with the support you received for &ldquo;{{.Ticket.Title}}&rdquo;?</p>
+<form method="post">
+{{- range .Ratings}}
+<label><input type="radio" name="rating" value="{{.}}"{{if eq . $.Rating}} checked{{end}} required> {{.}}</label>
+{{- end}}
+<p><textarea name="comment" rows="4" cols="50" placeholder="Anything else you'd like to tell us?"></textarea></p>
+<button type="submit">Submit</button>
+</form>
+{{- end}}
+</body>
+</html>
+`))
+
+type surveyPageData struct {
+	Ticket   *domain.Ticket
+	Response *domain.CSATResponse
+	Ratings  []int
+	Rating   int
+	Error    string
+}
+
+// GetSurvey shows the survey for a link from the survey email. Following a
+// link records nothing, so mail scanners that open links can't rate a
+// ticket; the rating in the link is only picked in the form.
+func (h *Handler) GetSurvey(w http.ResponseWriter, r *http.Request) {
+	ticket, err := h.csatService.GetSurvey(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		writeSurveyPage(w, csatStatus(err), surveyPageData{Error: err.Error()})
+		return
+	}
+
+	data := surveyPageData{Ticket: ticket}
+	for rating := domain.MaxCSATRating; rating >= domain.MinCSATRating; rating-- {
+		data.Ratings = append(data.Ratings, rating)
+	}
+	data.Rating, _ = strconv.Atoi(r.URL.Query().Get("rating"))
+	writeSurveyPage(w, http.StatusOK, data)
+}
+
+// RateTicket records a rating for a survey link. It takes the survey form,
+// answering with a page, or a JSON body, answering with JSON.
+func (h *Handler) RateTicket(w http.ResponseWriter, r *http.Request) {
+	token := chi.URLParam(r, "token")
+
+	var payload CSATPayload
+	form := strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded")
+	if form {
+		if err := r.ParseForm(); err != nil {
+			writeSurveyPage(w, http.StatusBadRequest, surveyPageData{Error: err.Error()})
+			return
+		}
+		rating, err := strconv.Atoi(r.PostForm.Get("rating"))
+		if err != nil {
+			writeSurveyPage(w, http.StatusBadRequest, surveyPageData{Error: domain.ErrInvalidRating.Error()})
+			return
+		}
+		payload = CSATPayload{Rating: rating, Comment: r.PostForm.Get("comment")}
+	} else if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	response, err := h.csatService.SubmitRating(r.Context(), token, payload.Rating, payload.Comment)
+	if form {
+		data := surveyPageData{Response: response}
+		if err != nil {
+			data.Error = err.Error()
+		}
+		writeSurveyPage(w, csatStatus(err), data)
+		return
+	}
+	if err != nil {
+		util.ErrorResponse(w, csatStatus(err), err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, response)
+}
+
+func csatStatus(err error) int {
+	switch {
+	case err == nil:
+		return http.StatusOK
+	case errors.Is(err, util.ErrInvalidActionToken):
+		return http.StatusUnauthorized
+	case errors.Is(err, domain.ErrInvalidRating):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrSurveyClosed):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writeSurveyPage(w http.ResponseWriter, status int, data surveyPageData) {
+	if status == http.StatusInternalServerError {
+		data.Error = "something went wrong; please try again later"
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	if err := surveyPage.Execute(w, data); err != nil {
+		log.Println("Error writing survey page:", err)
+	}
+}
+
+func (h *Handler) GetCSATSummary(w http.ResponseWriter, r *http.Request) {
+	summary, err := h.csatService.GetSummary(r.Context())
+	if err != nil {
+		if err == authorization.ErrAccessDenied {
+			util.ErrorResponse(w, http.StatusForbidden, err)
+			return
+		}
+		util.ErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, summary)
+}
+
+func (h *Handler) GetCSATByAssignee(w http.ResponseWriter, r *http.Request) {
+	summaries, err := h.csatService.GetSummaryByAssignee(r.Context())
+	if err != nil {
+		if err == authorization.ErrAccessDenied {
+			util.ErrorResponse(w, http.StatusForbidden, err)
+			return
+		}
+		util.ErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, summaries)
+}
@@ -0,0 +1,113 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	httpadapter "github.com/nickhildpac/ticket-management-app/internal/adapters/http"
+	"github.com/nickhildpac/ticket-management-app/internal/adapters/http/handlers"
+	"github.com/nickhildpac/ticket-management-app/internal/application/service"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+	"github.com/nickhildpac/ticket-management-app/internal/ports"
+	"github.com/nickhildpac/ticket-management-app/pkg/configs"
+	"github.com/nickhildpac/ticket-management-app/pkg/util"
+)
+
+// fakeCSATRepo keeps the last rating given for each ticket
+type fakeCSATRepo struct {
+	ports.CSATRepository
+	ratings map[uuid.UUID]int
+}
+
+func (r *fakeCSATRepo) Upsert(_ context.Context, response domain.CSATResponse) (*domain.CSATResponse, error) {
+	r.ratings[response.TicketID] = response.Rating
+	return &response, nil
+}
+
+func TestRateTicket(t *testing.T) {
+	conf := &configs.Config{
+		JWTSecret:   "secret",
+		JWTIssuer:   "example.com",
+		JWTAudience: "example.com",
+		CSATExpiry:  time.Hour,
+	}
+
+	resolved := domain.Ticket{ID: uuid.New(), Title: "VPN <down>", State: domain.TicketStateResolved}
+	open := domain.Ticket{ID: uuid.New(), Title: "Printer", State: domain.TicketStateOpen}
+	tickets := &fakeTicketRepo{tickets: map[uuid.UUID]domain.Ticket{resolved.ID: resolved, open.ID: open}}
+	csat := &fakeCSATRepo{ratings: map[uuid.UUID]int{}}
+	h := handlers.NewHandler(conf, nil, nil, nil, service.NewCSATService(csat, tickets, nil, nil, conf), nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	router := httpadapter.Router(conf, h)
+
+	token := func(ticketID uuid.UUID) string {
+		token, err := util.GenerateActionToken(conf, ticketID.String(), "csat", time.Hour)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+
+	t.Run("Following a link records nothing", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/csat/"+token(resolved.ID)+"?rating=5", nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET /csat = %d; want %d (%s)", rec.Code, http.StatusOK, rec.Body)
+		}
+		if len(csat.ratings) != 0 {
+			t.Errorf("GET /csat recorded %v", csat.ratings)
+		}
+		body := rec.Body.String()
+		if !strings.Contains(body, `value="5" checked`) || !strings.Contains(body, "VPN &lt;down&gt;") {
+			t.Errorf("GET /csat = %s; want the form with 5 picked", body)
+		}
+	})
+
+	tests := []struct {
+		name        string
+		ticket      uuid.UUID
+		contentType string
+		body        string
+		expected    int
+		rating      int
+	}{
+		{"Form", resolved.ID, "application/x-www-form-urlencoded", url.Values{"rating": {"4"}, "comment": {"quick"}}.Encode(), http.StatusOK, 4},
+		{"JSON", resolved.ID, "application/json", `{"rating": 2}`, http.StatusOK, 2},
+		{"Invalid rating", resolved.ID, "application/json", `{"rating": 9}`, http.StatusBadRequest, 0},
+		{"Ticket not resolved", open.ID, "application/json", `{"rating": 5}`, http.StatusConflict, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clear(csat.ratings)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/csat/"+token(tt.ticket), strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expected {
+				t.Fatalf("POST /csat = %d; want %d (%s)", rec.Code, tt.expected, rec.Body)
+			}
+			if got := csat.ratings[tt.ticket]; got != tt.rating {
+				t.Errorf("POST /csat recorded %d; want %d", got, tt.rating)
+			}
+		})
+	}
+
+	t.Run("Bad token", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/csat/not-a-token?rating=5", nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("GET /csat = %d; want %d", rec.Code, http.StatusUnauthorized)
+		}
+	})
+}
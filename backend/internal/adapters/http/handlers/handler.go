@@ -7,17 +7,49 @@ import (
 )
 
 type Handler struct {
-	config         *configs.Config
-	userService    ports.UserService
-	ticketService  ports.TicketService
-	commentService ports.CommentService
+	config              *configs.Config
+	userService         ports.UserService
+	ticketService       ports.TicketService
+	commentService      ports.CommentService
+	csatService         ports.CSATService
+	matrixService       ports.PriorityMatrixService
+	checklistService    ports.ChecklistService
+	viewService         ports.SavedViewService
+	approvalService     ports.ApprovalService
+	orgService          ports.OrganizationService
+	teamService         ports.TeamService
+	availabilityService ports.AvailabilityService
+	calendarService     ports.CalendarService
+	transferService     ports.TransferService
+	mentionService      ports.MentionService
+	reactionService     ports.ReactionService
+	macroService        ports.MacroService
+	passwordService     ports.PasswordService
+	verificationService ports.VerificationService
+	sessionService      ports.SessionService
 }
 
-func NewHandler(cfg *configs.Config, u ports.UserService, t ports.TicketService, c ports.CommentService) *Handler {
+func NewHandler(cfg *configs.Config, u ports.UserService, t ports.TicketService, c ports.CommentService, cs ports.CSATService, pm ports.PriorityMatrixService, cl ports.ChecklistService, sv ports.SavedViewService, ap ports.ApprovalService, org ports.OrganizationService, tm ports.TeamService, av ports.AvailabilityService, cal ports.CalendarService, tr ports.TransferService, mn ports.MentionService, rc ports.ReactionService, mc ports.MacroService, pw ports.PasswordService, vf ports.VerificationService, ss ports.SessionService) *Handler {
 	return &Handler{
-		config:         cfg,
-		userService:    u,
-		ticketService:  t,
-		commentService: c,
+		config:              cfg,
+		userService:         u,
+		ticketService:       t,
+		commentService:      c,
+		csatService:         cs,
+		matrixService:       pm,
+		checklistService:    cl,
+		viewService:         sv,
+		approvalService:     ap,
+		orgService:          org,
+		teamService:         tm,
+		availabilityService: av,
+		calendarService:     cal,
+		transferService:     tr,
+		mentionService:      mn,
+		reactionService:     rc,
+		macroService:        mc,
+		passwordService:     pw,
+		verificationService: vf,
+		sessionService:      ss,
 	}
 }
@@ -0,0 +1,302 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/nickhildpac/ticket-management-app/internal/application/authorization"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+	"github.com/nickhildpac/ticket-management-app/pkg/markdown"
+	"github.com/nickhildpac/ticket-management-app/pkg/util"
+)
+
+// MacroPayload is used for both create and update; an update replaces the
+// whole macro. Unset actions leave the ticket as it is, and the state and
+// priority use their names, e.g. "resolved".
+type MacroPayload struct {
+	Name           string       `json:"name"`
+	Reply          string       `json:"reply"`
+	Visibility     string       `json:"visibility"`
+	Shared         bool         `json:"shared"`
+	State          *string      `json:"state"`
+	ResolutionCode string       `json:"resolution_code,omitempty"`
+	Priority       *string      `json:"priority"`
+	AssignedTo     *[]uuid.UUID `json:"assigned_to"`
+	Labels         []string     `json:"labels"`
+}
+
+type MacroResponse struct {
+	MacroPayload
+	ID        uuid.UUID  `json:"id"`
+	OwnerID   *uuid.UUID `json:"owner_id"`
+	CreatedBy *uuid.UUID `json:"created_by"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// ApplyMacroPayload names the ticket to apply a macro to
+type ApplyMacroPayload struct {
+	TicketID uuid.UUID `json:"ticket_id"`
+}
+
+type ApplyMacroResponse struct {
+	Comment *CommentDetailResponse `json:"comment"`
+	Ticket  UpdateTicketResponse   `json:"ticket"`
+}
+
+func (p MacroPayload) macro() (domain.Macro, error) {
+	macro := domain.Macro{
+		Name:  p.Name,
+		Reply: p.Reply,
+		Actions: domain.MacroActions{
+			Labels: p.Labels,
+		},
+	}
+	visibility, err := domain.GetCommentVisibility(p.Visibility)
+	if err != nil {
+		return macro, err
+	}
+	macro.Visibility = visibility
+	if p.State != nil {
+		state, err := domain.GetTicketState(*p.State)
+		if err != nil {
+			return macro, err
+		}
+		macro.Actions.State = &state
+		if p.ResolutionCode != "" {
+			if macro.Actions.ResolutionCode, err = domain.GetResolutionCode(p.ResolutionCode); err != nil {
+				return macro, err
+			}
+		}
+	}
+	if p.Priority != nil {
+		priority := domain.GetTicketPriority(*p.Priority)
+		if priority == -1 {
+			return macro, domain.ErrInvalidPriority
+		}
+		macro.Actions.Priority = &priority
+	}
+	if p.AssignedTo != nil {
+		macro.Actions.AssignedTo = *p.AssignedTo
+		if macro.Actions.AssignedTo == nil {
+			macro.Actions.AssignedTo = []uuid.UUID{}
+		}
+	}
+	return macro, nil
+}
+
+func macroResponse(m domain.Macro) MacroResponse {
+	resp := MacroResponse{
+		MacroPayload: MacroPayload{
+			Name:           m.Name,
+			Reply:          m.Reply,
+			Visibility:     string(m.Visibility),
+			Shared:         m.Shared(),
+			ResolutionCode: string(m.Actions.ResolutionCode),
+			Labels:         m.Actions.Labels,
+		},
+		ID:        m.ID,
+		OwnerID:   m.OwnerID,
+		CreatedBy: m.CreatedBy,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+	if m.Actions.State != nil {
+		state := m.Actions.State.String()
+		resp.State = &state
+	}
+	if m.Actions.Priority != nil {
+		priority := m.Actions.Priority.String()
+		resp.Priority = &priority
+	}
+	if m.Actions.AssignedTo != nil {
+		resp.AssignedTo = &m.Actions.AssignedTo
+	}
+	return resp
+}
+
+func macroError(w http.ResponseWriter, err error) {
+	if err == authorization.ErrAccessDenied {
+		util.ErrorResponse(w, http.StatusForbidden, err)
+		return
+	}
+	if errors.Is(err, domain.ErrMacroNotFound) {
+		util.ErrorResponse(w, http.StatusNotFound, err)
+		return
+	}
+	if errors.Is(err, domain.ErrEmptyMacroName) ||
+		errors.Is(err, domain.ErrEmptyMacro) ||
+		errors.Is(err, domain.ErrCommentTooLong) ||
+		errors.Is(err, domain.ErrUnknownPlaceholder) ||
+		errors.Is(err, domain.ErrInvalidCommand) ||
+		errors.Is(err, domain.ErrUnknownAssignee) ||
+		errors.Is(err, domain.ErrResolutionCodeRequired) ||
+		errors.Is(err, domain.ErrReopenReasonRequired) ||
+		errors.Is(err, domain.ErrTeamOrgMismatch) {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+	if errors.Is(err, domain.ErrInvalidStatusTransition) ||
+		errors.Is(err, domain.ErrChecklistIncomplete) ||
+		errors.Is(err, domain.ErrApprovalRequired) ||
+		errors.Is(err, domain.ErrApprovalRejected) ||
+		errors.Is(err, domain.ErrAgentOutOfOffice) ||
+		errors.Is(err, domain.ErrAgentAway) ||
+		errors.Is(err, domain.ErrAgentAtCapacity) {
+		util.ErrorResponse(w, http.StatusConflict, err)
+		return
+	}
+	util.ErrorResponse(w, http.StatusInternalServerError, err)
+}
+
+func (h *Handler) GetMacros(w http.ResponseWriter, r *http.Request) {
+	macros, err := h.macroService.ListMacros(r.Context())
+	if err != nil {
+		macroError(w, err)
+		return
+	}
+
+	resp := make([]MacroResponse, len(macros))
+	for i, m := range macros {
+		resp[i] = macroResponse(m)
+	}
+	util.WriteResponse(w, http.StatusOK, resp)
+}
+
+func (h *Handler) GetMacro(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	macro, err := h.macroService.GetMacro(r.Context(), id)
+	if err != nil {
+		macroError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, macroResponse(*macro))
+}
+
+func (h *Handler) CreateMacro(w http.ResponseWriter, r *http.Request) {
+	var payload MacroPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	macro, err := payload.macro()
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	created, err := h.macroService.CreateMacro(r.Context(), macro, payload.Shared)
+	if err != nil {
+		macroError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusCreated, macroResponse(*created))
+}
+
+func (h *Handler) UpdateMacro(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var payload MacroPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	macro, err := payload.macro()
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+	macro.ID = id
+
+	updated, err := h.macroService.UpdateMacro(r.Context(), macro, payload.Shared)
+	if err != nil {
+		macroError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, macroResponse(*updated))
+}
+
+func (h *Handler) DeleteMacro(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.macroService.DeleteMacro(r.Context(), id); err != nil {
+		macroError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, map[string]string{"message": "macro deleted"})
+}
+
+// RenderMacro previews a macro's reply for the ticket in the ticket_id query
+// parameter
+func (h *Handler) RenderMacro(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+	ticketID, err := uuid.Parse(r.URL.Query().Get("ticket_id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	reply, err := h.macroService.Render(r.Context(), id, ticketID)
+	if err != nil {
+		macroError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, map[string]string{
+		"reply":      reply,
+		"reply_html": markdown.Render(reply),
+	})
+}
+
+// ApplyMacro posts a macro's reply to a ticket and makes its changes in one
+// go
+func (h *Handler) ApplyMacro(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var payload ApplyMacroPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	applied, err := h.macroService.Apply(r.Context(), id, payload.TicketID)
+	if err != nil {
+		macroError(w, err)
+		return
+	}
+
+	resp := ApplyMacroResponse{
+		Ticket: UpdateTicketResponse{Ticket: applied.Ticket, DescriptionHTML: markdown.Render(applied.Ticket.Description)},
+	}
+	if applied.Comment != nil {
+		comment := commentDetail(applied.Comment)
+		resp.Comment = &comment
+	}
+	util.WriteResponse(w, http.StatusOK, resp)
+}
@@ -0,0 +1,23 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/nickhildpac/ticket-management-app/pkg/util"
+)
+
+// GetMentions lists the places the caller was mentioned, newest first
+func (h *Handler) GetMentions(w http.ResponseWriter, r *http.Request) {
+	limit, offset, err := pagination(r)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	mentions, err := h.mentionService.ListMine(r.Context(), limit, offset)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, mentions)
+}
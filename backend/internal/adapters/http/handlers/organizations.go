@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/nickhildpac/ticket-management-app/internal/application/authorization"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+	"github.com/nickhildpac/ticket-management-app/pkg/util"
+)
+
+type OrganizationPayload struct {
+	Name        string `json:"name"`
+	EmailDomain string `json:"email_domain"`
+}
+
+type MoveUserOrgPayload struct {
+	OrgID uuid.UUID `json:"org_id"`
+}
+
+func organizationError(w http.ResponseWriter, err error) {
+	if err == authorization.ErrAccessDenied {
+		util.ErrorResponse(w, http.StatusForbidden, err)
+		return
+	}
+	if errors.Is(err, domain.ErrEmptyOrganizationName) ||
+		errors.Is(err, domain.ErrInvalidEmailDomain) ||
+		errors.Is(err, domain.ErrOrgAccessNotStaff) {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+	util.ErrorResponse(w, http.StatusInternalServerError, err)
+}
+
+func (h *Handler) GetOrganizations(w http.ResponseWriter, r *http.Request) {
+	orgs, err := h.orgService.ListOrganizations(r.Context())
+	if err != nil {
+		organizationError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, orgs)
+}
+
+func (h *Handler) CreateOrganization(w http.ResponseWriter, r *http.Request) {
+	var payload OrganizationPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	org, err := h.orgService.CreateOrganization(r.Context(), domain.Organization{
+		Name:        payload.Name,
+		EmailDomain: payload.EmailDomain,
+	})
+	if err != nil {
+		organizationError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusCreated, org)
+}
+
+func (h *Handler) MoveUserToOrganization(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var payload MoveUserOrgPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	user, err := h.orgService.MoveUser(r.Context(), userID, payload.OrgID)
+	if err != nil {
+		organizationError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, user)
+}
+
+func (h *Handler) GrantOrgAccess(w http.ResponseWriter, r *http.Request) {
+	h.setOrgAccess(w, r, true)
+}
+
+func (h *Handler) RevokeOrgAccess(w http.ResponseWriter, r *http.Request) {
+	h.setOrgAccess(w, r, false)
+}
+
+func (h *Handler) setOrgAccess(w http.ResponseWriter, r *http.Request, grant bool) {
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+	orgID, err := uuid.Parse(chi.URLParam(r, "orgID"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if grant {
+		err = h.orgService.GrantAccess(r.Context(), userID, orgID)
+	} else {
+		err = h.orgService.RevokeAccess(r.Context(), userID, orgID)
+	}
+	if err != nil {
+		organizationError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusNoContent, nil)
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+	"github.com/nickhildpac/ticket-management-app/pkg/util"
+)
+
+type ForgotPasswordPayload struct {
+	Email string `json:"email"`
+}
+
+type ResetPasswordPayload struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+func passwordError(w http.ResponseWriter, err error) {
+	if errors.Is(err, domain.ErrInvalidUserToken) ||
+		errors.Is(err, domain.ErrPasswordTooShort) ||
+		errors.Is(err, domain.ErrPasswordTooLong) {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+	util.ErrorResponse(w, http.StatusInternalServerError, err)
+}
+
+// ForgotPassword mails a reset link to the address if it has an account.
+// The response is the same whether or not it does, and failures are only
+// logged, so it can't be used to find out who has an account.
+func (h *Handler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	var payload ForgotPasswordPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.passwordService.RequestReset(r.Context(), payload.Email); err != nil {
+		log.Printf("failed to send password reset: %v", err)
+	}
+	util.WriteResponse(w, http.StatusAccepted, map[string]string{
+		"message": "if the address has an account, a reset link is on its way",
+	})
+}
+
+func (h *Handler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var payload ResetPasswordPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.passwordService.ResetPassword(r.Context(), payload.Token, payload.Password); err != nil {
+		passwordError(w, err)
+		return
+	}
+	http.SetCookie(w, util.GetExpiredRefreshCookie(h.config))
+	util.WriteResponse(w, http.StatusOK, map[string]string{"message": "password reset"})
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/nickhildpac/ticket-management-app/internal/application/authorization"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+	"github.com/nickhildpac/ticket-management-app/pkg/util"
+)
+
+type PriorityMatrixEntryPayload struct {
+	Impact   string `json:"impact"`
+	Urgency  string `json:"urgency"`
+	Priority string `json:"priority"`
+}
+
+func priorityMatrixResponse(matrix domain.PriorityMatrix) []PriorityMatrixEntryPayload {
+	entries := matrix.Entries()
+	resp := make([]PriorityMatrixEntryPayload, len(entries))
+	for i, e := range entries {
+		resp[i] = PriorityMatrixEntryPayload{
+			Impact:   e.Impact.String(),
+			Urgency:  e.Urgency.String(),
+			Priority: e.Priority.String(),
+		}
+	}
+	return resp
+}
+
+func (h *Handler) GetPriorityMatrix(w http.ResponseWriter, r *http.Request) {
+	matrix, err := h.matrixService.GetMatrix(r.Context())
+	if err != nil {
+		if err == authorization.ErrAccessDenied {
+			util.ErrorResponse(w, http.StatusForbidden, err)
+			return
+		}
+		util.ErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, priorityMatrixResponse(matrix))
+}
+
+func (h *Handler) UpdatePriorityMatrix(w http.ResponseWriter, r *http.Request) {
+	var payload []PriorityMatrixEntryPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	entries := make([]domain.PriorityMatrixEntry, len(payload))
+	for i, p := range payload {
+		impact, err := domain.GetTicketImpact(p.Impact)
+		if err != nil {
+			util.ErrorResponse(w, http.StatusBadRequest, err)
+			return
+		}
+		urgency, err := domain.GetTicketUrgency(p.Urgency)
+		if err != nil {
+			util.ErrorResponse(w, http.StatusBadRequest, err)
+			return
+		}
+		entries[i] = domain.PriorityMatrixEntry{
+			Impact:   impact,
+			Urgency:  urgency,
+			Priority: domain.GetTicketPriority(p.Priority),
+		}
+	}
+
+	matrix, err := h.matrixService.UpdateMatrix(r.Context(), entries)
+	if err != nil {
+		if err == authorization.ErrAccessDenied {
+			util.ErrorResponse(w, http.StatusForbidden, err)
+			return
+		}
+		if errors.Is(err, domain.ErrInvalidPriority) || errors.Is(err, domain.ErrIncompletePriorityMatrix) {
+			util.ErrorResponse(w, http.StatusBadRequest, err)
+			return
+		}
+		util.ErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, priorityMatrixResponse(matrix))
+}
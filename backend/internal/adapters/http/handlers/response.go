@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+	"github.com/nickhildpac/ticket-management-app/pkg/markdown"
 )
 
 type UserInfo struct {
@@ -14,23 +16,84 @@ type UserInfo struct {
 }
 
 type TicketResponse struct {
-	TicketID    uuid.UUID   `json:"id"`
-	CreatedBy   uuid.UUID   `json:"created_by"`
-	Creator     UserInfo    `json:"creator"`
-	AssignedTo  []uuid.UUID `json:"assigned_to"`
-	Title       string      `json:"title"`
-	Description string      `json:"description"`
-	State       string      `json:"state"`
-	Priority    string      `json:"priority"`
-	CreatedAt   time.Time   `json:"created_at"`
-	UpdatedAt   time.Time   `json:"updated_at"`
+	TicketID           uuid.UUID                `json:"id"`
+	CreatedBy          uuid.UUID                `json:"created_by"`
+	Creator            UserInfo                 `json:"creator"`
+	AssignedTo         []uuid.UUID              `json:"assigned_to"`
+	TeamID             *uuid.UUID               `json:"team_id"`
+	Title              string                   `json:"title"`
+	Description        string                   `json:"description"`
+	DescriptionHTML    string                   `json:"description_html"`
+	Type               string                   `json:"type"`
+	State              string                   `json:"state"`
+	ResolutionCode     string                   `json:"resolution_code,omitempty"`
+	StateReason        string                   `json:"state_reason,omitempty"`
+	Priority           string                   `json:"priority"`
+	Impact             string                   `json:"impact"`
+	Urgency            string                   `json:"urgency"`
+	PriorityOverridden bool                     `json:"priority_overridden"`
+	Rank               string                   `json:"rank"`
+	Labels             []string                 `json:"labels"`
+	Checklist          domain.ChecklistProgress `json:"checklist"`
+	PendingTransfer    *domain.Transfer         `json:"pending_transfer"`
+	CreatedAt          time.Time                `json:"created_at"`
+	UpdatedAt          time.Time                `json:"updated_at"`
+}
+
+// UpdateTicketResponse is the updated ticket, plus a warning for each new
+// assignee who is unavailable
+type UpdateTicketResponse struct {
+	*domain.Ticket
+	DescriptionHTML string                     `json:"description_html"`
+	Warnings        []domain.AssignmentWarning `json:"warnings,omitempty"`
 }
 
 type CommentResponse struct {
-	ID        uuid.UUID `json:"id"`
-	TicketID  uuid.UUID `json:"ticket_id"`
-	CreatedBy uuid.UUID `json:"created_by"`
-	Creator   UserInfo  `json:"creator"`
-	Description string `json:"description"`
-	CreatedAt time.Time `json:"created_at"`
+	ID              uuid.UUID                `json:"id"`
+	TicketID        uuid.UUID                `json:"ticket_id"`
+	CreatedBy       uuid.UUID                `json:"created_by"`
+	Creator         UserInfo                 `json:"creator"`
+	Description     string                   `json:"description"`
+	DescriptionHTML string                   `json:"description_html"`
+	Visibility      domain.CommentVisibility `json:"visibility"`
+	ParentID        *uuid.UUID               `json:"parent_id"`
+	Depth           int                      `json:"depth"`
+	Reactions       []domain.Reaction        `json:"reactions"`
+	EditedAt        *time.Time               `json:"edited_at"`
+	CreatedAt       time.Time                `json:"created_at"`
+	// Replies are only set when comments are listed as a tree
+	Replies []CommentResponse `json:"replies,omitempty"`
+}
+
+// CommentDetailResponse is a single comment with its description rendered
+// to HTML
+type CommentDetailResponse struct {
+	*domain.Comment
+	DescriptionHTML string `json:"description_html"`
+}
+
+func commentDetail(c *domain.Comment) CommentDetailResponse {
+	return CommentDetailResponse{Comment: c, DescriptionHTML: markdown.Render(c.Description)}
+}
+
+type DuplicateResponse struct {
+	TicketID uuid.UUID `json:"id"`
+	Title    string    `json:"title"`
+	State    string    `json:"state"`
+	Priority string    `json:"priority"`
+	Score    float64   `json:"score"`
+}
+
+func duplicateResponses(matches []domain.TicketMatch) []DuplicateResponse {
+	resp := make([]DuplicateResponse, len(matches))
+	for i, m := range matches {
+		resp[i] = DuplicateResponse{
+			TicketID: m.Ticket.ID,
+			Title:    m.Ticket.Title,
+			State:    m.Ticket.State.String(),
+			Priority: m.Ticket.Priority.String(),
+			Score:    m.Score,
+		}
+	}
+	return resp
 }
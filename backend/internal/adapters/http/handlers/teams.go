@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/nickhildpac/ticket-management-app/internal/application/authorization"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+	"github.com/nickhildpac/ticket-management-app/pkg/util"
+)
+
+// TeamPayload creates or updates a team. OrgID defaults to the caller's own
+// organization and is ignored on update.
+type TeamPayload struct {
+	Name   string     `json:"name"`
+	LeadID *uuid.UUID `json:"lead_id"`
+	OrgID  uuid.UUID  `json:"org_id"`
+}
+
+func teamError(w http.ResponseWriter, err error) {
+	if err == authorization.ErrAccessDenied {
+		util.ErrorResponse(w, http.StatusForbidden, err)
+		return
+	}
+	if errors.Is(err, domain.ErrEmptyTeamName) ||
+		errors.Is(err, domain.ErrTeamMemberNotStaff) ||
+		errors.Is(err, domain.ErrTeamMemberOrg) {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+	if errors.Is(err, domain.ErrRemoveTeamLead) {
+		util.ErrorResponse(w, http.StatusConflict, err)
+		return
+	}
+	util.ErrorResponse(w, http.StatusInternalServerError, err)
+}
+
+func (h *Handler) GetTeams(w http.ResponseWriter, r *http.Request) {
+	teams, err := h.teamService.ListTeams(r.Context())
+	if err != nil {
+		teamError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, teams)
+}
+
+func (h *Handler) GetTeam(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	team, err := h.teamService.GetTeam(r.Context(), id)
+	if err != nil {
+		teamError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, team)
+}
+
+func (h *Handler) CreateTeam(w http.ResponseWriter, r *http.Request) {
+	var payload TeamPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	team, err := h.teamService.CreateTeam(r.Context(), domain.Team{
+		OrgID:  payload.OrgID,
+		Name:   payload.Name,
+		LeadID: payload.LeadID,
+	})
+	if err != nil {
+		teamError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusCreated, team)
+}
+
+func (h *Handler) UpdateTeam(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var payload TeamPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	team, err := h.teamService.UpdateTeam(r.Context(), domain.Team{
+		ID:     id,
+		Name:   payload.Name,
+		LeadID: payload.LeadID,
+	})
+	if err != nil {
+		teamError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, team)
+}
+
+func (h *Handler) DeleteTeam(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.teamService.DeleteTeam(r.Context(), id); err != nil {
+		teamError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusNoContent, nil)
+}
+
+func (h *Handler) AddTeamMember(w http.ResponseWriter, r *http.Request) {
+	h.setTeamMember(w, r, true)
+}
+
+func (h *Handler) RemoveTeamMember(w http.ResponseWriter, r *http.Request) {
+	h.setTeamMember(w, r, false)
+}
+
+func (h *Handler) setTeamMember(w http.ResponseWriter, r *http.Request, add bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+	userID, err := uuid.Parse(chi.URLParam(r, "userID"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var team *domain.Team
+	if add {
+		team, err = h.teamService.AddMember(r.Context(), id, userID)
+	} else {
+		team, err = h.teamService.RemoveMember(r.Context(), id, userID)
+	}
+	if err != nil {
+		teamError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, team)
+}
@@ -3,27 +3,41 @@ package handlers
 import (
 	"encoding/json"
 	"errors"
+	"log"
 	"net/http"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	"github.com/nickhildpac/ticket-management-app/internal/application/authorization"
 	"github.com/nickhildpac/ticket-management-app/internal/domain"
 	"github.com/nickhildpac/ticket-management-app/pkg/configs"
+	"github.com/nickhildpac/ticket-management-app/pkg/markdown"
 	"github.com/nickhildpac/ticket-management-app/pkg/util"
 )
 
 type TicketPayload struct {
 	Title       string `json:"title"`
 	Description string `json:"description"`
+	Impact      string `json:"impact"`
+	Urgency     string `json:"urgency"`
+	Type        string `json:"type"`
 }
 
+// UpdateTicketPayload changes only the fields that are set. A zero UUID in
+// TeamID takes the ticket off its team's queue.
 type UpdateTicketPayload struct {
-	Title       *string      `json:"title"`
-	Description *string      `json:"description"`
-	State       *string      `json:"state"`
-	Priority    *string      `json:"priority"`
-	AssignedTo  *[]uuid.UUID `json:"assigned_to"`
+	Title          *string      `json:"title"`
+	Description    *string      `json:"description"`
+	State          *string      `json:"state"`
+	ResolutionCode *string      `json:"resolution_code"`
+	Reason         *string      `json:"reason"`
+	Impact         *string      `json:"impact"`
+	Urgency        *string      `json:"urgency"`
+	Priority       *string      `json:"priority"`
+	AssignedTo     *[]uuid.UUID `json:"assigned_to"`
+	TeamID         *uuid.UUID   `json:"team_id"`
+	Labels         *[]string    `json:"labels"`
 }
 
 func (h *Handler) GetAllTickets(w http.ResponseWriter, r *http.Request) {
@@ -68,6 +82,37 @@ func (h *Handler) GetAssignedTickets(w http.ResponseWriter, r *http.Request) {
 	util.WriteResponse(w, http.StatusOK, tickets)
 }
 
+// GetTeamTickets lists the tickets queued to the team given by team_id, or
+// to any of the caller's teams without it
+func (h *Handler) GetTeamTickets(w http.ResponseWriter, r *http.Request) {
+	limit, offset, err := pagination(r)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var teamID *uuid.UUID
+	if s := r.URL.Query().Get("team_id"); s != "" {
+		id, err := uuid.Parse(s)
+		if err != nil {
+			util.ErrorResponse(w, http.StatusBadRequest, err)
+			return
+		}
+		teamID = &id
+	}
+
+	tickets, err := h.ticketService.ListByTeam(r.Context(), teamID, limit, offset)
+	if err != nil {
+		if err == authorization.ErrAccessDenied {
+			util.ErrorResponse(w, http.StatusForbidden, err)
+			return
+		}
+		util.ErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, tickets)
+}
+
 func (h *Handler) GetTicket(w http.ResponseWriter, r *http.Request) {
 	idParam := chi.URLParam(r, "id")
 	tid, err := uuid.Parse(idParam)
@@ -92,21 +137,45 @@ func (h *Handler) GetTicket(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	progress, err := h.checklistService.Progress(r.Context(), ticket.ID)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	transfer, err := h.transferService.Pending(r.Context(), ticket.ID)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
 	resp := TicketResponse{
-		TicketID:    ticket.ID,
-		Title:       ticket.Title,
-		Description: ticket.Description,
-		CreatedBy:   ticket.CreatedBy,
+		TicketID:        ticket.ID,
+		Title:           ticket.Title,
+		Description:     ticket.Description,
+		DescriptionHTML: markdown.Render(ticket.Description),
+		Type:            ticket.Type,
+		CreatedBy:       ticket.CreatedBy,
 		Creator: UserInfo{
 			ID:        creator.ID,
 			FirstName: creator.FirstName,
 			LastName:  creator.LastName,
 			Email:     creator.Email,
 		},
-		CreatedAt:   ticket.CreatedAt,
-		State:       ticket.State.String(),
-		Priority:    ticket.Priority.String(),
-		AssignedTo:  ticket.AssignedTo,
+		CreatedAt:          ticket.CreatedAt,
+		State:              ticket.State.String(),
+		ResolutionCode:     string(ticket.ResolutionCode),
+		StateReason:        ticket.StateReason,
+		Priority:           ticket.Priority.String(),
+		Impact:             ticket.Impact.String(),
+		Urgency:            ticket.Urgency.String(),
+		PriorityOverridden: ticket.PriorityOverridden,
+		Rank:               ticket.Rank,
+		Labels:             ticket.Labels,
+		AssignedTo:         ticket.AssignedTo,
+		TeamID:             ticket.TeamID,
+		Checklist:          *progress,
+		PendingTransfer:    transfer,
 	}
 	util.WriteResponse(w, http.StatusOK, resp)
 }
@@ -124,16 +193,82 @@ func (h *Handler) CreateTicket(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ticket, err := h.ticketService.CreateTicket(r.Context(), domain.Ticket{
+	newTicket := domain.Ticket{
 		Title:       payload.Title,
 		Description: payload.Description,
+		Type:        payload.Type,
 		CreatedBy:   userID,
+	}
+	if payload.Impact != "" {
+		newTicket.Impact, err = domain.GetTicketImpact(payload.Impact)
+		if err != nil {
+			util.ErrorResponse(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+	if payload.Urgency != "" {
+		newTicket.Urgency, err = domain.GetTicketUrgency(payload.Urgency)
+		if err != nil {
+			util.ErrorResponse(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	matches, err := h.ticketService.FindDuplicates(r.Context(), payload.Title, payload.Description)
+	if err != nil {
+		// Suggestions are best effort and must not block ticket creation
+		log.Println("Error finding duplicate tickets:", err)
+		matches = nil
+	}
+
+	// dry_run only reports likely duplicates so clients can warn before submitting
+	if r.URL.Query().Get("dry_run") == "true" {
+		util.WriteResponse(w, http.StatusOK, struct {
+			PossibleDuplicates []DuplicateResponse `json:"possible_duplicates"`
+		}{
+			PossibleDuplicates: duplicateResponses(matches),
+		})
+		return
+	}
+
+	ticket, err := h.ticketService.CreateTicket(r.Context(), newTicket)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidTicketType) || errors.Is(err, domain.ErrDescriptionTooLong) {
+			util.ErrorResponse(w, http.StatusBadRequest, err)
+			return
+		}
+		if errors.Is(err, domain.ErrEmailNotVerified) {
+			util.ErrorResponse(w, http.StatusForbidden, err)
+			return
+		}
+		util.ErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusAccepted, struct {
+		*domain.Ticket
+		DescriptionHTML    string              `json:"description_html"`
+		PossibleDuplicates []DuplicateResponse `json:"possible_duplicates"`
+	}{
+		Ticket:             ticket,
+		DescriptionHTML:    markdown.Render(ticket.Description),
+		PossibleDuplicates: duplicateResponses(matches),
 	})
+}
+
+// SuggestDuplicates is called while the user types a new ticket
+func (h *Handler) SuggestDuplicates(w http.ResponseWriter, r *http.Request) {
+	var payload TicketPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	matches, err := h.ticketService.FindDuplicates(r.Context(), payload.Title, payload.Description)
 	if err != nil {
 		util.ErrorResponse(w, http.StatusInternalServerError, err)
 		return
 	}
-	util.WriteResponse(w, http.StatusAccepted, ticket)
+	util.WriteResponse(w, http.StatusOK, duplicateResponses(matches))
 }
 
 func (h *Handler) UpdateTicket(w http.ResponseWriter, r *http.Request) {
@@ -160,6 +295,7 @@ func (h *Handler) UpdateTicket(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	assignedBefore := ticket.AssignedTo
 	changed := false
 	updatedFields := []string{}
 	if payload.Title != nil {
@@ -182,8 +318,52 @@ func (h *Handler) UpdateTicket(w http.ResponseWriter, r *http.Request) {
 		changed = true
 		updatedFields = append(updatedFields, "state")
 	}
+	if payload.ResolutionCode != nil {
+		code, err := domain.GetResolutionCode(*payload.ResolutionCode)
+		if err != nil {
+			util.ErrorResponse(w, http.StatusBadRequest, err)
+			return
+		}
+		ticket.ResolutionCode = code
+		updatedFields = append(updatedFields, "resolution_code")
+	}
+	if payload.Reason != nil {
+		ticket.StateReason = *payload.Reason
+		updatedFields = append(updatedFields, "reason")
+	}
+	if payload.Impact != nil {
+		impact, err := domain.GetTicketImpact(*payload.Impact)
+		if err != nil {
+			util.ErrorResponse(w, http.StatusBadRequest, err)
+			return
+		}
+		ticket.Impact = impact
+		changed = true
+		updatedFields = append(updatedFields, "impact")
+	}
+	if payload.Urgency != nil {
+		urgency, err := domain.GetTicketUrgency(*payload.Urgency)
+		if err != nil {
+			util.ErrorResponse(w, http.StatusBadRequest, err)
+			return
+		}
+		ticket.Urgency = urgency
+		changed = true
+		updatedFields = append(updatedFields, "urgency")
+	}
 	if payload.Priority != nil {
-		ticket.Priority = domain.GetTicketPriority(*payload.Priority)
+		// "auto" drops an override so priority follows the matrix again
+		if strings.EqualFold(*payload.Priority, "auto") {
+			ticket.PriorityOverridden = false
+		} else {
+			priority := domain.GetTicketPriority(*payload.Priority)
+			if priority < 0 {
+				util.ErrorResponse(w, http.StatusBadRequest, domain.ErrInvalidPriority)
+				return
+			}
+			ticket.Priority = priority
+			ticket.PriorityOverridden = true
+		}
 		changed = true
 		updatedFields = append(updatedFields, "priority")
 	}
@@ -192,6 +372,19 @@ func (h *Handler) UpdateTicket(w http.ResponseWriter, r *http.Request) {
 		changed = true
 		updatedFields = append(updatedFields, "assigned_to")
 	}
+	if payload.TeamID != nil {
+		ticket.TeamID = payload.TeamID
+		if *payload.TeamID == uuid.Nil {
+			ticket.TeamID = nil
+		}
+		changed = true
+		updatedFields = append(updatedFields, "team_id")
+	}
+	if payload.Labels != nil {
+		ticket.Labels = domain.NormalizeLabels(*payload.Labels)
+		changed = true
+		updatedFields = append(updatedFields, "labels")
+	}
 
 	if !changed {
 		util.ErrorResponse(w, http.StatusBadRequest, errors.New("no fields provided to update"))
@@ -204,11 +397,35 @@ func (h *Handler) UpdateTicket(w http.ResponseWriter, r *http.Request) {
 			util.ErrorResponse(w, http.StatusForbidden, err)
 			return
 		}
+		if errors.Is(err, domain.ErrResolutionCodeRequired) ||
+			errors.Is(err, domain.ErrReopenReasonRequired) ||
+			errors.Is(err, domain.ErrTeamOrgMismatch) ||
+			errors.Is(err, domain.ErrDescriptionTooLong) {
+			util.ErrorResponse(w, http.StatusBadRequest, err)
+			return
+		}
+		if errors.Is(err, domain.ErrChecklistIncomplete) ||
+			errors.Is(err, domain.ErrApprovalRequired) ||
+			errors.Is(err, domain.ErrApprovalRejected) ||
+			errors.Is(err, domain.ErrAgentOutOfOffice) ||
+			errors.Is(err, domain.ErrAgentAway) ||
+			errors.Is(err, domain.ErrAgentAtCapacity) {
+			util.ErrorResponse(w, http.StatusConflict, err)
+			return
+		}
 		util.ErrorResponse(w, http.StatusInternalServerError, err)
 		return
 	}
 
-	util.WriteResponse(w, http.StatusOK, updated)
+	resp := UpdateTicketResponse{Ticket: updated, DescriptionHTML: markdown.Render(updated.Description)}
+	if payload.AssignedTo != nil {
+		resp.Warnings, err = h.availabilityService.Warnings(r.Context(), assignedBefore, updated.AssignedTo)
+		if err != nil {
+			util.ErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+	}
+	util.WriteResponse(w, http.StatusOK, resp)
 }
 
 func (h *Handler) DeleteTicket(w http.ResponseWriter, r *http.Request) {
@@ -231,3 +448,36 @@ func (h *Handler) DeleteTicket(w http.ResponseWriter, r *http.Request) {
 
 	util.WriteResponse(w, http.StatusNoContent, nil)
 }
+
+func (h *Handler) GetTicketEvents(w http.ResponseWriter, r *http.Request) {
+	idParam := chi.URLParam(r, "id")
+	tid, err := uuid.Parse(idParam)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	events, err := h.ticketService.ListEvents(r.Context(), tid, 50, 0)
+	if err != nil {
+		if err == authorization.ErrAccessDenied {
+			util.ErrorResponse(w, http.StatusForbidden, err)
+			return
+		}
+		util.ErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, events)
+}
+
+func (h *Handler) GetResolutionReport(w http.ResponseWriter, r *http.Request) {
+	counts, err := h.ticketService.ResolutionReport(r.Context())
+	if err != nil {
+		if err == authorization.ErrAccessDenied {
+			util.ErrorResponse(w, http.StatusForbidden, err)
+			return
+		}
+		util.ErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, counts)
+}
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/nickhildpac/ticket-management-app/internal/application/authorization"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+	"github.com/nickhildpac/ticket-management-app/pkg/util"
+)
+
+// TransferPayload hands a ticket to exactly one of an agent or a team
+type TransferPayload struct {
+	ToUserID *uuid.UUID `json:"to_user_id"`
+	ToTeamID *uuid.UUID `json:"to_team_id"`
+	Note     string     `json:"note"`
+}
+
+type TransferDecisionPayload struct {
+	Reason string `json:"reason"`
+}
+
+func transferError(w http.ResponseWriter, err error) {
+	if err == authorization.ErrAccessDenied {
+		util.ErrorResponse(w, http.StatusForbidden, err)
+		return
+	}
+	if errors.Is(err, domain.ErrInvalidTransferTarget) ||
+		errors.Is(err, domain.ErrTransferToSelf) ||
+		errors.Is(err, domain.ErrTransferNotStaff) ||
+		errors.Is(err, domain.ErrTransferOrg) ||
+		errors.Is(err, domain.ErrTeamOrgMismatch) {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+	if errors.Is(err, domain.ErrTransferPending) ||
+		errors.Is(err, domain.ErrNoPendingTransfer) ||
+		errors.Is(err, domain.ErrTransferTicketClosed) {
+		util.ErrorResponse(w, http.StatusConflict, err)
+		return
+	}
+	util.ErrorResponse(w, http.StatusInternalServerError, err)
+}
+
+func (h *Handler) GetTicketTransfers(w http.ResponseWriter, r *http.Request) {
+	tid, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	transfers, err := h.transferService.ListForTicket(r.Context(), tid)
+	if err != nil {
+		transferError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, transfers)
+}
+
+func (h *Handler) TransferTicket(w http.ResponseWriter, r *http.Request) {
+	tid, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var payload TransferPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	transfer, err := h.transferService.RequestTransfer(r.Context(), domain.Transfer{
+		TicketID: tid,
+		ToUserID: payload.ToUserID,
+		ToTeamID: payload.ToTeamID,
+		Note:     payload.Note,
+	})
+	if err != nil {
+		transferError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusCreated, transfer)
+}
+
+func (h *Handler) GetIncomingTransfers(w http.ResponseWriter, r *http.Request) {
+	transfers, err := h.transferService.ListIncoming(r.Context())
+	if err != nil {
+		transferError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, transfers)
+}
+
+func (h *Handler) AcceptTransfer(w http.ResponseWriter, r *http.Request) {
+	h.decideTransfer(w, r, true)
+}
+
+func (h *Handler) DeclineTransfer(w http.ResponseWriter, r *http.Request) {
+	h.decideTransfer(w, r, false)
+}
+
+func (h *Handler) decideTransfer(w http.ResponseWriter, r *http.Request, accept bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var payload TransferDecisionPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	decided, err := h.transferService.Decide(r.Context(), id, accept, payload.Reason)
+	if err != nil {
+		transferError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, decided)
+}
+
+func (h *Handler) CancelTransfer(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	cancelled, err := h.transferService.CancelTransfer(r.Context(), id)
+	if err != nil {
+		transferError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, cancelled)
+}
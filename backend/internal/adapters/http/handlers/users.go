@@ -7,7 +7,6 @@ import (
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"github.com/nickhildpac/ticket-management-app/internal/application/authorization"
 	"github.com/nickhildpac/ticket-management-app/internal/domain"
@@ -43,36 +42,23 @@ func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 		util.ErrorResponse(w, http.StatusUnauthorized, errors.New("invalid credentials"))
 		return
 	}
-
-	tokenPairs, err := util.GenerateTokenPair(h.config, &util.JWTUser{
-		ID:        user.ID,
-		FirstName: user.FirstName,
-		LastName:  user.LastName,
-		Email:     user.Email,
-		Role:      user.Role,
-	})
-	if err != nil {
-		util.ErrorResponse(w, http.StatusInternalServerError, err)
+	// Checked after the password so it doesn't tell strangers who signed up
+	if !user.Verified() && domain.VerificationPolicy(h.config.VerificationPolicy) == domain.VerificationPolicyLogin {
+		util.ErrorResponse(w, http.StatusForbidden, domain.ErrEmailNotVerified)
 		return
 	}
 
-	http.SetCookie(w, util.GetRefreshCookie(h.config, tokenPairs.RefreshToken))
-	util.WriteResponse(w, http.StatusOK, struct {
-		AccessToken string       `json:"access_token"`
-		User        util.JWTUser `json:"user"`
-	}{
-		AccessToken: tokenPairs.Token,
-		User: util.JWTUser{
-			ID:        user.ID,
-			FirstName: user.FirstName,
-			LastName:  user.LastName,
-			Email:     user.Email,
-			Role:      user.Role,
-		},
-	})
+	h.issueTokens(w, r, user, uuid.New())
 }
 
+// Logout ends the session server-side as well, so a copy of the refresh
+// token stops working too
 func (h *Handler) Logout(w http.ResponseWriter, r *http.Request) {
+	if refreshCookie, err := r.Cookie(h.config.CookieName); err == nil {
+		if err := h.sessionService.Revoke(r.Context(), refreshCookie.Value); err != nil {
+			log.Printf("failed to revoke session: %v", err)
+		}
+	}
 	http.SetCookie(w, util.GetExpiredRefreshCookie(h.config))
 	w.WriteHeader(http.StatusAccepted)
 }
@@ -120,12 +106,41 @@ func (h *Handler) GetAllUsers(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) GetBasicUsers(w http.ResponseWriter, r *http.Request) {
 	users, err := h.userService.GetAllUsersForAssignment(r.Context())
 	if err != nil {
+		if err == authorization.ErrAccessDenied {
+			util.ErrorResponse(w, http.StatusForbidden, err)
+			return
+		}
 		util.ErrorResponse(w, http.StatusInternalServerError, err)
 		return
 	}
 	util.WriteResponse(w, http.StatusOK, users)
 }
 
+// SearchUsers suggests users for mention autocomplete from the q query
+// parameter. Like GetBasicUsers it returns basic info only.
+func (h *Handler) SearchUsers(w http.ResponseWriter, r *http.Request) {
+	users, err := h.userService.SearchUsers(r.Context(), r.URL.Query().Get("q"))
+	if err != nil {
+		if err == authorization.ErrAccessDenied {
+			util.ErrorResponse(w, http.StatusForbidden, err)
+			return
+		}
+		util.ErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := make([]UserInfo, len(users))
+	for i, u := range users {
+		response[i] = UserInfo{
+			ID:        u.ID,
+			FirstName: u.FirstName,
+			LastName:  u.LastName,
+			Email:     u.Email,
+		}
+	}
+	util.WriteResponse(w, http.StatusOK, response)
+}
+
 func (h *Handler) CreateUser(w http.ResponseWriter, r *http.Request) {
 	var requestPayload struct {
 		FirstName string `json:"first_name"`
@@ -152,6 +167,10 @@ func (h *Handler) CreateUser(w http.ResponseWriter, r *http.Request) {
 		HashedPassword: hashedPassword,
 	})
 	if err != nil {
+		if errors.Is(err, domain.ErrSignupDomainNotAllowed) {
+			util.ErrorResponse(w, http.StatusForbidden, err)
+			return
+		}
 		log.Println("Error creating user:", err)
 		util.ErrorResponse(w, http.StatusInternalServerError, err)
 		return
@@ -159,6 +178,8 @@ func (h *Handler) CreateUser(w http.ResponseWriter, r *http.Request) {
 	util.WriteResponse(w, http.StatusCreated, user)
 }
 
+// RefreshToken exchanges the refresh token for a new pair. Each refresh
+// token works once; see ports.SessionService.
 func (h *Handler) RefreshToken(w http.ResponseWriter, r *http.Request) {
 	refreshCookie, err := r.Cookie(h.config.CookieName)
 	if err != nil {
@@ -166,40 +187,81 @@ func (h *Handler) RefreshToken(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	claims := &util.RefreshClaims{}
-	refreshToken := refreshCookie.Value
-	_, err = jwt.ParseWithClaims(refreshToken, claims, func(token *jwt.Token) (any, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("unexpected signing method")
-		}
-		return []byte(h.config.JWTSecret), nil
-	})
+	claims, err := util.VerifyRefreshToken(h.config, refreshCookie.Value)
 	if err != nil {
 		util.ErrorResponse(w, http.StatusUnauthorized, err)
 		return
 	}
 
-	userID, err := uuid.Parse(claims.Subject)
+	stored, err := h.sessionService.Rotate(r.Context(), refreshCookie.Value)
 	if err != nil {
-		util.ErrorResponse(w, http.StatusUnauthorized, err)
+		if errors.Is(err, domain.ErrInvalidRefreshToken) || errors.Is(err, domain.ErrRefreshTokenReused) {
+			http.SetCookie(w, util.GetExpiredRefreshCookie(h.config))
+			util.ErrorResponse(w, http.StatusUnauthorized, err)
+			return
+		}
+		util.ErrorResponse(w, http.StatusInternalServerError, err)
 		return
 	}
-	user, err := h.userService.GetUserByID(r.Context(), userID)
+	if stored.UserID.String() != claims.Subject || stored.FamilyID != claims.Family {
+		util.ErrorResponse(w, http.StatusUnauthorized, domain.ErrInvalidRefreshToken)
+		return
+	}
+
+	user, err := h.userService.GetUserByID(r.Context(), stored.UserID)
 	if err != nil {
 		util.ErrorResponse(w, http.StatusInternalServerError, err)
 		return
 	}
-	tokens, err := util.GenerateTokenPair(h.config, &util.JWTUser{
-		ID:        user.ID,
-		FirstName: user.FirstName,
-		LastName:  user.LastName,
-		Email:     user.Email,
-		Role:      user.Role,
-	})
+	// Resetting the password bumps the version, revoking older tokens
+	if claims.Version != user.SessionVersion {
+		util.ErrorResponse(w, http.StatusUnauthorized, errors.New("session expired"))
+		return
+	}
+	if !user.Verified() && domain.VerificationPolicy(h.config.VerificationPolicy) == domain.VerificationPolicyLogin {
+		util.ErrorResponse(w, http.StatusForbidden, domain.ErrEmailNotVerified)
+		return
+	}
+	h.issueTokens(w, r, user, stored.FamilyID)
+}
+
+// issueTokens sends the user a new token pair and records the refresh token
+// in the family. Organization access and team membership are read each
+// time so that changes take effect on refresh.
+func (h *Handler) issueTokens(w http.ResponseWriter, r *http.Request, user *domain.User, familyID uuid.UUID) {
+	orgIDs, err := h.userService.OrgIDs(r.Context(), user)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+	teamIDs, err := h.userService.TeamIDs(r.Context(), user)
 	if err != nil {
 		util.ErrorResponse(w, http.StatusInternalServerError, err)
 		return
 	}
+	jwtUser := util.JWTUser{
+		ID:             user.ID,
+		FirstName:      user.FirstName,
+		LastName:       user.LastName,
+		Email:          user.Email,
+		Role:           user.Role,
+		OrgID:          user.OrgID,
+		OrgIDs:         orgIDs,
+		TeamIDs:        teamIDs,
+		EmailVerified:  user.Verified(),
+		SessionVersion: user.SessionVersion,
+		FamilyID:       familyID,
+	}
+
+	tokens, err := util.GenerateTokenPair(h.config, &jwtUser)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+	if err := h.sessionService.Record(r.Context(), user.ID, familyID, tokens.RefreshToken); err != nil {
+		util.ErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
 
 	http.SetCookie(w, util.GetRefreshCookie(h.config, tokens.RefreshToken))
 	util.WriteResponse(w, http.StatusOK, struct {
@@ -207,13 +269,7 @@ func (h *Handler) RefreshToken(w http.ResponseWriter, r *http.Request) {
 		User        util.JWTUser `json:"user"`
 	}{
 		AccessToken: tokens.Token,
-		User: util.JWTUser{
-			ID:        user.ID,
-			FirstName: user.FirstName,
-			LastName:  user.LastName,
-			Email:     user.Email,
-			Role:      user.Role,
-		},
+		User:        jwtUser,
 	})
 }
 
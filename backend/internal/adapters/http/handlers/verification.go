@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"html/template"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/nickhildpac/ticket-management-app/internal/application/authorization"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+	"github.com/nickhildpac/ticket-management-app/pkg/util"
+)
+
+type VerifyEmailPayload struct {
+	Token string `json:"token"`
+}
+
+func verificationError(w http.ResponseWriter, err error) {
+	util.ErrorResponse(w, verificationStatus(err), err)
+}
+
+func verificationStatus(err error) int {
+	switch {
+	case err == authorization.ErrAccessDenied:
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrInvalidUserToken):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrEmailAlreadyVerified):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// verifyEmailPage is what someone following a verification link sees: a
+// button that confirms the address, or the outcome of pressing it
+var verifyEmailPage = template.Must(template.New("verify").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head><meta charset="utf-8"><title>Confirm your email address</title></head>
+<body>
+{{- if .Error}}
+<p>{{.Error}}</p>
+{{- else if .User}}
+<p>Thanks, {{.User.Email}} is confirmed.</p>
+{{- else}}
+<h1>Confirm your email address</h1>
+<form method="post">
+<input type="hidden" name="token" value="{{.Token}}">
+<button type="submit">Confirm</button>
+</form>
+{{- end}}
+</body>
+</html>
+`))
+
+type verifyEmailPageData struct {
+	Token string
+	User  *domain.User
+	Error string
+}
+
+// ShowVerifyEmail shows the page for the link mailed at signup. Following
+// the link doesn't use up its token, so mail scanners that open links can't
+// verify the address or spend the token before the user gets there.
+func (h *Handler) ShowVerifyEmail(w http.ResponseWriter, r *http.Request) {
+	writeVerifyEmailPage(w, http.StatusOK, verifyEmailPageData{Token: r.URL.Query().Get("token")})
+}
+
+// VerifyEmail verifies the address the token was mailed to. It takes the
+// form from ShowVerifyEmail, answering with a page, or a JSON body,
+// answering with JSON.
+func (h *Handler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	var payload VerifyEmailPayload
+	form := strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded")
+	if form {
+		if err := r.ParseForm(); err != nil {
+			writeVerifyEmailPage(w, http.StatusBadRequest, verifyEmailPageData{Error: err.Error()})
+			return
+		}
+		payload.Token = r.PostForm.Get("token")
+	} else if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	user, err := h.verificationService.VerifyEmail(r.Context(), payload.Token)
+	if form {
+		data := verifyEmailPageData{User: user}
+		status := http.StatusOK
+		if err != nil {
+			data.Error = err.Error()
+			status = verificationStatus(err)
+		}
+		writeVerifyEmailPage(w, status, data)
+		return
+	}
+	if err != nil {
+		verificationError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, map[string]any{
+		"message":           "email address verified",
+		"email":             user.Email,
+		"email_verified_at": user.EmailVerifiedAt,
+	})
+}
+
+func writeVerifyEmailPage(w http.ResponseWriter, status int, data verifyEmailPageData) {
+	if status == http.StatusInternalServerError {
+		data.Error = "something went wrong; please try again later"
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	if err := verifyEmailPage.Execute(w, data); err != nil {
+		log.Println("Error writing verification page:", err)
+	}
+}
+
+func (h *Handler) ResendVerification(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.verificationService.ResendVerification(r.Context(), userID); err != nil {
+		verificationError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusAccepted, map[string]string{"message": "verification email sent"})
+}
@@ -0,0 +1,213 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	httpadapter "github.com/nickhildpac/ticket-management-app/internal/adapters/http"
+	"github.com/nickhildpac/ticket-management-app/internal/adapters/http/handlers"
+	"github.com/nickhildpac/ticket-management-app/internal/application/service"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+	"github.com/nickhildpac/ticket-management-app/internal/ports"
+	"github.com/nickhildpac/ticket-management-app/pkg/configs"
+	"github.com/nickhildpac/ticket-management-app/pkg/util"
+)
+
+// fakeTransactor runs everything straight away, with no transaction
+type fakeTransactor struct{}
+
+func (fakeTransactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (fakeTransactor) AfterCommit(ctx context.Context, fn func(ctx context.Context)) {
+	fn(ctx)
+}
+
+// fakeUserTokenRepo keeps tokens by hash
+type fakeUserTokenRepo struct {
+	ports.UserTokenRepository
+	tokens map[string]domain.UserToken
+}
+
+func (r *fakeUserTokenRepo) Consume(_ context.Context, tokenHash string, purpose domain.TokenPurpose) (*domain.UserToken, error) {
+	t, ok := r.tokens[tokenHash]
+	if !ok || t.Purpose != purpose || t.UsedAt != nil {
+		return nil, domain.ErrInvalidUserToken
+	}
+	now := time.Now()
+	t.UsedAt = &now
+	r.tokens[tokenHash] = t
+	return &t, nil
+}
+
+type fakeUserRepo struct {
+	ports.UserRepository
+	users map[uuid.UUID]domain.User
+}
+
+func (r *fakeUserRepo) GetUserByID(_ context.Context, id uuid.UUID) (*domain.User, error) {
+	u, ok := r.users[id]
+	if !ok {
+		return nil, domain.ErrUserNotFound
+	}
+	return &u, nil
+}
+
+func (r *fakeUserRepo) VerifyEmail(_ context.Context, id uuid.UUID) (*domain.User, error) {
+	u := r.users[id]
+	now := time.Now()
+	u.EmailVerifiedAt = &now
+	r.users[id] = u
+	return &u, nil
+}
+
+func (r *fakeUserRepo) GetAllUsers(_ context.Context) ([]domain.User, error) {
+	out := make([]domain.User, 0, len(r.users))
+	for _, u := range r.users {
+		out = append(out, u)
+	}
+	return out, nil
+}
+
+// fakeOrgRepo finds organizations by their email domain
+type fakeOrgRepo struct {
+	ports.OrganizationRepository
+	orgs  []domain.Organization
+	users *fakeUserRepo
+}
+
+func (r *fakeOrgRepo) GetByDomain(_ context.Context, domainName string) (*domain.Organization, error) {
+	for _, org := range r.orgs {
+		if org.EmailDomain == domainName {
+			return &org, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeOrgRepo) MoveUser(_ context.Context, userID, orgID uuid.UUID) (*domain.User, error) {
+	u := r.users.users[userID]
+	u.OrgID = orgID
+	r.users.users[userID] = u
+	return &u, nil
+}
+
+func TestVerifyEmail(t *testing.T) {
+	conf := &configs.Config{}
+
+	user := domain.User{ID: uuid.New(), Email: "ada@example.com", OrgID: domain.DefaultOrganizationID}
+	org := domain.Organization{ID: uuid.New(), EmailDomain: "example.com"}
+	users := &fakeUserRepo{users: map[uuid.UUID]domain.User{user.ID: user}}
+	token := "mailed-token"
+	tokens := &fakeUserTokenRepo{tokens: map[string]domain.UserToken{
+		util.HashSecretToken(token): {ID: uuid.New(), UserID: user.ID, Purpose: domain.TokenEmailVerification, ExpiresAt: time.Now().Add(time.Hour)},
+	}}
+	orgs := &fakeOrgRepo{orgs: []domain.Organization{org}, users: users}
+	verification := service.NewVerificationService(users, tokens, orgs, nil, fakeTransactor{}, conf)
+	h := handlers.NewHandler(conf, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, verification, nil)
+	router := httpadapter.Router(conf, h)
+
+	t.Run("Following the link uses nothing up", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/email/verify?token="+url.QueryEscape(token), nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET /email/verify = %d; want %d (%s)", rec.Code, http.StatusOK, rec.Body)
+		}
+		if used := tokens.tokens[util.HashSecretToken(token)].UsedAt; used != nil {
+			t.Errorf("GET /email/verify used the token at %v", used)
+		}
+		if users.users[user.ID].Verified() {
+			t.Error("GET /email/verify verified the address")
+		}
+		if got := users.users[user.ID].OrgID; got != domain.DefaultOrganizationID {
+			t.Errorf("GET /email/verify moved the user to organization %s", got)
+		}
+		if body := rec.Body.String(); !strings.Contains(body, `name="token" value="mailed-token"`) {
+			t.Errorf("GET /email/verify = %s; want the form with the token", body)
+		}
+	})
+
+	tests := []struct {
+		name     string
+		expected int
+	}{
+		{"Confirming verifies the address", http.StatusOK},
+		{"Confirming again", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form := url.Values{"token": {token}}
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/email/verify", strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expected {
+				t.Fatalf("POST /email/verify = %d; want %d (%s)", rec.Code, tt.expected, rec.Body)
+			}
+			if !users.users[user.ID].Verified() {
+				t.Error("POST /email/verify left the address unverified")
+			}
+			if got := users.users[user.ID].OrgID; got != org.ID {
+				t.Errorf("user is in organization %s; want %s, which claims their domain", got, org.ID)
+			}
+		})
+	}
+}
+
+func TestUnverifiedUserLists(t *testing.T) {
+	conf := &configs.Config{
+		JWTSecret:   "secret",
+		JWTIssuer:   "example.com",
+		JWTAudience: "example.com",
+		TokenExpiry: time.Minute,
+	}
+
+	org := uuid.New()
+	users := &fakeUserRepo{users: map[uuid.UUID]domain.User{uuid.New(): {Email: "grace@example.com", OrgID: org}}}
+	h := handlers.NewHandler(conf, service.NewUserService(users, nil, nil, conf), nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	router := httpadapter.Router(conf, h)
+
+	tests := []struct {
+		name     string
+		path     string
+		verified bool
+		expected int
+	}{
+		{"Verified user lists users", "/api/v1/users", true, http.StatusOK},
+		{"Unverified user lists users", "/api/v1/users", false, http.StatusForbidden},
+		{"Unverified user searches users", "/api/v1/users/search?q=gr", false, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, err := util.GenerateTokenPair(conf, &util.JWTUser{
+				ID:            uuid.New(),
+				Role:          domain.RoleUser,
+				OrgID:         org,
+				OrgIDs:        []uuid.UUID{org},
+				EmailVerified: tt.verified,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set("Authorization", "Bearer "+tokens.Token)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expected {
+				t.Fatalf("GET %s = %d; want %d (%s)", tt.path, rec.Code, tt.expected, rec.Body)
+			}
+		})
+	}
+}
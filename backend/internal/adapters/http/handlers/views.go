@@ -0,0 +1,262 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/nickhildpac/ticket-management-app/internal/application/authorization"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+	"github.com/nickhildpac/ticket-management-app/pkg/util"
+)
+
+// SavedViewPayload is used for both create and update; an update replaces
+// the whole view. States and priorities use their names, e.g. "open".
+type SavedViewPayload struct {
+	Name         string     `json:"name"`
+	States       []string   `json:"states"`
+	Priorities   []string   `json:"priorities"`
+	AssigneeID   *uuid.UUID `json:"assignee_id"`
+	AssignedToMe bool       `json:"assigned_to_me"`
+	Unassigned   bool       `json:"unassigned"`
+	CreatedBy    *uuid.UUID `json:"created_by"`
+	CreatedByMe  bool       `json:"created_by_me"`
+	Search       string     `json:"search"`
+	Sort         string     `json:"sort"`
+	Columns      []string   `json:"columns"`
+	SharedRole   *string    `json:"shared_role"`
+	SharedTeamID *uuid.UUID `json:"shared_team_id"`
+}
+
+type SavedViewResponse struct {
+	SavedViewPayload
+	ID        uuid.UUID `json:"id"`
+	OwnerID   uuid.UUID `json:"owner_id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (p SavedViewPayload) view() (domain.SavedView, error) {
+	view := domain.SavedView{
+		Name: p.Name,
+		Filter: domain.TicketFilter{
+			AssigneeID:   p.AssigneeID,
+			AssignedToMe: p.AssignedToMe,
+			Unassigned:   p.Unassigned,
+			CreatedBy:    p.CreatedBy,
+			CreatedByMe:  p.CreatedByMe,
+			Search:       p.Search,
+			Sort:         domain.TicketSort(p.Sort),
+		},
+		Columns:      p.Columns,
+		SharedTeamID: p.SharedTeamID,
+	}
+	for _, s := range p.States {
+		state, err := domain.GetTicketState(s)
+		if err != nil {
+			return view, err
+		}
+		view.Filter.States = append(view.Filter.States, state)
+	}
+	for _, s := range p.Priorities {
+		priority := domain.GetTicketPriority(s)
+		if priority == -1 {
+			return view, domain.ErrInvalidPriority
+		}
+		view.Filter.Priorities = append(view.Filter.Priorities, priority)
+	}
+	if p.SharedRole != nil {
+		role, err := domain.GetRole(*p.SharedRole)
+		if err != nil {
+			return view, err
+		}
+		view.SharedRole = &role
+	}
+	return view, nil
+}
+
+func savedViewResponse(v domain.SavedView) SavedViewResponse {
+	resp := SavedViewResponse{
+		SavedViewPayload: SavedViewPayload{
+			Name:         v.Name,
+			States:       make([]string, len(v.Filter.States)),
+			Priorities:   make([]string, len(v.Filter.Priorities)),
+			AssigneeID:   v.Filter.AssigneeID,
+			AssignedToMe: v.Filter.AssignedToMe,
+			Unassigned:   v.Filter.Unassigned,
+			CreatedBy:    v.Filter.CreatedBy,
+			CreatedByMe:  v.Filter.CreatedByMe,
+			Search:       v.Filter.Search,
+			Sort:         string(v.Filter.Sort),
+			Columns:      v.Columns,
+			SharedTeamID: v.SharedTeamID,
+		},
+		ID:        v.ID,
+		OwnerID:   v.OwnerID,
+		CreatedAt: v.CreatedAt,
+		UpdatedAt: v.UpdatedAt,
+	}
+	for i, s := range v.Filter.States {
+		resp.States[i] = s.String()
+	}
+	for i, p := range v.Filter.Priorities {
+		resp.Priorities[i] = p.String()
+	}
+	if v.SharedRole != nil {
+		role := string(*v.SharedRole)
+		resp.SharedRole = &role
+	}
+	return resp
+}
+
+func viewError(w http.ResponseWriter, err error) {
+	if err == authorization.ErrAccessDenied {
+		util.ErrorResponse(w, http.StatusForbidden, err)
+		return
+	}
+	if errors.Is(err, domain.ErrEmptyViewName) ||
+		errors.Is(err, domain.ErrInvalidTicketSort) ||
+		errors.Is(err, domain.ErrInvalidViewColumn) ||
+		errors.Is(err, domain.ErrViewTeamOrgMismatch) {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+	util.ErrorResponse(w, http.StatusInternalServerError, err)
+}
+
+func (h *Handler) GetViews(w http.ResponseWriter, r *http.Request) {
+	views, err := h.viewService.ListViews(r.Context())
+	if err != nil {
+		viewError(w, err)
+		return
+	}
+
+	resp := make([]SavedViewResponse, len(views))
+	for i, v := range views {
+		resp[i] = savedViewResponse(v)
+	}
+	util.WriteResponse(w, http.StatusOK, resp)
+}
+
+func (h *Handler) GetView(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	view, err := h.viewService.GetView(r.Context(), id)
+	if err != nil {
+		viewError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, savedViewResponse(*view))
+}
+
+func (h *Handler) CreateView(w http.ResponseWriter, r *http.Request) {
+	var payload SavedViewPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	view, err := payload.view()
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	created, err := h.viewService.CreateView(r.Context(), view)
+	if err != nil {
+		viewError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusCreated, savedViewResponse(*created))
+}
+
+func (h *Handler) UpdateView(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var payload SavedViewPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	view, err := payload.view()
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+	view.ID = id
+
+	updated, err := h.viewService.UpdateView(r.Context(), view)
+	if err != nil {
+		viewError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, savedViewResponse(*updated))
+}
+
+func (h *Handler) DeleteView(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.viewService.DeleteView(r.Context(), id); err != nil {
+		viewError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, map[string]string{"message": "view deleted"})
+}
+
+// GetViewTickets evaluates a view for the caller. Pagination uses the
+// optional limit and offset query parameters.
+func (h *Handler) GetViewTickets(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	limit, offset, err := pagination(r)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	tickets, err := h.viewService.ListTickets(r.Context(), id, limit, offset)
+	if err != nil {
+		viewError(w, err)
+		return
+	}
+	util.WriteResponse(w, http.StatusOK, tickets)
+}
+
+// pagination reads limit and offset from the query, defaulting to the first
+// 20 results
+func pagination(r *http.Request) (int32, int32, error) {
+	limit, offset := int64(20), int64(0)
+	var err error
+	if s := r.URL.Query().Get("limit"); s != "" {
+		if limit, err = strconv.ParseInt(s, 10, 32); err != nil || limit < 1 || limit > 100 {
+			return 0, 0, errors.New("limit must be between 1 and 100")
+		}
+	}
+	if s := r.URL.Query().Get("offset"); s != "" {
+		if offset, err = strconv.ParseInt(s, 10, 32); err != nil || offset < 0 {
+			return 0, 0, errors.New("offset must be a non-negative integer")
+		}
+	}
+	return int32(limit), int32(offset), nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/google/uuid"
 	"github.com/nickhildpac/ticket-management-app/pkg/configs"
 	"github.com/nickhildpac/ticket-management-app/pkg/util"
 )
@@ -30,9 +31,7 @@ func AuthRequired(conf *configs.Config) func(http.Handler) http.Handler {
 				w.WriteHeader(http.StatusUnauthorized)
 				return
 			}
-			ctx := context.WithValue(r.Context(), configs.UserIDKey, claims.Subject)
-			ctx = context.WithValue(ctx, configs.UserRoleKey, claims.Role)
-			next.ServeHTTP(w, r.WithContext(ctx))
+			next.ServeHTTP(w, r.WithContext(withClaims(r.Context(), claims)))
 		})
 	}
 }
@@ -49,9 +48,23 @@ func AdminRequired(conf *configs.Config) func(http.Handler) http.Handler {
 				w.WriteHeader(http.StatusForbidden)
 				return
 			}
-			ctx := context.WithValue(r.Context(), configs.UserIDKey, claims.Subject)
-			ctx = context.WithValue(ctx, configs.UserRoleKey, claims.Role)
-			next.ServeHTTP(w, r.WithContext(ctx))
+			next.ServeHTTP(w, r.WithContext(withClaims(r.Context(), claims)))
 		})
 	}
 }
+
+// withClaims stores the caller's identity in the context. The organization
+// list is never nil, so tokens issued before organizations existed see
+// nothing rather than everything.
+func withClaims(ctx context.Context, claims *util.Claims) context.Context {
+	orgs := claims.Orgs
+	if orgs == nil {
+		orgs = []uuid.UUID{}
+	}
+	ctx = context.WithValue(ctx, configs.UserIDKey, claims.Subject)
+	ctx = context.WithValue(ctx, configs.UserRoleKey, claims.Role)
+	ctx = context.WithValue(ctx, configs.UserOrgKey, claims.Org)
+	ctx = context.WithValue(ctx, configs.UserTeamsKey, claims.Teams)
+	ctx = context.WithValue(ctx, configs.UserUnverifiedKey, claims.Unverified)
+	return context.WithValue(ctx, configs.UserOrgsKey, orgs)
+}
@@ -26,31 +26,176 @@ func Router(conf *configs.Config, h *handlers.Handler) http.Handler {
 		r.Get("/logout", h.Logout)
 		r.Post("/user", h.CreateUser)
 		r.Get("/refresh", h.RefreshToken)
+		r.Post("/password/forgot", h.ForgotPassword)
+		r.Post("/password/reset", h.ResetPassword)
+		r.Get("/email/verify", h.ShowVerifyEmail)
+		r.Post("/email/verify", h.VerifyEmail)
+
+		// Survey links (authenticated by the signed token in the URL)
+		r.Get("/csat/{token}", h.GetSurvey)
+		r.Post("/csat/{token}", h.RateTicket)
 
 		// Ticket routes (authenticated)
 		r.Route("/ticket", func(mux chi.Router) {
 			mux.Use(middlewares.AuthRequired(conf))
 			mux.Get("/all", h.GetTickets)
 			mux.Get("/assigned", h.GetAssignedTickets)
+			mux.Get("/team", h.GetTeamTickets)
 			mux.Post("/", h.CreateTicket)
+			mux.Post("/suggest", h.SuggestDuplicates)
 			mux.Get("/{id}", h.GetTicket)
 			mux.Patch("/{id}", h.UpdateTicket)
 			mux.Delete("/{id}", h.DeleteTicket)
 			mux.Get("/{id}/comments", h.GetComments)
+			mux.Get("/{id}/events", h.GetTicketEvents)
+			mux.Get("/{id}/approvals", h.GetTicketApprovals)
+			mux.Post("/{id}/approve", h.ApproveTicket)
+			mux.Post("/{id}/reject", h.RejectTicket)
+			mux.Get("/{id}/transfers", h.GetTicketTransfers)
+			mux.Post("/{id}/transfers", h.TransferTicket)
+			mux.Get("/{id}/checklist", h.GetChecklist)
+			mux.Post("/{id}/checklist", h.CreateChecklistItem)
+			mux.Put("/{id}/checklist/order", h.ReorderChecklist)
+			mux.Patch("/{id}/checklist/{itemID}", h.UpdateChecklistItem)
+			mux.Delete("/{id}/checklist/{itemID}", h.DeleteChecklistItem)
+		})
+
+		// Approvals waiting on the caller (authenticated)
+		r.With(middlewares.AuthRequired(conf)).Get("/approvals/pending", h.GetPendingApprovals)
+
+		// Ticket handoffs (authenticated; checked per transfer)
+		r.Route("/transfers", func(mux chi.Router) {
+			mux.Use(middlewares.AuthRequired(conf))
+			mux.Get("/incoming", h.GetIncomingTransfers)
+			mux.Post("/{id}/accept", h.AcceptTransfer)
+			mux.Post("/{id}/decline", h.DeclineTransfer)
+			mux.Post("/{id}/cancel", h.CancelTransfer)
+		})
+
+		// Kanban board (authenticated)
+		r.Route("/board", func(mux chi.Router) {
+			mux.Use(middlewares.AuthRequired(conf))
+			mux.Get("/", h.GetBoard)
+			mux.Post("/move", h.MoveTicket)
+		})
+
+		// Teams (authenticated; changes are checked per team)
+		r.Route("/teams", func(mux chi.Router) {
+			mux.Use(middlewares.AuthRequired(conf))
+			mux.Get("/", h.GetTeams)
+			mux.Post("/", h.CreateTeam)
+			mux.Get("/{id}", h.GetTeam)
+			mux.Put("/{id}", h.UpdateTeam)
+			mux.Delete("/{id}", h.DeleteTeam)
+			mux.Put("/{id}/members/{userID}", h.AddTeamMember)
+			mux.Delete("/{id}/members/{userID}", h.RemoveTeamMember)
+			mux.Put("/{id}/calendar", h.SetTeamCalendar)
+		})
+
+		// Business calendars (authenticated; changes are admin-only)
+		r.Route("/calendars", func(mux chi.Router) {
+			mux.Use(middlewares.AuthRequired(conf))
+			mux.Get("/", h.GetCalendars)
+			mux.Post("/", h.CreateCalendar)
+			mux.Get("/{id}", h.GetCalendar)
+			mux.Put("/{id}", h.UpdateCalendar)
+			mux.Delete("/{id}", h.DeleteCalendar)
+		})
+
+		// Saved views (authenticated)
+		r.Route("/views", func(mux chi.Router) {
+			mux.Use(middlewares.AuthRequired(conf))
+			mux.Get("/", h.GetViews)
+			mux.Post("/", h.CreateView)
+			mux.Get("/{id}", h.GetView)
+			mux.Put("/{id}", h.UpdateView)
+			mux.Delete("/{id}", h.DeleteView)
+			mux.Get("/{id}/tickets", h.GetViewTickets)
+		})
+
+		// Canned replies and macros (authenticated; agents and admins)
+		r.Route("/macros", func(mux chi.Router) {
+			mux.Use(middlewares.AuthRequired(conf))
+			mux.Get("/", h.GetMacros)
+			mux.Post("/", h.CreateMacro)
+			mux.Get("/{id}", h.GetMacro)
+			mux.Put("/{id}", h.UpdateMacro)
+			mux.Delete("/{id}", h.DeleteMacro)
+			mux.Get("/{id}/render", h.RenderMacro)
+			mux.Post("/{id}/apply", h.ApplyMacro)
 		})
 
 		// Comment routes (authenticated)
-		r.With(middlewares.AuthRequired(conf)).Post("/comment", h.CreateComment)
-		r.Get("/comment/{id}", h.GetComment)
+		r.Route("/comment", func(mux chi.Router) {
+			mux.Use(middlewares.AuthRequired(conf))
+			mux.Post("/", h.CreateComment)
+			mux.Get("/{id}", h.GetComment)
+			mux.Put("/{id}", h.UpdateComment)
+			mux.Delete("/{id}", h.DeleteComment)
+			mux.Get("/{id}/revisions", h.GetCommentRevisions)
+			mux.Get("/{id}/replies", h.GetCommentReplies)
+			mux.Put("/{id}/reactions/{emoji}", h.ReactToComment)
+			mux.Delete("/{id}/reactions/{emoji}", h.UnreactToComment)
+		})
 
 		// User routes (authenticated) - for getting user list for assignments
 		r.With(middlewares.AuthRequired(conf)).Get("/users", h.GetBasicUsers)
+		r.With(middlewares.AuthRequired(conf)).Get("/users/search", h.SearchUsers)
+
+		// Mentions of the caller (authenticated)
+		r.With(middlewares.AuthRequired(conf)).Get("/mentions", h.GetMentions)
+
+		// Agent availability and out of office (authenticated)
+		r.Route("/users/{id}", func(mux chi.Router) {
+			mux.Use(middlewares.AuthRequired(conf))
+			mux.Get("/availability", h.GetAvailability)
+			mux.Put("/availability", h.UpdateAvailability)
+			mux.Get("/out-of-office", h.GetOutOfOffice)
+			mux.Post("/out-of-office", h.CreateOutOfOffice)
+			mux.Delete("/out-of-office/{oooID}", h.DeleteOutOfOffice)
+		})
 
 		// Admin-only user management routes
 		r.Route("/admin/users", func(mux chi.Router) {
 			mux.Use(middlewares.AdminRequired(conf))
 			mux.Put("/{id}/role", h.UpdateUserRole)
 			mux.Delete("/{id}", h.DeleteUser)
+			mux.Put("/{id}/org", h.MoveUserToOrganization)
+			mux.Post("/{id}/verification", h.ResendVerification)
+			mux.Put("/{id}/orgs/{orgID}", h.GrantOrgAccess)
+			mux.Delete("/{id}/orgs/{orgID}", h.RevokeOrgAccess)
+		})
+
+		// Admin-only organization management
+		r.Route("/admin/organizations", func(mux chi.Router) {
+			mux.Use(middlewares.AdminRequired(conf))
+			mux.Get("/", h.GetOrganizations)
+			mux.Post("/", h.CreateOrganization)
+			mux.Put("/{id}/calendar", h.SetOrganizationCalendar)
+		})
+
+		// Admin-only CSAT reports
+		r.Route("/admin/csat", func(mux chi.Router) {
+			mux.Use(middlewares.AdminRequired(conf))
+			mux.Get("/summary", h.GetCSATSummary)
+			mux.Get("/assignees", h.GetCSATByAssignee)
+		})
+
+		r.With(middlewares.AdminRequired(conf)).Get("/admin/reports/resolutions", h.GetResolutionReport)
+
+		// Admin-only priority matrix configuration
+		r.Route("/admin/priority-matrix", func(mux chi.Router) {
+			mux.Use(middlewares.AdminRequired(conf))
+			mux.Get("/", h.GetPriorityMatrix)
+			mux.Put("/", h.UpdatePriorityMatrix)
+		})
+
+		// Admin-only approval step configuration, per ticket type
+		r.Route("/admin/approval-steps", func(mux chi.Router) {
+			mux.Use(middlewares.AdminRequired(conf))
+			mux.Get("/", h.GetApprovalStepTypes)
+			mux.Get("/{type}", h.GetApprovalSteps)
+			mux.Put("/{type}", h.UpdateApprovalSteps)
 		})
 
 		// Legacy admin endpoint (can be deprecated)
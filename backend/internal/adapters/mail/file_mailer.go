@@ -0,0 +1,47 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// FileMailer writes each outgoing message to its own .eml file in a
+// directory, for development and tests that need to read what was sent
+type FileMailer struct {
+	from string
+	dir  string
+	seq  atomic.Uint64
+}
+
+func NewFileMailer(from, dir string) (*FileMailer, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create mail directory: %w", err)
+	}
+	return &FileMailer{from: from, dir: dir}, nil
+}
+
+func (m *FileMailer) Send(ctx context.Context, to, subject, body string) error {
+	name := fmt.Sprintf("%s-%04d.eml", time.Now().UTC().Format("20060102T150405.000000000"), m.seq.Add(1))
+	return os.WriteFile(filepath.Join(m.dir, name), message(m.from, to, subject, body), 0o600)
+}
+
+// message formats a plain-text message with the headers mail clients expect
+func message(from, to, subject, body string) []byte {
+	// Header values must not break out onto lines of their own
+	clean := strings.NewReplacer("\r", "", "\n", " ")
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", clean.Replace(from))
+	fmt.Fprintf(&b, "To: %s\r\n", clean.Replace(to))
+	fmt.Fprintf(&b, "Subject: %s\r\n", clean.Replace(subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(body, "\r\n", "\n"), "\n", "\r\n"))
+	return []byte(b.String())
+}
@@ -0,0 +1,21 @@
+// Package mail contains Mailer implementations
+package mail
+
+import (
+	"context"
+	"log"
+)
+
+// LogMailer writes outgoing mail to the server log instead of sending it
+type LogMailer struct {
+	from string
+}
+
+func NewLogMailer(from string) *LogMailer {
+	return &LogMailer{from: from}
+}
+
+func (m *LogMailer) Send(ctx context.Context, to, subject, body string) error {
+	log.Printf("mail from=%s to=%s subject=%q\n%s", m.from, to, subject, body)
+	return nil
+}
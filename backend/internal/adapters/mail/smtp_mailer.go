@@ -0,0 +1,36 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+)
+
+// SMTPMailer sends mail through an SMTP server. The connection is upgraded
+// with STARTTLS when the server offers it; credentials are only sent over
+// TLS or to localhost.
+type SMTPMailer struct {
+	from string
+	addr string
+	auth smtp.Auth
+}
+
+func NewSMTPMailer(from, host string, port int, username, password string) *SMTPMailer {
+	m := &SMTPMailer{from: from, addr: net.JoinHostPort(host, strconv.Itoa(port))}
+	if username != "" {
+		m.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return m
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, to, subject, body string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := smtp.SendMail(m.addr, m.auth, m.from, []string{to}, message(m.from, to, subject, body)); err != nil {
+		return fmt.Errorf("failed to send mail to %s: %w", to, err)
+	}
+	return nil
+}
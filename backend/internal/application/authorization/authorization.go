@@ -15,9 +15,22 @@ var (
 	ErrNotAssignee    = errors.New("access denied: not assigned to ticket")
 )
 
+// AuthContext describes the caller. OrgID is their own organization and
+// OrgIDs every organization they may access, including OrgID. TeamIDs are
+// the teams they are a member of. Unverified is set until they verify their
+// email address.
+//
+// All of it comes from the access token, so it is as old as the token: a
+// member removed from a team keeps its queue until the token expires and is
+// refreshed. Checks that grant more than the token's lifetime, such as
+// claiming a ticket, look the team up again.
 type AuthContext struct {
-	UserID uuid.UUID
-	Role   domain.UserRole
+	UserID     uuid.UUID
+	Role       domain.UserRole
+	OrgID      uuid.UUID
+	OrgIDs     []uuid.UUID
+	TeamIDs    []uuid.UUID
+	Unverified bool
 }
 
 // GetAuthContext extracts user info from context
@@ -37,19 +50,49 @@ func GetAuthContext(ctx context.Context) (AuthContext, error) {
 		return AuthContext{}, err
 	}
 
+	// Tokens issued before organizations existed carry neither claim and so
+	// get no organization access
+	orgID, _ := uuid.Parse(ctxString(ctx, configs.UserOrgKey))
+	orgIDs, _ := ctx.Value(configs.UserOrgsKey).([]uuid.UUID)
+	teamIDs, _ := ctx.Value(configs.UserTeamsKey).([]uuid.UUID)
+	unverified, _ := ctx.Value(configs.UserUnverifiedKey).(bool)
+
 	return AuthContext{
-		UserID: userID,
-		Role:   domain.UserRole(roleStr),
+		UserID:     userID,
+		Role:       domain.UserRole(roleStr),
+		OrgID:      orgID,
+		OrgIDs:     orgIDs,
+		TeamIDs:    teamIDs,
+		Unverified: unverified,
 	}, nil
 }
 
-// CanViewTicket determines if user can view ticket
+func ctxString(ctx context.Context, key any) string {
+	s, _ := ctx.Value(key).(string)
+	return s
+}
+
+// CanAccessOrg determines if user can see data belonging to the organization.
+// Every ticket, user and view check below starts here.
+func CanAccessOrg(auth AuthContext, orgID uuid.UUID) bool {
+	return orgID != uuid.Nil && isUserInList(orgID, auth.OrgIDs)
+}
+
+// CanViewTicket determines if user can view ticket. Agents see tickets
+// assigned to them and those queued to any of their teams. Readers see the
+// ticket whatever their role.
 func CanViewTicket(auth AuthContext, ticket *domain.Ticket) bool {
+	if !CanAccessOrg(auth, ticket.OrgID) {
+		return false
+	}
+	if isUserInList(auth.UserID, ticket.Readers) {
+		return true
+	}
 	switch auth.Role {
 	case domain.RoleAdmin:
 		return true
 	case domain.RoleAgent:
-		return isUserInList(auth.UserID, ticket.AssignedTo)
+		return isUserInList(auth.UserID, ticket.AssignedTo) || IsTeamMember(auth, ticket.TeamID)
 	case domain.RoleUser:
 		return ticket.CreatedBy == auth.UserID
 	default:
@@ -57,13 +100,18 @@ func CanViewTicket(auth AuthContext, ticket *domain.Ticket) bool {
 	}
 }
 
-// CanUpdateTicket determines if user can update ticket
+// CanUpdateTicket determines if user can update ticket. Agents can update
+// tickets assigned to them or queued to one of their teams; which fields
+// they may change is checked separately.
 func CanUpdateTicket(auth AuthContext, ticket *domain.Ticket) bool {
+	if !CanAccessOrg(auth, ticket.OrgID) {
+		return false
+	}
 	switch auth.Role {
 	case domain.RoleAdmin:
 		return true
 	case domain.RoleAgent:
-		return isUserInList(auth.UserID, ticket.AssignedTo)
+		return isUserInList(auth.UserID, ticket.AssignedTo) || IsTeamMember(auth, ticket.TeamID)
 	case domain.RoleUser:
 		return ticket.CreatedBy == auth.UserID
 	default:
@@ -71,8 +119,18 @@ func CanUpdateTicket(auth AuthContext, ticket *domain.Ticket) bool {
 	}
 }
 
+// CanManageChecklist determines if user can add, remove, reorder or
+// (un)require the ticket's checklist items. The ticket creator can tick
+// items and reword them but not change which ones block resolving it.
+func CanManageChecklist(auth AuthContext, ticket *domain.Ticket) bool {
+	return (auth.Role == domain.RoleAdmin || auth.Role == domain.RoleAgent) && CanUpdateTicket(auth, ticket)
+}
+
 // CanUpdateTicketState determines if user can change ticket state
 func CanUpdateTicketState(auth AuthContext, ticket *domain.Ticket) bool {
+	if !CanAccessOrg(auth, ticket.OrgID) {
+		return false
+	}
 	switch auth.Role {
 	case domain.RoleAdmin:
 		return true
@@ -85,28 +143,85 @@ func CanUpdateTicketState(auth AuthContext, ticket *domain.Ticket) bool {
 	}
 }
 
+// CanRankTicket determines if user can reorder the ticket on the board
+func CanRankTicket(auth AuthContext, ticket *domain.Ticket) bool {
+	return CanUpdateTicketState(auth, ticket)
+}
+
 // CanUpdateTicketPriority determines if user can change priority
 func CanUpdateTicketPriority(auth AuthContext, ticket *domain.Ticket) bool {
-	return auth.Role == domain.RoleAdmin
+	return auth.Role == domain.RoleAdmin && CanAccessOrg(auth, ticket.OrgID)
+}
+
+// CanUpdateTicketImpact determines if user can change impact or urgency after
+// creation. Reporters set them when opening the ticket; triage refines them.
+func CanUpdateTicketImpact(auth AuthContext, ticket *domain.Ticket) bool {
+	if !CanAccessOrg(auth, ticket.OrgID) {
+		return false
+	}
+	switch auth.Role {
+	case domain.RoleAdmin:
+		return true
+	case domain.RoleAgent:
+		return isUserInList(auth.UserID, ticket.AssignedTo)
+	default:
+		return false
+	}
+}
+
+// CanTransferTicket determines if user can hand the ticket off. Only its
+// current assignees can.
+func CanTransferTicket(auth AuthContext, ticket *domain.Ticket) bool {
+	return CanAccessOrg(auth, ticket.OrgID) && isUserInList(auth.UserID, ticket.AssignedTo)
+}
+
+// CanDecideTransfer determines if user can accept or decline the transfer
+func CanDecideTransfer(auth AuthContext, ticket *domain.Ticket, transfer *domain.Transfer) bool {
+	return CanAccessOrg(auth, ticket.OrgID) && transfer.IsReceiver(auth.UserID, auth.TeamIDs)
+}
+
+// CanCancelTransfer determines if user can withdraw the transfer. The
+// sender can, as can admins.
+func CanCancelTransfer(auth AuthContext, ticket *domain.Ticket, transfer *domain.Transfer) bool {
+	if !CanAccessOrg(auth, ticket.OrgID) {
+		return false
+	}
+	return auth.Role == domain.RoleAdmin || transfer.FromUserID == auth.UserID
+}
+
+// CanManagePriorityMatrix determines if user can configure how priority is
+// derived. The matrix is shared by every organization.
+func CanManagePriorityMatrix(auth AuthContext) bool {
+	return CanManageOrganizations(auth)
 }
 
 // CanAssignTicket determines if user can assign tickets
 func CanAssignTicket(auth AuthContext, ticket *domain.Ticket) bool {
-	return auth.Role == domain.RoleAdmin
+	return auth.Role == domain.RoleAdmin && CanAccessOrg(auth, ticket.OrgID)
+}
+
+// CanClaimTicket determines if user can add themselves to the ticket's
+// assignees. Agents can claim tickets queued to one of their teams.
+func CanClaimTicket(auth AuthContext, ticket *domain.Ticket) bool {
+	return auth.Role == domain.RoleAgent && CanAccessOrg(auth, ticket.OrgID) && IsTeamMember(auth, ticket.TeamID)
 }
 
 // CanDeleteTicket determines if user can delete ticket
 func CanDeleteTicket(auth AuthContext, ticket *domain.Ticket) bool {
-	return auth.Role == domain.RoleAdmin
+	return auth.Role == domain.RoleAdmin && CanAccessOrg(auth, ticket.OrgID)
 }
 
-// CanCommentOnTicket determines if user can comment on ticket
+// CanCommentOnTicket determines if user can comment on ticket. Agents can
+// comment on tickets assigned to them or queued to one of their teams.
 func CanCommentOnTicket(auth AuthContext, ticket *domain.Ticket) bool {
+	if !CanAccessOrg(auth, ticket.OrgID) {
+		return false
+	}
 	switch auth.Role {
 	case domain.RoleAdmin:
 		return true
 	case domain.RoleAgent:
-		return isUserInList(auth.UserID, ticket.AssignedTo)
+		return isUserInList(auth.UserID, ticket.AssignedTo) || IsTeamMember(auth, ticket.TeamID)
 	case domain.RoleUser:
 		return ticket.CreatedBy == auth.UserID
 	default:
@@ -114,11 +229,215 @@ func CanCommentOnTicket(auth AuthContext, ticket *domain.Ticket) bool {
 	}
 }
 
-// CanManageUsers determines if user can manage users
+// CanViewInternalComments determines if user can see and post internal notes
+func CanViewInternalComments(auth AuthContext) bool {
+	return auth.Role == domain.RoleAdmin || auth.Role == domain.RoleAgent
+}
+
+// CanViewComment determines if user can see a comment on a ticket they can
+// view
+func CanViewComment(auth AuthContext, ticket *domain.Ticket, comment *domain.Comment) bool {
+	if !CanViewTicket(auth, ticket) {
+		return false
+	}
+	return comment.Visibility != domain.CommentInternal || CanViewInternalComments(auth)
+}
+
+// CanEditComment determines if user can change the text of a comment. Only
+// its author can.
+func CanEditComment(auth AuthContext, ticket *domain.Ticket, comment *domain.Comment) bool {
+	return CanAccessOrg(auth, ticket.OrgID) && comment.CreatedBy == auth.UserID
+}
+
+// CanDeleteComment determines if user can delete a comment
+func CanDeleteComment(auth AuthContext, ticket *domain.Ticket) bool {
+	return auth.Role == domain.RoleAdmin && CanAccessOrg(auth, ticket.OrgID)
+}
+
+// CanViewDeletedComment determines if user can read what a deleted comment
+// said
+func CanViewDeletedComment(auth AuthContext, ticket *domain.Ticket) bool {
+	return auth.Role == domain.RoleAdmin && CanAccessOrg(auth, ticket.OrgID)
+}
+
+// IsTeamMember reports whether the user is on the team. A nil team has no
+// members.
+func IsTeamMember(auth AuthContext, teamID *uuid.UUID) bool {
+	return teamID != nil && isUserInList(*teamID, auth.TeamIDs)
+}
+
+// CanListUsers determines if user can list the users of their
+// organizations, such as to assign or mention them
+func CanListUsers(auth AuthContext) bool {
+	return !auth.Unverified
+}
+
+// CanViewTeams determines if user can list teams and see who is on them
+func CanViewTeams(auth AuthContext) bool {
+	return auth.Role == domain.RoleAdmin || auth.Role == domain.RoleAgent
+}
+
+// CanViewTeamQueue determines if user can list the tickets queued to a team
+func CanViewTeamQueue(auth AuthContext, teamID uuid.UUID) bool {
+	return auth.Role == domain.RoleAdmin || IsTeamMember(auth, &teamID)
+}
+
+// CanManageTeams determines if user can create teams
+func CanManageTeams(auth AuthContext) bool {
+	return auth.Role == domain.RoleAdmin
+}
+
+// CanManageTeam determines if user can rename or delete the team and pick
+// its lead
+func CanManageTeam(auth AuthContext, team *domain.Team) bool {
+	return CanManageTeams(auth) && CanAccessOrg(auth, team.OrgID)
+}
+
+// CanManageTeamMembers determines if user can add people to or remove them
+// from the team. Leads manage their own team.
+func CanManageTeamMembers(auth AuthContext, team *domain.Team) bool {
+	if !CanAccessOrg(auth, team.OrgID) {
+		return false
+	}
+	return auth.Role == domain.RoleAdmin || team.IsLead(auth.UserID)
+}
+
+// CanViewAvailability determines if user can see whether agents are
+// available, to decide who to assign
+func CanViewAvailability(auth AuthContext) bool {
+	return auth.Role == domain.RoleAdmin || auth.Role == domain.RoleAgent
+}
+
+// CanManageAvailability determines if user can change the agent's status,
+// capacity and absences. Agents manage their own.
+func CanManageAvailability(auth AuthContext, user *domain.User) bool {
+	if !CanAccessOrg(auth, user.OrgID) {
+		return false
+	}
+	return auth.Role == domain.RoleAdmin || user.ID == auth.UserID
+}
+
+// CanViewCalendars determines if user can see business calendars and which
+// organizations and teams use them
+func CanViewCalendars(auth AuthContext) bool {
+	return auth.Role == domain.RoleAdmin || auth.Role == domain.RoleAgent
+}
+
+// CanManageCalendar determines if user can change or delete the calendar
+func CanManageCalendar(auth AuthContext, calendar *domain.BusinessCalendar) bool {
+	return auth.Role == domain.RoleAdmin && CanAccessOrg(auth, calendar.OrgID)
+}
+
+// CanSetOrganizationCalendar determines if user can choose the calendar an
+// organization's tickets are timed by
+func CanSetOrganizationCalendar(auth AuthContext, orgID uuid.UUID) bool {
+	return auth.Role == domain.RoleAdmin && CanAccessOrg(auth, orgID)
+}
+
+// CanManageUsers determines if user can list and manage users in the
+// organizations they can access
 func CanManageUsers(auth AuthContext) bool {
 	return auth.Role == domain.RoleAdmin
 }
 
+// CanManageUser determines if user can change or delete the given user
+func CanManageUser(auth AuthContext, user *domain.User) bool {
+	return CanManageUsers(auth) && CanAccessOrg(auth, user.OrgID)
+}
+
+// CanManageOrganizations determines if user can create organizations and
+// decide who belongs to or works in them. That is reserved for admins of
+// the default organization, which runs the deployment.
+func CanManageOrganizations(auth AuthContext) bool {
+	return auth.Role == domain.RoleAdmin && auth.OrgID == domain.DefaultOrganizationID
+}
+
+// CanViewReports determines if user can view aggregate reports. Reports only
+// cover the organizations the user can access.
+func CanViewReports(auth AuthContext) bool {
+	return auth.Role == domain.RoleAdmin
+}
+
+// CanManageApprovalSteps determines if user can configure approval steps.
+// Ticket types and their steps are shared by every organization.
+func CanManageApprovalSteps(auth AuthContext) bool {
+	return CanManageOrganizations(auth)
+}
+
+// CanDecideApproval determines if user can approve or reject the step.
+// Creators never approve their own tickets, whatever their role.
+func CanDecideApproval(auth AuthContext, ticket *domain.Ticket, approval *domain.TicketApproval) bool {
+	if !CanAccessOrg(auth, ticket.OrgID) || ticket.CreatedBy == auth.UserID {
+		return false
+	}
+	return approval.IsApprover(auth.UserID, auth.Role)
+}
+
+// CanViewSavedView determines if user can open a saved view. Views shared
+// with a role reach that role in the owner's organization only; those shared
+// with a team reach its members.
+func CanViewSavedView(auth AuthContext, view *domain.SavedView) bool {
+	if !CanAccessOrg(auth, view.OrgID) {
+		return false
+	}
+	if view.OwnerID == auth.UserID || IsTeamMember(auth, view.SharedTeamID) {
+		return true
+	}
+	return view.SharedRole != nil && *view.SharedRole == auth.Role && view.OrgID == auth.OrgID
+}
+
+// CanManageSavedView determines if user can change or delete a saved view
+func CanManageSavedView(auth AuthContext, view *domain.SavedView) bool {
+	if !CanAccessOrg(auth, view.OrgID) {
+		return false
+	}
+	return auth.Role == domain.RoleAdmin || view.OwnerID == auth.UserID
+}
+
+// CanShareViewWithRole determines if user can share a view with a role.
+// Only admins can publish views to roles other than their own.
+func CanShareViewWithRole(auth AuthContext, role domain.UserRole) bool {
+	return auth.Role == domain.RoleAdmin || auth.Role == role
+}
+
+// CanShareViewWithTeam determines if user can share a view with a team.
+// Admins can share with any team, others only with their own.
+func CanShareViewWithTeam(auth AuthContext, teamID uuid.UUID) bool {
+	return auth.Role == domain.RoleAdmin || IsTeamMember(auth, &teamID)
+}
+
+// CanUseMacros determines if user can keep and apply macros
+func CanUseMacros(auth AuthContext) bool {
+	return auth.Role == domain.RoleAdmin || auth.Role == domain.RoleAgent
+}
+
+// CanViewMacro determines if user can see and apply a macro. Shared macros
+// reach everyone who can use macros in their organization.
+func CanViewMacro(auth AuthContext, macro *domain.Macro) bool {
+	if !CanUseMacros(auth) || !CanAccessOrg(auth, macro.OrgID) {
+		return false
+	}
+	if macro.Shared() {
+		return macro.OrgID == auth.OrgID
+	}
+	return *macro.OwnerID == auth.UserID
+}
+
+// CanManageMacro determines if user can change or delete a macro. Only
+// admins manage shared macros.
+func CanManageMacro(auth AuthContext, macro *domain.Macro) bool {
+	if !CanUseMacros(auth) || !CanAccessOrg(auth, macro.OrgID) {
+		return false
+	}
+	return auth.Role == domain.RoleAdmin || (!macro.Shared() && *macro.OwnerID == auth.UserID)
+}
+
+// CanShareMacro determines if user can publish a macro to their
+// organization
+func CanShareMacro(auth AuthContext) bool {
+	return auth.Role == domain.RoleAdmin
+}
+
 // Helper function to check if UUID is in list
 func isUserInList(userID uuid.UUID, list []uuid.UUID) bool {
 	for _, id := range list {
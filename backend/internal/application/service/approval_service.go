@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nickhildpac/ticket-management-app/internal/application/authorization"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+	"github.com/nickhildpac/ticket-management-app/internal/ports"
+)
+
+type ApprovalService struct {
+	repo       ports.ApprovalRepository
+	ticketRepo ports.TicketRepository
+	events     ports.TicketEventRepository
+}
+
+func NewApprovalService(r ports.ApprovalRepository, tr ports.TicketRepository, er ports.TicketEventRepository) *ApprovalService {
+	return &ApprovalService{repo: r, ticketRepo: tr, events: er}
+}
+
+func (s *ApprovalService) ListStepTypes(ctx context.Context) ([]string, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !authorization.CanManageApprovalSteps(auth) {
+		return nil, authorization.ErrAccessDenied
+	}
+
+	return s.repo.ListStepTypes(ctx)
+}
+
+func (s *ApprovalService) ListSteps(ctx context.Context, ticketType string) ([]domain.ApprovalStep, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !authorization.CanManageApprovalSteps(auth) {
+		return nil, authorization.ErrAccessDenied
+	}
+
+	return s.repo.ListSteps(ctx, ticketType)
+}
+
+// UpdateSteps replaces the steps of a ticket type. Tickets that already
+// exist keep the steps they were created with.
+func (s *ApprovalService) UpdateSteps(ctx context.Context, ticketType string, steps []domain.ApprovalStep) ([]domain.ApprovalStep, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !authorization.CanManageApprovalSteps(auth) {
+		return nil, authorization.ErrAccessDenied
+	}
+
+	if err := domain.ValidateTicketType(ticketType); err != nil {
+		return nil, err
+	}
+	for _, step := range steps {
+		if err := step.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repo.ReplaceSteps(ctx, ticketType, steps); err != nil {
+		return nil, err
+	}
+	return s.repo.ListSteps(ctx, ticketType)
+}
+
+// ListForTicket returns the approvals of a ticket to anyone who can see the
+// ticket or is one of its approvers
+func (s *ApprovalService) ListForTicket(ctx context.Context, ticketID uuid.UUID) ([]domain.TicketApproval, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ticket, err := s.ticketRepo.Get(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	approvals, err := s.repo.ListByTicket(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	if authorization.CanViewTicket(auth, ticket) {
+		return approvals, nil
+	}
+	for _, a := range approvals {
+		if a.IsApprover(auth.UserID, auth.Role) {
+			return approvals, nil
+		}
+	}
+	return nil, authorization.ErrAccessDenied
+}
+
+// Decide approves or rejects the step the ticket is currently waiting on.
+// Steps are decided in order, so a later approver has to wait for the
+// earlier ones.
+func (s *ApprovalService) Decide(ctx context.Context, ticketID uuid.UUID, approve bool, comment string) (*domain.TicketApproval, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ticket, err := s.ticketRepo.Get(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	approvals, err := s.repo.ListByTicket(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	current := domain.CurrentApproval(approvals)
+	if current == nil || current.Status != domain.ApprovalPending {
+		return nil, domain.ErrNoPendingApproval
+	}
+	if !authorization.CanDecideApproval(auth, ticket, current) {
+		return nil, authorization.ErrAccessDenied
+	}
+	if ticket.State != domain.TicketStateOpen {
+		return nil, domain.ErrApprovalTicketNotOpen
+	}
+
+	comment = strings.TrimSpace(comment)
+	current.Status = domain.ApprovalApproved
+	if !approve {
+		if comment == "" {
+			return nil, domain.ErrRejectionNeedsComment
+		}
+		current.Status = domain.ApprovalRejected
+	}
+	now := time.Now()
+	current.DecidedBy = &auth.UserID
+	current.Comment = comment
+	current.DecidedAt = &now
+
+	decided, err := s.repo.Decide(ctx, *current)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.events.Create(ctx, domain.TicketEvent{
+		TicketID: ticketID,
+		ActorID:  auth.UserID,
+		Kind:     domain.TicketEventApprovalDecided,
+		OldValue: string(domain.ApprovalPending),
+		NewValue: string(decided.Status),
+		Note:     decided.Comment,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return decided, nil
+}
+
+// ListPending returns the approvals waiting on the caller
+func (s *ApprovalService) ListPending(ctx context.Context) ([]domain.TicketApproval, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	approvals, err := s.repo.ListPending(ctx, auth.UserID, auth.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	// Creators can't approve their own tickets, so don't list those
+	out := make([]domain.TicketApproval, 0, len(approvals))
+	for _, a := range approvals {
+		ticket, err := s.ticketRepo.Get(ctx, a.TicketID)
+		if err != nil {
+			return nil, err
+		}
+		if authorization.CanDecideApproval(auth, ticket, &a) {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nickhildpac/ticket-management-app/internal/application/authorization"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+	"github.com/nickhildpac/ticket-management-app/internal/ports"
+	"github.com/nickhildpac/ticket-management-app/pkg/configs"
+)
+
+type AvailabilityService struct {
+	repo   ports.AvailabilityRepository
+	users  ports.UserRepository
+	events ports.TicketEventRepository
+	policy domain.AssignmentPolicy
+}
+
+func NewAvailabilityService(r ports.AvailabilityRepository, ur ports.UserRepository, events ports.TicketEventRepository, conf *configs.Config) *AvailabilityService {
+	return &AvailabilityService{
+		repo:   r,
+		users:  ur,
+		events: events,
+		policy: domain.AssignmentPolicy(conf.AssignmentPolicy),
+	}
+}
+
+func (s *AvailabilityService) GetAvailability(ctx context.Context, userID uuid.UUID) (*domain.Availability, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !authorization.CanViewAvailability(auth) {
+		return nil, authorization.ErrAccessDenied
+	}
+
+	if _, err := s.agent(ctx, userID); err != nil {
+		return nil, err
+	}
+	return s.repo.Get(ctx, userID)
+}
+
+func (s *AvailabilityService) UpdateAvailability(ctx context.Context, a domain.Availability) (*domain.Availability, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.agent(ctx, a.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !authorization.CanManageAvailability(auth, user) {
+		return nil, authorization.ErrAccessDenied
+	}
+
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.Update(ctx, a)
+}
+
+func (s *AvailabilityService) ListOutOfOffice(ctx context.Context, userID uuid.UUID) ([]domain.OutOfOffice, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !authorization.CanViewAvailability(auth) {
+		return nil, authorization.ErrAccessDenied
+	}
+
+	if _, err := s.agent(ctx, userID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListOutOfOffice(ctx, userID)
+}
+
+// CreateOutOfOffice records an absence. One that has already started
+// forwards the agent's open tickets straight away.
+func (s *AvailabilityService) CreateOutOfOffice(ctx context.Context, o domain.OutOfOffice) (*domain.OutOfOffice, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.agent(ctx, o.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !authorization.CanManageAvailability(auth, user) {
+		return nil, authorization.ErrAccessDenied
+	}
+
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+	if o.DelegateID != nil {
+		if _, err := s.agent(ctx, *o.DelegateID); err != nil {
+			return nil, err
+		}
+	}
+
+	created, err := s.repo.CreateOutOfOffice(ctx, o)
+	if err != nil {
+		return nil, err
+	}
+	if created.DelegateID != nil && created.Covers(time.Now()) {
+		if err := s.forward(ctx, *created); err != nil {
+			return nil, err
+		}
+	}
+	return created, nil
+}
+
+func (s *AvailabilityService) DeleteOutOfOffice(ctx context.Context, userID, id uuid.UUID) error {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.agent(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !authorization.CanManageAvailability(auth, user) {
+		return authorization.ErrAccessDenied
+	}
+
+	return s.repo.DeleteOutOfOffice(ctx, userID, id)
+}
+
+func (s *AvailabilityService) RouteAssignees(ctx context.Context, prev, next []uuid.UUID) ([]uuid.UUID, error) {
+	out := make([]uuid.UUID, 0, len(next))
+	for _, id := range next {
+		if !slices.Contains(prev, id) {
+			a, err := s.repo.Get(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			switch problem := a.Problem(); {
+			case a.OutOfOffice != nil && a.OutOfOffice.DelegateID != nil:
+				id = *a.OutOfOffice.DelegateID
+			case problem != nil && s.policy == domain.AssignmentPolicyRefuse:
+				return nil, fmt.Errorf("cannot assign %s: %w", id, problem)
+			}
+		}
+		if !slices.Contains(out, id) {
+			out = append(out, id)
+		}
+	}
+	return out, nil
+}
+
+func (s *AvailabilityService) Warnings(ctx context.Context, prev, next []uuid.UUID) ([]domain.AssignmentWarning, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !authorization.CanViewAvailability(auth) {
+		return nil, authorization.ErrAccessDenied
+	}
+
+	var warnings []domain.AssignmentWarning
+	for _, id := range next {
+		if slices.Contains(prev, id) {
+			continue
+		}
+		a, err := s.repo.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if problem := a.Problem(); problem != nil {
+			warnings = append(warnings, domain.AssignmentWarning{UserID: id, Reason: problem.Error()})
+		}
+	}
+	return warnings, nil
+}
+
+func (s *AvailabilityService) ForwardOutOfOffice(ctx context.Context) error {
+	absences, err := s.repo.ListActiveOutOfOffice(ctx)
+	if err != nil {
+		return err
+	}
+	for _, o := range absences {
+		if err := s.forward(ctx, o); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// forward hands the absent agent's open tickets to their delegate and
+// records it on each ticket
+func (s *AvailabilityService) forward(ctx context.Context, o domain.OutOfOffice) error {
+	ids, err := s.repo.ForwardTickets(ctx, o.UserID, *o.DelegateID)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		_, err := s.events.Create(ctx, domain.TicketEvent{
+			TicketID: id,
+			ActorID:  o.UserID,
+			Kind:     domain.TicketEventAssigneeForwarded,
+			OldValue: o.UserID.String(),
+			NewValue: o.DelegateID.String(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// agent loads a user who can have availability
+func (s *AvailabilityService) agent(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	user, err := s.users.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role != domain.RoleAgent && user.Role != domain.RoleAdmin {
+		return nil, domain.ErrNotAgent
+	}
+	return user, nil
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/nickhildpac/ticket-management-app/internal/application/authorization"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+	"github.com/nickhildpac/ticket-management-app/internal/ports"
+)
+
+type CalendarService struct {
+	repo  ports.CalendarRepository
+	orgs  ports.OrganizationRepository
+	teams ports.TeamRepository
+}
+
+func NewCalendarService(r ports.CalendarRepository, or ports.OrganizationRepository, tr ports.TeamRepository) *CalendarService {
+	return &CalendarService{repo: r, orgs: or, teams: tr}
+}
+
+func (s *CalendarService) ListCalendars(ctx context.Context) ([]domain.BusinessCalendar, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !authorization.CanViewCalendars(auth) {
+		return nil, authorization.ErrAccessDenied
+	}
+
+	return s.repo.List(ctx)
+}
+
+func (s *CalendarService) GetCalendar(ctx context.Context, id uuid.UUID) (*domain.BusinessCalendar, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !authorization.CanViewCalendars(auth) {
+		return nil, authorization.ErrAccessDenied
+	}
+
+	calendar, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !authorization.CanAccessOrg(auth, calendar.OrgID) {
+		return nil, authorization.ErrAccessDenied
+	}
+
+	return calendar, nil
+}
+
+// CreateCalendar creates a calendar in the caller's own organization unless
+// another one is given
+func (s *CalendarService) CreateCalendar(ctx context.Context, calendar domain.BusinessCalendar) (*domain.BusinessCalendar, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if calendar.OrgID == uuid.Nil {
+		calendar.OrgID = auth.OrgID
+	}
+	if !authorization.CanManageCalendar(auth, &calendar) {
+		return nil, authorization.ErrAccessDenied
+	}
+
+	if err := calendar.Normalize(); err != nil {
+		return nil, err
+	}
+
+	return s.repo.Create(ctx, calendar)
+}
+
+func (s *CalendarService) UpdateCalendar(ctx context.Context, calendar domain.BusinessCalendar) (*domain.BusinessCalendar, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	prev, err := s.repo.Get(ctx, calendar.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !authorization.CanManageCalendar(auth, prev) {
+		return nil, authorization.ErrAccessDenied
+	}
+
+	calendar.OrgID = prev.OrgID
+	if err := calendar.Normalize(); err != nil {
+		return nil, err
+	}
+
+	return s.repo.Update(ctx, calendar)
+}
+
+// DeleteCalendar removes the calendar. Organizations and teams using it fall
+// back to round-the-clock timing.
+func (s *CalendarService) DeleteCalendar(ctx context.Context, id uuid.UUID) error {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	calendar, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if !authorization.CanManageCalendar(auth, calendar) {
+		return authorization.ErrAccessDenied
+	}
+
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *CalendarService) SetOrganizationCalendar(ctx context.Context, orgID uuid.UUID, calendarID *uuid.UUID) (*domain.Organization, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !authorization.CanSetOrganizationCalendar(auth, orgID) {
+		return nil, authorization.ErrAccessDenied
+	}
+
+	if err := s.checkCalendar(ctx, orgID, calendarID); err != nil {
+		return nil, err
+	}
+
+	return s.orgs.SetCalendar(ctx, orgID, calendarID)
+}
+
+func (s *CalendarService) SetTeamCalendar(ctx context.Context, teamID uuid.UUID, calendarID *uuid.UUID) (*domain.Team, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	team, err := s.teams.Get(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !authorization.CanManageTeam(auth, team) {
+		return nil, authorization.ErrAccessDenied
+	}
+
+	if err := s.checkCalendar(ctx, team.OrgID, calendarID); err != nil {
+		return nil, err
+	}
+
+	return s.teams.SetCalendar(ctx, teamID, calendarID)
+}
+
+func (s *CalendarService) ForTicket(ctx context.Context, ticket *domain.Ticket) (*domain.BusinessCalendar, error) {
+	if ticket.TeamID != nil {
+		team, err := s.teams.Get(ctx, *ticket.TeamID)
+		if err != nil {
+			return nil, err
+		}
+		if team.CalendarID != nil {
+			return s.repo.Get(ctx, *team.CalendarID)
+		}
+	}
+
+	org, err := s.orgs.Get(ctx, ticket.OrgID)
+	if err != nil {
+		return nil, err
+	}
+	if org.CalendarID == nil {
+		return nil, nil
+	}
+	return s.repo.Get(ctx, *org.CalendarID)
+}
+
+// checkCalendar returns an error unless the calendar, if any, belongs to the
+// organization
+func (s *CalendarService) checkCalendar(ctx context.Context, orgID uuid.UUID, calendarID *uuid.UUID) error {
+	if calendarID == nil {
+		return nil
+	}
+	calendar, err := s.repo.Get(ctx, *calendarID)
+	if err != nil {
+		return err
+	}
+	if calendar.OrgID != orgID {
+		return domain.ErrCalendarOrgMismatch
+	}
+	return nil
+}
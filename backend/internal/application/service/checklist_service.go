@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nickhildpac/ticket-management-app/internal/application/authorization"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+	"github.com/nickhildpac/ticket-management-app/internal/ports"
+)
+
+type ChecklistService struct {
+	repo       ports.ChecklistRepository
+	ticketRepo ports.TicketRepository
+}
+
+func NewChecklistService(r ports.ChecklistRepository, tr ports.TicketRepository) *ChecklistService {
+	return &ChecklistService{repo: r, ticketRepo: tr}
+}
+
+// authorize loads the ticket and checks the caller against it with can
+func (s *ChecklistService) authorize(ctx context.Context, ticketID uuid.UUID, can func(authorization.AuthContext, *domain.Ticket) bool) error {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	ticket, err := s.ticketRepo.Get(ctx, ticketID)
+	if err != nil {
+		return err
+	}
+
+	if !can(auth, ticket) {
+		return authorization.ErrAccessDenied
+	}
+	return nil
+}
+
+func (s *ChecklistService) ListItems(ctx context.Context, ticketID uuid.UUID) ([]domain.ChecklistItem, error) {
+	if err := s.authorize(ctx, ticketID, authorization.CanViewTicket); err != nil {
+		return nil, err
+	}
+	return s.repo.ListByTicket(ctx, ticketID)
+}
+
+func (s *ChecklistService) GetItem(ctx context.Context, ticketID, itemID uuid.UUID) (*domain.ChecklistItem, error) {
+	if err := s.authorize(ctx, ticketID, authorization.CanViewTicket); err != nil {
+		return nil, err
+	}
+
+	item, err := s.repo.Get(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if item.TicketID != ticketID {
+		return nil, domain.ErrChecklistItemNotFound
+	}
+	return item, nil
+}
+
+func (s *ChecklistService) CreateItem(ctx context.Context, item domain.ChecklistItem) (*domain.ChecklistItem, error) {
+	if err := s.authorize(ctx, item.TicketID, authorization.CanManageChecklist); err != nil {
+		return nil, err
+	}
+
+	item.Text = strings.TrimSpace(item.Text)
+	if item.Text == "" {
+		return nil, domain.ErrEmptyChecklistItem
+	}
+	item.UpdatedAt = time.Now()
+	return s.repo.Create(ctx, item)
+}
+
+// UpdateItem saves an item; changing whether it is required takes the
+// stricter manage check
+func (s *ChecklistService) UpdateItem(ctx context.Context, item domain.ChecklistItem) (*domain.ChecklistItem, error) {
+	if err := s.authorize(ctx, item.TicketID, authorization.CanUpdateTicket); err != nil {
+		return nil, err
+	}
+
+	prev, err := s.repo.Get(ctx, item.ID)
+	if err != nil {
+		return nil, err
+	}
+	if prev.TicketID != item.TicketID {
+		return nil, domain.ErrChecklistItemNotFound
+	}
+	if prev.Required != item.Required {
+		if err := s.authorize(ctx, item.TicketID, authorization.CanManageChecklist); err != nil {
+			return nil, err
+		}
+	}
+
+	item.Text = strings.TrimSpace(item.Text)
+	if item.Text == "" {
+		return nil, domain.ErrEmptyChecklistItem
+	}
+	item.UpdatedAt = time.Now()
+	return s.repo.Update(ctx, item)
+}
+
+func (s *ChecklistService) DeleteItem(ctx context.Context, ticketID, itemID uuid.UUID) error {
+	if err := s.authorize(ctx, ticketID, authorization.CanManageChecklist); err != nil {
+		return err
+	}
+
+	item, err := s.repo.Get(ctx, itemID)
+	if err != nil {
+		return err
+	}
+	if item.TicketID != ticketID {
+		return domain.ErrChecklistItemNotFound
+	}
+	return s.repo.Delete(ctx, itemID)
+}
+
+// Reorder takes the full list of item IDs in their new order
+func (s *ChecklistService) Reorder(ctx context.Context, ticketID uuid.UUID, ids []uuid.UUID) ([]domain.ChecklistItem, error) {
+	if err := s.authorize(ctx, ticketID, authorization.CanManageChecklist); err != nil {
+		return nil, err
+	}
+
+	items, err := s.repo.ListByTicket(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsPermutation(items, ids) {
+		return nil, domain.ErrInvalidChecklistOrder
+	}
+
+	if err := s.repo.Reorder(ctx, ticketID, ids); err != nil {
+		return nil, err
+	}
+	return s.repo.ListByTicket(ctx, ticketID)
+}
+
+func (s *ChecklistService) Progress(ctx context.Context, ticketID uuid.UUID) (*domain.ChecklistProgress, error) {
+	if err := s.authorize(ctx, ticketID, authorization.CanViewTicket); err != nil {
+		return nil, err
+	}
+	return s.repo.Progress(ctx, ticketID)
+}
@@ -2,23 +2,35 @@ package service
 
 import (
 	"context"
+	"log"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/nickhildpac/ticket-management-app/internal/application/authorization"
 	"github.com/nickhildpac/ticket-management-app/internal/domain"
 	"github.com/nickhildpac/ticket-management-app/internal/ports"
+	"github.com/nickhildpac/ticket-management-app/pkg/configs"
 )
 
 type CommentService struct {
-	repo       ports.CommentRepository
-	ticketRepo ports.TicketRepository
+	repo          ports.CommentRepository
+	ticketRepo    ports.TicketRepository
+	events        ports.TicketEventRepository
+	mentions      ports.MentionService
+	ticketService ports.TicketService
+	userRepo      ports.UserRepository
+	tx            ports.Transactor
+	config        *configs.Config
 }
 
-func NewCommentService(r ports.CommentRepository, tr ports.TicketRepository) *CommentService {
-	return &CommentService{repo: r, ticketRepo: tr}
+func NewCommentService(r ports.CommentRepository, tr ports.TicketRepository, er ports.TicketEventRepository, ms ports.MentionService, ts ports.TicketService, ur ports.UserRepository, tx ports.Transactor, conf *configs.Config) *CommentService {
+	return &CommentService{repo: r, ticketRepo: tr, events: er, mentions: ms, ticketService: ts, userRepo: ur, tx: tx, config: conf}
 }
 
+// ListByTicket returns a page of the ticket's threads with all of their
+// replies, leaving out internal notes unless the caller is an agent or admin.
+// The comments are listed depth first, so each reply follows its parent. A
+// deleted comment with replies stays as a placeholder without its text.
 func (s *CommentService) ListByTicket(ctx context.Context, ticketID uuid.UUID, limit, offset int32) ([]domain.Comment, error) {
 	auth, err := authorization.GetAuthContext(ctx)
 	if err != nil {
@@ -35,11 +47,82 @@ func (s *CommentService) ListByTicket(ctx context.Context, ticketID uuid.UUID, l
 		return nil, authorization.ErrAccessDenied
 	}
 
-	return s.repo.ListByTicket(ctx, ticketID, limit, offset)
+	var visibility *domain.CommentVisibility
+	if !authorization.CanViewInternalComments(auth) {
+		public := domain.CommentPublic
+		visibility = &public
+	}
+
+	threads, err := s.repo.ListByTicket(ctx, ticketID, visibility, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if len(threads) == 0 {
+		return threads, nil
+	}
+
+	ids := make([]uuid.UUID, len(threads))
+	for i, c := range threads {
+		ids[i] = c.ID
+	}
+	replies, err := s.repo.ListThreadReplies(ctx, ids, visibility)
+	if err != nil {
+		return nil, err
+	}
+
+	tree := domain.PruneDeletedComments(domain.BuildCommentTree(append(threads, replies...)))
+	return domain.FlattenCommentTree(tree), nil
 }
 
+// ListReplies returns a page of the replies in the thread the comment starts
+// or belongs to, oldest first
+func (s *CommentService) ListReplies(ctx context.Context, id uuid.UUID, limit, offset int32) ([]domain.Comment, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := s.GetComment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var visibility *domain.CommentVisibility
+	if !authorization.CanViewInternalComments(auth) {
+		public := domain.CommentPublic
+		visibility = &public
+	}
+
+	thread := comment.ID
+	if comment.ThreadID != nil {
+		thread = *comment.ThreadID
+	}
+	return s.repo.ListReplies(ctx, thread, visibility, limit, offset)
+}
+
+// GetComment returns the comment to anyone who can see it. Everyone else is
+// told it doesn't exist, so comment IDs can't be probed.
 func (s *CommentService) GetComment(ctx context.Context, id uuid.UUID) (*domain.Comment, error) {
-	return s.repo.Get(ctx, id)
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	ticket, err := s.ticketRepo.Get(ctx, comment.TicketID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !authorization.CanViewComment(auth, ticket, comment) {
+		return nil, domain.ErrCommentNotFound
+	}
+
+	return comment, nil
 }
 
 func (s *CommentService) CreateComment(ctx context.Context, comment domain.Comment) (*domain.Comment, error) {
@@ -57,6 +140,224 @@ func (s *CommentService) CreateComment(ctx context.Context, comment domain.Comme
 	if !authorization.CanCommentOnTicket(auth, ticket) {
 		return nil, authorization.ErrAccessDenied
 	}
+	if comment.Visibility == "" {
+		comment.Visibility = domain.CommentPublic
+	}
+	if comment.ParentID != nil {
+		parent, err := s.repo.Get(ctx, *comment.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		if !authorization.CanViewComment(auth, ticket, parent) {
+			return nil, domain.ErrCommentNotFound
+		}
+		if err := comment.ReplyTo(*parent); err != nil {
+			return nil, err
+		}
+	}
+	if comment.Visibility == domain.CommentInternal && !authorization.CanViewInternalComments(auth) {
+		return nil, authorization.ErrAccessDenied
+	}
+	if err := domain.CheckCommentLength(comment.Description); err != nil {
+		return nil, err
+	}
+	commands, err := domain.ParseCommands(comment.Description)
+	if err != nil {
+		return nil, err
+	}
+	if len(commands) == 0 {
+		return s.create(ctx, ticket, comment)
+	}
+
+	// The comment and its commands are kept together or not at all
+	var created *domain.Comment
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if created, err = s.create(ctx, ticket, comment); err != nil {
+			return err
+		}
+		return s.runCommands(ctx, ticket, commands)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (s *CommentService) create(ctx context.Context, ticket *domain.Ticket, comment domain.Comment) (*domain.Comment, error) {
 	comment.UpdatedAt = time.Now()
-	return s.repo.Create(ctx, comment)
+	created, err := s.repo.Create(ctx, comment)
+	if err != nil {
+		return nil, err
+	}
+
+	// Mentions are best effort and must not fail the comment, so they are
+	// recorded once it is committed
+	s.tx.AfterCommit(ctx, func(ctx context.Context) {
+		if err := s.mentions.Record(ctx, ticket, created); err != nil {
+			log.Printf("failed to record mentions in comment %s: %v", created.ID, err)
+		}
+	})
+	return created, nil
+}
+
+// runCommands makes the changes the comment's slash commands ask for through
+// UpdateTicket, so they are held to the same permission and transition
+// checks as an edit, and records what each command did
+func (s *CommentService) runCommands(ctx context.Context, ticket *domain.Ticket, commands []domain.Command) error {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	var handles []string
+	for _, c := range commands {
+		handles = append(handles, c.Handles()...)
+	}
+	users := map[string]uuid.UUID{}
+	if len(handles) > 0 {
+		emails, names := domain.SplitMentionHandles(handles)
+		candidates, err := s.userRepo.ListMentionable(ctx, ticket.OrgID, emails, names)
+		if err != nil {
+			return err
+		}
+		for _, h := range handles {
+			if matched := domain.ResolveMentions([]string{h}, candidates); len(matched) == 1 {
+				users[h] = matched[0].ID
+			}
+		}
+	}
+
+	changed := *ticket
+	fields, results, err := domain.ApplyCommands(&changed, commands, users)
+	if err != nil {
+		return err
+	}
+	if _, err := s.ticketService.UpdateTicket(ctx, changed, fields); err != nil {
+		return err
+	}
+
+	for _, r := range results {
+		_, err := s.events.Create(ctx, domain.TicketEvent{
+			TicketID: ticket.ID,
+			ActorID:  auth.UserID,
+			Kind:     domain.TicketEventCommandApplied,
+			OldValue: r.OldValue,
+			NewValue: r.NewValue,
+			Reason:   r.Command,
+			Note:     r.Field,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *CommentService) UpdateComment(ctx context.Context, id uuid.UUID, description string) (*domain.Comment, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	ticket, err := s.ticketRepo.Get(ctx, comment.TicketID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !authorization.CanEditComment(auth, ticket, comment) {
+		return nil, authorization.ErrAccessDenied
+	}
+
+	now := time.Now()
+	if !comment.Editable(now, s.config.CommentEditWindow) {
+		return nil, domain.ErrCommentEditExpired
+	}
+	if comment.Description, err = domain.NormalizeCommentText(description); err != nil {
+		return nil, err
+	}
+	comment.EditedAt = &now
+
+	updated, err := s.repo.Update(ctx, *comment, auth.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.mentions.Record(ctx, ticket, updated); err != nil {
+		log.Printf("failed to record mentions in comment %s: %v", updated.ID, err)
+	}
+	return updated, nil
+}
+
+// DeleteComment hides a comment. Its revisions are kept, along with its final
+// text, and the ticket's history records who deleted it and whether it was
+// internal.
+func (s *CommentService) DeleteComment(ctx context.Context, id uuid.UUID) error {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	comment, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	ticket, err := s.ticketRepo.Get(ctx, comment.TicketID)
+	if err != nil {
+		return err
+	}
+
+	if !authorization.CanDeleteComment(auth, ticket) {
+		return authorization.ErrAccessDenied
+	}
+
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.Delete(ctx, id, auth.UserID); err != nil {
+			return err
+		}
+		_, err := s.events.Create(ctx, domain.TicketEvent{
+			TicketID: ticket.ID,
+			ActorID:  auth.UserID,
+			Kind:     domain.TicketEventCommentDeleted,
+			OldValue: comment.ID.String(),
+			NewValue: string(comment.Visibility),
+		})
+		return err
+	})
+}
+
+// ListRevisions returns the earlier texts of a comment to anyone who can see
+// it. Those of a deleted comment, ending with the text it had when deleted,
+// are only shown to admins.
+func (s *CommentService) ListRevisions(ctx context.Context, id uuid.UUID) ([]domain.CommentRevision, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := s.repo.GetIncludingDeleted(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	ticket, err := s.ticketRepo.Get(ctx, comment.TicketID)
+	if err != nil {
+		return nil, err
+	}
+
+	if comment.DeletedAt != nil && !authorization.CanViewDeletedComment(auth, ticket) {
+		return nil, domain.ErrCommentNotFound
+	}
+
+	if !authorization.CanViewComment(auth, ticket, comment) {
+		return nil, authorization.ErrAccessDenied
+	}
+
+	return s.repo.ListRevisions(ctx, id)
 }
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nickhildpac/ticket-management-app/internal/application/authorization"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+	"github.com/nickhildpac/ticket-management-app/internal/ports"
+	"github.com/nickhildpac/ticket-management-app/pkg/configs"
+	"github.com/nickhildpac/ticket-management-app/pkg/util"
+)
+
+const csatTokenPurpose = "csat"
+
+type CSATService struct {
+	repo       ports.CSATRepository
+	ticketRepo ports.TicketRepository
+	userRepo   ports.UserRepository
+	mailer     ports.Mailer
+	config     *configs.Config
+}
+
+func NewCSATService(r ports.CSATRepository, tr ports.TicketRepository, ur ports.UserRepository, m ports.Mailer, conf *configs.Config) *CSATService {
+	return &CSATService{
+		repo:       r,
+		ticketRepo: tr,
+		userRepo:   ur,
+		mailer:     m,
+		config:     conf,
+	}
+}
+
+// SendSurvey mails the ticket creator one link per rating. The links carry a
+// signed token, so rating works without logging in.
+func (s *CSATService) SendSurvey(ctx context.Context, ticket domain.Ticket) error {
+	creator, err := s.userRepo.GetUserByID(ctx, ticket.CreatedBy)
+	if err != nil {
+		return err
+	}
+
+	token, err := util.GenerateActionToken(s.config, ticket.ID.String(), csatTokenPurpose, s.config.CSATExpiry)
+	if err != nil {
+		return err
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "Hi %s,\n\n", creator.FirstName)
+	fmt.Fprintf(&body, "Your ticket %q has been resolved. How satisfied are you with the support you received?\n\n", ticket.Title)
+	for rating := domain.MaxCSATRating; rating >= domain.MinCSATRating; rating-- {
+		fmt.Fprintf(&body, "%d - %s/api/v1/csat/%s?rating=%d\n", rating, s.config.BaseURL, token, rating)
+	}
+	fmt.Fprintf(&body, "\nThis link expires on %s.\n", time.Now().Add(s.config.CSATExpiry).Format(time.RFC1123))
+
+	return s.mailer.Send(ctx, creator.Email, "How did we do?", body.String())
+}
+
+// GetSurvey returns the ticket a survey link is for, so the survey can be
+// shown before anything is recorded
+func (s *CSATService) GetSurvey(ctx context.Context, token string) (*domain.Ticket, error) {
+	return s.surveyTicket(ctx, token)
+}
+
+// SubmitRating records a rating from a survey link. Submitting again before
+// the link expires replaces the previous rating.
+func (s *CSATService) SubmitRating(ctx context.Context, token string, rating int, comment string) (*domain.CSATResponse, error) {
+	ticket, err := s.surveyTicket(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if err := domain.ValidateRating(rating); err != nil {
+		return nil, err
+	}
+
+	return s.repo.Upsert(ctx, domain.CSATResponse{
+		TicketID:   ticket.ID,
+		AssignedTo: ticket.AssignedTo,
+		Rating:     rating,
+		Comment:    strings.TrimSpace(comment),
+		UpdatedAt:  time.Now(),
+	})
+}
+
+// surveyTicket returns the ticket a survey token was issued for if it can
+// still be rated
+func (s *CSATService) surveyTicket(ctx context.Context, token string) (*domain.Ticket, error) {
+	subject, err := util.VerifyActionToken(s.config, token, csatTokenPurpose)
+	if err != nil {
+		return nil, err
+	}
+	ticketID, err := uuid.Parse(subject)
+	if err != nil {
+		return nil, util.ErrInvalidActionToken
+	}
+
+	ticket, err := s.ticketRepo.Get(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	if !ticket.State.AcceptsSurvey() {
+		return nil, domain.ErrSurveyClosed
+	}
+	return ticket, nil
+}
+
+func (s *CSATService) GetSummary(ctx context.Context) (*domain.CSATSummary, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !authorization.CanViewReports(auth) {
+		return nil, authorization.ErrAccessDenied
+	}
+
+	return s.repo.Summary(ctx)
+}
+
+func (s *CSATService) GetSummaryByAssignee(ctx context.Context) ([]domain.CSATAssigneeSummary, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !authorization.CanViewReports(auth) {
+		return nil, authorization.ErrAccessDenied
+	}
+
+	return s.repo.SummaryByAssignee(ctx)
+}
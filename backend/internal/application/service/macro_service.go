@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nickhildpac/ticket-management-app/internal/application/authorization"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+	"github.com/nickhildpac/ticket-management-app/internal/ports"
+)
+
+type MacroService struct {
+	repo           ports.MacroRepository
+	userRepo       ports.UserRepository
+	ticketService  ports.TicketService
+	commentService ports.CommentService
+	tx             ports.Transactor
+}
+
+func NewMacroService(r ports.MacroRepository, ur ports.UserRepository, ts ports.TicketService, cs ports.CommentService, tx ports.Transactor) *MacroService {
+	return &MacroService{repo: r, userRepo: ur, ticketService: ts, commentService: cs, tx: tx}
+}
+
+func (s *MacroService) ListMacros(ctx context.Context) ([]domain.Macro, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !authorization.CanUseMacros(auth) {
+		return nil, authorization.ErrAccessDenied
+	}
+	return s.repo.ListVisible(ctx, auth.UserID, auth.OrgID)
+}
+
+func (s *MacroService) GetMacro(ctx context.Context, id uuid.UUID) (*domain.Macro, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	macro, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !authorization.CanViewMacro(auth, macro) {
+		return nil, authorization.ErrAccessDenied
+	}
+	return macro, nil
+}
+
+func (s *MacroService) CreateMacro(ctx context.Context, macro domain.Macro, shared bool) (*domain.Macro, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !authorization.CanUseMacros(auth) {
+		return nil, authorization.ErrAccessDenied
+	}
+
+	if err := validateMacro(auth, &macro, shared); err != nil {
+		return nil, err
+	}
+	macro.OwnerID = nil
+	if !shared {
+		macro.OwnerID = &auth.UserID
+	}
+	macro.CreatedBy = &auth.UserID
+	macro.OrgID = auth.OrgID
+	macro.UpdatedAt = time.Now()
+	return s.repo.Create(ctx, macro)
+}
+
+// UpdateMacro replaces the macro. An admin who unshares a macro keeps it as
+// their own.
+func (s *MacroService) UpdateMacro(ctx context.Context, macro domain.Macro, shared bool) (*domain.Macro, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	prev, err := s.repo.Get(ctx, macro.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !authorization.CanManageMacro(auth, prev) {
+		return nil, authorization.ErrAccessDenied
+	}
+
+	if err := validateMacro(auth, &macro, shared); err != nil {
+		return nil, err
+	}
+	switch {
+	case shared:
+		macro.OwnerID = nil
+	case prev.Shared():
+		macro.OwnerID = &auth.UserID
+	default:
+		macro.OwnerID = prev.OwnerID
+	}
+	macro.OrgID = prev.OrgID
+	macro.UpdatedAt = time.Now()
+	return s.repo.Update(ctx, macro)
+}
+
+func (s *MacroService) DeleteMacro(ctx context.Context, id uuid.UUID) error {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	macro, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !authorization.CanManageMacro(auth, macro) {
+		return authorization.ErrAccessDenied
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *MacroService) Render(ctx context.Context, id, ticketID uuid.UUID) (string, error) {
+	macro, err := s.GetMacro(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	ticket, err := s.ticketService.GetTicket(ctx, ticketID)
+	if err != nil {
+		return "", err
+	}
+	return s.expand(ctx, macro, ticket)
+}
+
+// Apply runs the reply and the ticket changes through the comment and ticket
+// services, so a macro can do no more than the caller could by hand. If
+// either fails, neither is kept.
+func (s *MacroService) Apply(ctx context.Context, id, ticketID uuid.UUID) (*domain.MacroApplication, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	macro, err := s.GetMacro(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.MacroApplication{}
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		ticket, err := s.ticketService.GetTicket(ctx, ticketID)
+		if err != nil {
+			return err
+		}
+
+		reply, err := s.expand(ctx, macro, ticket)
+		if err != nil {
+			return err
+		}
+		if strings.TrimSpace(reply) != "" {
+			result.Comment, err = s.commentService.CreateComment(ctx, domain.Comment{
+				TicketID:    ticket.ID,
+				CreatedBy:   auth.UserID,
+				Description: reply,
+				Visibility:  macro.Visibility,
+			})
+			if err != nil {
+				return err
+			}
+			// Slash commands in the reply may have changed the ticket
+			if ticket, err = s.ticketService.GetTicket(ctx, ticketID); err != nil {
+				return err
+			}
+		}
+
+		result.Ticket = ticket
+		fields := macro.Actions.Apply(ticket)
+		if len(fields) == 0 {
+			return nil
+		}
+		// Reopening needs a reason; the macro's name says where it came from
+		if slices.Contains(fields, "state") {
+			ticket.StateReason = "macro: " + macro.Name
+			fields = append(fields, "reason")
+		}
+		result.Ticket, err = s.ticketService.UpdateTicket(ctx, *ticket, fields)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *MacroService) expand(ctx context.Context, macro *domain.Macro, ticket *domain.Ticket) (string, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	creator, err := s.userRepo.GetUserByID(ctx, ticket.CreatedBy)
+	if err != nil {
+		return "", err
+	}
+	agent, err := s.userRepo.GetUserByID(ctx, auth.UserID)
+	if err != nil {
+		return "", err
+	}
+	return domain.Placeholders{Ticket: ticket, Creator: creator, Agent: agent}.Expand(macro.Reply), nil
+}
+
+func validateMacro(auth authorization.AuthContext, macro *domain.Macro, shared bool) error {
+	macro.Name = strings.TrimSpace(macro.Name)
+	if macro.Name == "" {
+		return domain.ErrEmptyMacroName
+	}
+	macro.Reply = strings.TrimSpace(macro.Reply)
+	if macro.Reply == "" && macro.Actions.Empty() {
+		return domain.ErrEmptyMacro
+	}
+	if err := domain.ValidatePlaceholders(macro.Reply); err != nil {
+		return err
+	}
+	// A reply may start with slash commands, which run when it is posted
+	if _, err := domain.ParseCommands(macro.Reply); err != nil {
+		return err
+	}
+	if macro.Visibility == "" {
+		macro.Visibility = domain.CommentPublic
+	}
+	if macro.Visibility == domain.CommentInternal && !authorization.CanViewInternalComments(auth) {
+		return authorization.ErrAccessDenied
+	}
+	if state := macro.Actions.State; state != nil {
+		if state.RequiresResolutionCode() && macro.Actions.ResolutionCode == "" {
+			return domain.ErrResolutionCodeRequired
+		}
+		if !state.RequiresResolutionCode() {
+			macro.Actions.ResolutionCode = ""
+		}
+	}
+	macro.Actions.Labels = domain.NormalizeLabels(macro.Actions.Labels)
+	if shared && !authorization.CanShareMacro(auth) {
+		return authorization.ErrAccessDenied
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/nickhildpac/ticket-management-app/internal/application/authorization"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+	"github.com/nickhildpac/ticket-management-app/internal/ports"
+	"github.com/nickhildpac/ticket-management-app/pkg/configs"
+)
+
+// mentionExcerpt is how much of the text a mention notification quotes
+const mentionExcerpt = 500
+
+type MentionService struct {
+	repo       ports.MentionRepository
+	ticketRepo ports.TicketRepository
+	users      ports.UserRepository
+	mailer     ports.Mailer
+	config     *configs.Config
+}
+
+func NewMentionService(r ports.MentionRepository, tr ports.TicketRepository, ur ports.UserRepository, m ports.Mailer, conf *configs.Config) *MentionService {
+	return &MentionService{repo: r, ticketRepo: tr, users: ur, mailer: m, config: conf}
+}
+
+// Record resolves the mentions against the users who work in the ticket's
+// organization. Internal notes only mention agents and admins, and nobody is
+// notified of mentioning themselves. Under the grant policy, mentioned users
+// who aren't on the ticket become its readers.
+func (s *MentionService) Record(ctx context.Context, ticket *domain.Ticket, comment *domain.Comment) error {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	text := ticket.Description
+	var commentID *uuid.UUID
+	if comment != nil {
+		text = comment.Description
+		commentID = &comment.ID
+	}
+
+	handles := domain.ParseMentions(text)
+	if len(handles) == 0 {
+		return nil
+	}
+	emails, names := domain.SplitMentionHandles(handles)
+	candidates, err := s.users.ListMentionable(ctx, ticket.OrgID, emails, names)
+	if err != nil {
+		return err
+	}
+
+	mentioned := map[uuid.UUID]domain.User{}
+	var userIDs []uuid.UUID
+	for _, u := range domain.ResolveMentions(handles, candidates) {
+		if u.ID == auth.UserID {
+			continue
+		}
+		if comment != nil && comment.Visibility == domain.CommentInternal && u.Role != domain.RoleAgent && u.Role != domain.RoleAdmin {
+			continue
+		}
+		mentioned[u.ID] = u
+		userIDs = append(userIDs, u.ID)
+	}
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	created, err := s.repo.Create(ctx, ticket.ID, commentID, userIDs, auth.UserID)
+	if err != nil {
+		return err
+	}
+
+	if domain.MentionPolicy(s.config.MentionPolicy) == domain.MentionPolicyGrant {
+		var readers []uuid.UUID
+		for _, id := range userIDs {
+			if id != ticket.CreatedBy && !slices.Contains(ticket.AssignedTo, id) && !slices.Contains(ticket.Readers, id) {
+				readers = append(readers, id)
+			}
+		}
+		if len(readers) > 0 {
+			if err := s.ticketRepo.AddReaders(ctx, ticket.ID, readers); err != nil {
+				return err
+			}
+		}
+	}
+
+	author, err := s.users.GetUserByID(ctx, auth.UserID)
+	if err != nil {
+		return err
+	}
+	for _, m := range created {
+		// A failed notification must not lose the other mentions
+		if err := s.notify(ctx, mentioned[m.UserID], author, ticket, text); err != nil {
+			log.Printf("failed to notify %s of mention on ticket %s: %v", m.UserID, ticket.ID, err)
+		}
+	}
+	return nil
+}
+
+func (s *MentionService) ListMine(ctx context.Context, limit, offset int32) ([]domain.Mention, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListByUser(ctx, auth.UserID, limit, offset)
+}
+
+func (s *MentionService) notify(ctx context.Context, user domain.User, author *domain.User, ticket *domain.Ticket, text string) error {
+	if runes := []rune(text); len(runes) > mentionExcerpt {
+		text = string(runes[:mentionExcerpt]) + "…"
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "Hi %s,\n\n", user.FirstName)
+	fmt.Fprintf(&body, "%s %s mentioned you on ticket %q:\n\n", author.FirstName, author.LastName, ticket.Title)
+	fmt.Fprintf(&body, "%s\n\n", text)
+	fmt.Fprintf(&body, "%s/api/v1/ticket/%s\n", s.config.BaseURL, ticket.ID)
+
+	return s.mailer.Send(ctx, user.Email, fmt.Sprintf("You were mentioned on %q", ticket.Title), body.String())
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/nickhildpac/ticket-management-app/internal/application/authorization"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+	"github.com/nickhildpac/ticket-management-app/internal/ports"
+)
+
+type OrganizationService struct {
+	repo  ports.OrganizationRepository
+	users ports.UserRepository
+}
+
+func NewOrganizationService(r ports.OrganizationRepository, ur ports.UserRepository) *OrganizationService {
+	return &OrganizationService{repo: r, users: ur}
+}
+
+func (s *OrganizationService) ListOrganizations(ctx context.Context) ([]domain.Organization, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !authorization.CanManageOrganizations(auth) {
+		return nil, authorization.ErrAccessDenied
+	}
+
+	return s.repo.List(ctx)
+}
+
+func (s *OrganizationService) CreateOrganization(ctx context.Context, org domain.Organization) (*domain.Organization, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !authorization.CanManageOrganizations(auth) {
+		return nil, authorization.ErrAccessDenied
+	}
+
+	if err := org.Normalize(); err != nil {
+		return nil, err
+	}
+	return s.repo.Create(ctx, org)
+}
+
+// MoveUser makes orgID the user's own organization. Tickets they already
+// opened stay with the organization they were opened in.
+func (s *OrganizationService) MoveUser(ctx context.Context, userID, orgID uuid.UUID) (*domain.User, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !authorization.CanManageOrganizations(auth) {
+		return nil, authorization.ErrAccessDenied
+	}
+
+	if _, err := s.users.GetUserByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.Get(ctx, orgID); err != nil {
+		return nil, err
+	}
+	return s.repo.MoveUser(ctx, userID, orgID)
+}
+
+// GrantAccess lets an agent or admin work in another organization. It takes
+// effect the next time their token is refreshed.
+func (s *OrganizationService) GrantAccess(ctx context.Context, userID, orgID uuid.UUID) error {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !authorization.CanManageOrganizations(auth) {
+		return authorization.ErrAccessDenied
+	}
+
+	user, err := s.users.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.Role != domain.RoleAgent && user.Role != domain.RoleAdmin {
+		return domain.ErrOrgAccessNotStaff
+	}
+	if _, err := s.repo.Get(ctx, orgID); err != nil {
+		return err
+	}
+	return s.repo.GrantAccess(ctx, userID, orgID)
+}
+
+func (s *OrganizationService) RevokeAccess(ctx context.Context, userID, orgID uuid.UUID) error {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !authorization.CanManageOrganizations(auth) {
+		return authorization.ErrAccessDenied
+	}
+
+	if _, err := s.users.GetUserByID(ctx, userID); err != nil {
+		return err
+	}
+	return s.repo.RevokeAccess(ctx, userID, orgID)
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+	"github.com/nickhildpac/ticket-management-app/internal/ports"
+	"github.com/nickhildpac/ticket-management-app/pkg/configs"
+	"github.com/nickhildpac/ticket-management-app/pkg/util"
+)
+
+type PasswordService struct {
+	userRepo  ports.UserRepository
+	tokenRepo ports.UserTokenRepository
+	mailer    ports.Mailer
+	tx        ports.Transactor
+	config    *configs.Config
+}
+
+func NewPasswordService(ur ports.UserRepository, tr ports.UserTokenRepository, m ports.Mailer, tx ports.Transactor, conf *configs.Config) *PasswordService {
+	return &PasswordService{
+		userRepo:  ur,
+		tokenRepo: tr,
+		mailer:    m,
+		tx:        tx,
+		config:    conf,
+	}
+}
+
+// RequestReset replaces any reset link mailed before, so only the latest
+// one works
+func (s *PasswordService) RequestReset(ctx context.Context, email string) error {
+	user, err := s.userRepo.GetUser(ctx, strings.TrimSpace(email))
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	token, expiresAt, err := issueUserToken(ctx, s.tokenRepo, s.tx, user.ID, domain.TokenPasswordReset, s.config.PasswordResetExpiry)
+	if err != nil {
+		return err
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "Hi %s,\n\n", user.FirstName)
+	body.WriteString("Someone asked to reset the password for your account. To choose a new one, open this link:\n\n")
+	fmt.Fprintf(&body, "%s?token=%s\n\n", s.config.PasswordResetURL, url.QueryEscape(token))
+	fmt.Fprintf(&body, "The link works once and expires on %s. If you didn't ask for it, you can ignore this email.\n", expiresAt.Format(time.RFC1123))
+
+	return s.mailer.Send(ctx, user.Email, "Reset your password", body.String())
+}
+
+// ResetPassword checks the password before using up the token, so a
+// rejected password can be retried with the same link
+func (s *PasswordService) ResetPassword(ctx context.Context, token, password string) error {
+	if err := domain.ValidatePassword(password); err != nil {
+		return err
+	}
+	hashedPassword, err := util.HashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		t, err := s.tokenRepo.Consume(ctx, util.HashSecretToken(token), domain.TokenPasswordReset)
+		if err != nil {
+			return err
+		}
+		if _, err := s.userRepo.UpdatePassword(ctx, t.UserID, hashedPassword); err != nil {
+			return err
+		}
+		return s.tokenRepo.Revoke(ctx, t.UserID, domain.TokenPasswordReset)
+	})
+}
+
+// issueUserToken creates a token for the user and purpose, revoking those
+// issued before, and returns the secret to mail to them
+func issueUserToken(ctx context.Context, repo ports.UserTokenRepository, tx ports.Transactor, userID uuid.UUID, purpose domain.TokenPurpose, ttl time.Duration) (string, time.Time, error) {
+	token, hash, err := util.NewSecretToken()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	expiresAt := time.Now().Add(ttl)
+	err = tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := repo.Revoke(ctx, userID, purpose); err != nil {
+			return err
+		}
+		_, err := repo.Create(ctx, domain.UserToken{
+			UserID:    userID,
+			Purpose:   purpose,
+			TokenHash: hash,
+			ExpiresAt: expiresAt,
+		})
+		return err
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, expiresAt, nil
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+
+	"github.com/nickhildpac/ticket-management-app/internal/application/authorization"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+	"github.com/nickhildpac/ticket-management-app/internal/ports"
+)
+
+type PriorityMatrixService struct {
+	repo ports.PriorityMatrixRepository
+}
+
+func NewPriorityMatrixService(r ports.PriorityMatrixRepository) *PriorityMatrixService {
+	return &PriorityMatrixService{repo: r}
+}
+
+func (s *PriorityMatrixService) GetMatrix(ctx context.Context) (domain.PriorityMatrix, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !authorization.CanManagePriorityMatrix(auth) {
+		return nil, authorization.ErrAccessDenied
+	}
+
+	return s.repo.Get(ctx)
+}
+
+func (s *PriorityMatrixService) UpdateMatrix(ctx context.Context, entries []domain.PriorityMatrixEntry) (domain.PriorityMatrix, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !authorization.CanManagePriorityMatrix(auth) {
+		return nil, authorization.ErrAccessDenied
+	}
+
+	matrix, err := domain.NewPriorityMatrix(entries)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Save(ctx, matrix); err != nil {
+		return nil, err
+	}
+	return matrix, nil
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/nickhildpac/ticket-management-app/internal/application/authorization"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+	"github.com/nickhildpac/ticket-management-app/internal/ports"
+)
+
+// ReactionService lets people acknowledge a comment without writing one, so
+// reacting records no ticket event and notifies no one
+type ReactionService struct {
+	repo        ports.ReactionRepository
+	commentRepo ports.CommentRepository
+	ticketRepo  ports.TicketRepository
+}
+
+func NewReactionService(r ports.ReactionRepository, cr ports.CommentRepository, tr ports.TicketRepository) *ReactionService {
+	return &ReactionService{repo: r, commentRepo: cr, ticketRepo: tr}
+}
+
+// authorize checks that the caller may react to the comment. A comment the
+// caller can't see is reported as not found, like GetComment does.
+func (s *ReactionService) authorize(ctx context.Context, commentID uuid.UUID) (authorization.AuthContext, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return auth, err
+	}
+
+	comment, err := s.commentRepo.Get(ctx, commentID)
+	if err != nil {
+		return auth, err
+	}
+
+	ticket, err := s.ticketRepo.Get(ctx, comment.TicketID)
+	if err != nil {
+		return auth, err
+	}
+
+	if !authorization.CanViewComment(auth, ticket, comment) {
+		return auth, domain.ErrCommentNotFound
+	}
+	if !authorization.CanCommentOnTicket(auth, ticket) {
+		return auth, authorization.ErrAccessDenied
+	}
+	return auth, nil
+}
+
+func (s *ReactionService) React(ctx context.Context, commentID uuid.UUID, emoji string) ([]domain.Reaction, error) {
+	emoji, err := domain.NormalizeReaction(emoji)
+	if err != nil {
+		return nil, err
+	}
+	auth, err := s.authorize(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Add(ctx, commentID, auth.UserID, emoji); err != nil {
+		return nil, err
+	}
+	return s.summarizeOne(ctx, commentID, auth.UserID)
+}
+
+func (s *ReactionService) Unreact(ctx context.Context, commentID uuid.UUID, emoji string) ([]domain.Reaction, error) {
+	emoji, err := domain.NormalizeReaction(emoji)
+	if err != nil {
+		return nil, err
+	}
+	auth, err := s.authorize(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Remove(ctx, commentID, auth.UserID, emoji); err != nil {
+		return nil, err
+	}
+	return s.summarizeOne(ctx, commentID, auth.UserID)
+}
+
+func (s *ReactionService) summarizeOne(ctx context.Context, commentID, userID uuid.UUID) ([]domain.Reaction, error) {
+	reactions, err := s.repo.Summarize(ctx, []uuid.UUID{commentID}, userID)
+	if err != nil {
+		return nil, err
+	}
+	if reactions[commentID] == nil {
+		return []domain.Reaction{}, nil
+	}
+	return reactions[commentID], nil
+}
+
+func (s *ReactionService) Summarize(ctx context.Context, commentIDs []uuid.UUID) (map[uuid.UUID][]domain.Reaction, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(commentIDs) == 0 {
+		return map[uuid.UUID][]domain.Reaction{}, nil
+	}
+	return s.repo.Summarize(ctx, commentIDs, auth.UserID)
+}
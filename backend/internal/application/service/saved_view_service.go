@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nickhildpac/ticket-management-app/internal/application/authorization"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+	"github.com/nickhildpac/ticket-management-app/internal/ports"
+)
+
+type SavedViewService struct {
+	repo          ports.SavedViewRepository
+	teams         ports.TeamRepository
+	ticketService ports.TicketService
+}
+
+func NewSavedViewService(r ports.SavedViewRepository, tr ports.TeamRepository, ts ports.TicketService) *SavedViewService {
+	return &SavedViewService{repo: r, teams: tr, ticketService: ts}
+}
+
+func (s *SavedViewService) ListViews(ctx context.Context) ([]domain.SavedView, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.ListVisible(ctx, auth.UserID, auth.OrgID, auth.Role, auth.TeamIDs)
+}
+
+func (s *SavedViewService) GetView(ctx context.Context, id uuid.UUID) (*domain.SavedView, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	view, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !authorization.CanViewSavedView(auth, view) {
+		return nil, authorization.ErrAccessDenied
+	}
+	return view, nil
+}
+
+func (s *SavedViewService) CreateView(ctx context.Context, view domain.SavedView) (*domain.SavedView, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	view.OwnerID = auth.UserID
+	view.OrgID = auth.OrgID
+	if err := s.validateView(ctx, auth, &view); err != nil {
+		return nil, err
+	}
+	view.UpdatedAt = time.Now()
+	return s.repo.Create(ctx, view)
+}
+
+func (s *SavedViewService) UpdateView(ctx context.Context, view domain.SavedView) (*domain.SavedView, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	prev, err := s.repo.Get(ctx, view.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !authorization.CanManageSavedView(auth, prev) {
+		return nil, authorization.ErrAccessDenied
+	}
+
+	view.OwnerID = prev.OwnerID
+	view.OrgID = prev.OrgID
+	if err := s.validateView(ctx, auth, &view); err != nil {
+		return nil, err
+	}
+	view.UpdatedAt = time.Now()
+	return s.repo.Update(ctx, view)
+}
+
+func (s *SavedViewService) DeleteView(ctx context.Context, id uuid.UUID) error {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	view, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !authorization.CanManageSavedView(auth, view) {
+		return authorization.ErrAccessDenied
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// ListTickets evaluates the view for the caller. Results go through the same
+// role scoping as the ticket list, so a shared view never shows a viewer
+// more than they could see on their own.
+func (s *SavedViewService) ListTickets(ctx context.Context, id uuid.UUID, limit, offset int32) ([]domain.Ticket, error) {
+	view, err := s.GetView(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.ticketService.ListFiltered(ctx, view.Filter, limit, offset)
+}
+
+// validateView checks a view before it is saved. A team it is shared with
+// must be in the view's organization.
+func (s *SavedViewService) validateView(ctx context.Context, auth authorization.AuthContext, view *domain.SavedView) error {
+	view.Name = strings.TrimSpace(view.Name)
+	if view.Name == "" {
+		return domain.ErrEmptyViewName
+	}
+	if err := view.Filter.Sort.Validate(); err != nil {
+		return err
+	}
+	if err := domain.ValidateColumns(view.Columns); err != nil {
+		return err
+	}
+	if len(view.Columns) == 0 {
+		view.Columns = domain.TicketColumns
+	}
+	if view.SharedRole != nil && !authorization.CanShareViewWithRole(auth, *view.SharedRole) {
+		return authorization.ErrAccessDenied
+	}
+	if view.SharedTeamID != nil {
+		if !authorization.CanShareViewWithTeam(auth, *view.SharedTeamID) {
+			return authorization.ErrAccessDenied
+		}
+		team, err := s.teams.Get(ctx, *view.SharedTeamID)
+		if err != nil {
+			return err
+		}
+		if team.OrgID != view.OrgID {
+			return domain.ErrViewTeamOrgMismatch
+		}
+	}
+	return nil
+}
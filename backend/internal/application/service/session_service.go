@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+	"github.com/nickhildpac/ticket-management-app/internal/ports"
+	"github.com/nickhildpac/ticket-management-app/pkg/configs"
+	"github.com/nickhildpac/ticket-management-app/pkg/util"
+)
+
+type SessionService struct {
+	repo   ports.RefreshTokenRepository
+	config *configs.Config
+}
+
+func NewSessionService(r ports.RefreshTokenRepository, conf *configs.Config) *SessionService {
+	return &SessionService{repo: r, config: conf}
+}
+
+func (s *SessionService) Record(ctx context.Context, userID, familyID uuid.UUID, token string) error {
+	_, err := s.repo.Create(ctx, domain.RefreshToken{
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: util.HashSecretToken(token),
+		ExpiresAt: time.Now().Add(s.config.RefreshExpiry),
+	})
+	return err
+}
+
+// Rotate revokes the whole family when a used token comes back: either the
+// user or whoever stole the token already refreshed with it, and there is
+// no telling which.
+func (s *SessionService) Rotate(ctx context.Context, token string) (*domain.RefreshToken, error) {
+	stored, err := s.repo.GetByHash(ctx, util.HashSecretToken(token))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stored.Check(time.Now()); err != nil {
+		return nil, s.reject(ctx, stored, err)
+	}
+	used, err := s.repo.Use(ctx, stored.ID)
+	if err != nil {
+		return nil, s.reject(ctx, stored, err)
+	}
+	return used, nil
+}
+
+// reject returns err, having revoked the token's family if err says the
+// token was reused
+func (s *SessionService) reject(ctx context.Context, token *domain.RefreshToken, err error) error {
+	if !errors.Is(err, domain.ErrRefreshTokenReused) {
+		return err
+	}
+	log.Printf("refresh token reused for user %s; revoking family %s", token.UserID, token.FamilyID)
+	if revokeErr := s.repo.RevokeFamily(ctx, token.FamilyID); revokeErr != nil {
+		return revokeErr
+	}
+	return err
+}
+
+// Revoke ends the family of the token. Unknown tokens are ignored, so
+// logging out twice is fine.
+func (s *SessionService) Revoke(ctx context.Context, token string) error {
+	stored, err := s.repo.GetByHash(ctx, util.HashSecretToken(token))
+	if errors.Is(err, domain.ErrInvalidRefreshToken) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return s.repo.RevokeFamily(ctx, stored.FamilyID)
+}
+
+func (s *SessionService) RevokeUser(ctx context.Context, userID uuid.UUID) error {
+	return s.repo.RevokeUser(ctx, userID)
+}
+
+func (s *SessionService) PurgeExpired(ctx context.Context) error {
+	return s.repo.DeleteExpired(ctx)
+}
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/nickhildpac/ticket-management-app/internal/application/authorization"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+	"github.com/nickhildpac/ticket-management-app/internal/ports"
+)
+
+type TeamService struct {
+	repo  ports.TeamRepository
+	users ports.UserRepository
+}
+
+func NewTeamService(r ports.TeamRepository, ur ports.UserRepository) *TeamService {
+	return &TeamService{repo: r, users: ur}
+}
+
+func (s *TeamService) ListTeams(ctx context.Context) ([]domain.Team, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !authorization.CanViewTeams(auth) {
+		return nil, authorization.ErrAccessDenied
+	}
+
+	return s.repo.List(ctx)
+}
+
+func (s *TeamService) GetTeam(ctx context.Context, id uuid.UUID) (*domain.Team, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !authorization.CanViewTeams(auth) {
+		return nil, authorization.ErrAccessDenied
+	}
+
+	team, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !authorization.CanAccessOrg(auth, team.OrgID) {
+		return nil, authorization.ErrAccessDenied
+	}
+
+	return team, nil
+}
+
+// CreateTeam creates a team in the caller's own organization unless another
+// one is given. The lead, if any, is added as the first member.
+func (s *TeamService) CreateTeam(ctx context.Context, team domain.Team) (*domain.Team, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if team.OrgID == uuid.Nil {
+		team.OrgID = auth.OrgID
+	}
+	if !authorization.CanManageTeam(auth, &team) {
+		return nil, authorization.ErrAccessDenied
+	}
+
+	if err := team.Normalize(); err != nil {
+		return nil, err
+	}
+	if team.LeadID != nil {
+		if err := s.checkMember(ctx, &team, *team.LeadID); err != nil {
+			return nil, err
+		}
+	}
+
+	created, err := s.repo.Create(ctx, team)
+	if err != nil {
+		return nil, err
+	}
+	if created.LeadID == nil {
+		return created, nil
+	}
+	if err := s.repo.AddMember(ctx, created.ID, *created.LeadID); err != nil {
+		return nil, err
+	}
+	return s.repo.Get(ctx, created.ID)
+}
+
+// UpdateTeam renames the team and changes its lead. A new lead joins the
+// team if they weren't on it already.
+func (s *TeamService) UpdateTeam(ctx context.Context, team domain.Team) (*domain.Team, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	prev, err := s.repo.Get(ctx, team.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !authorization.CanManageTeam(auth, prev) {
+		return nil, authorization.ErrAccessDenied
+	}
+
+	team.OrgID = prev.OrgID
+	if err := team.Normalize(); err != nil {
+		return nil, err
+	}
+	if team.LeadID != nil && !prev.IsMember(*team.LeadID) {
+		if err := s.checkMember(ctx, prev, *team.LeadID); err != nil {
+			return nil, err
+		}
+		if err := s.repo.AddMember(ctx, team.ID, *team.LeadID); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.repo.Update(ctx, team)
+}
+
+func (s *TeamService) DeleteTeam(ctx context.Context, id uuid.UUID) error {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	team, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if !authorization.CanManageTeam(auth, team) {
+		return authorization.ErrAccessDenied
+	}
+
+	return s.repo.Delete(ctx, id)
+}
+
+// AddMember puts an agent or admin on the team. Their new tickets show up
+// once their token is refreshed.
+func (s *TeamService) AddMember(ctx context.Context, teamID, userID uuid.UUID) (*domain.Team, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	team, err := s.repo.Get(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !authorization.CanManageTeamMembers(auth, team) {
+		return nil, authorization.ErrAccessDenied
+	}
+
+	if err := s.checkMember(ctx, team, userID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.AddMember(ctx, teamID, userID); err != nil {
+		return nil, err
+	}
+	return s.repo.Get(ctx, teamID)
+}
+
+func (s *TeamService) RemoveMember(ctx context.Context, teamID, userID uuid.UUID) (*domain.Team, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	team, err := s.repo.Get(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !authorization.CanManageTeamMembers(auth, team) {
+		return nil, authorization.ErrAccessDenied
+	}
+
+	if team.IsLead(userID) {
+		return nil, domain.ErrRemoveTeamLead
+	}
+	if err := s.repo.RemoveMember(ctx, teamID, userID); err != nil {
+		return nil, err
+	}
+	return s.repo.Get(ctx, teamID)
+}
+
+// checkMember returns an error unless the user is an agent or admin who can
+// work in the team's organization
+func (s *TeamService) checkMember(ctx context.Context, team *domain.Team, userID uuid.UUID) error {
+	user, err := s.users.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.Role != domain.RoleAgent && user.Role != domain.RoleAdmin {
+		return domain.ErrTeamMemberNotStaff
+	}
+	if user.OrgID == team.OrgID {
+		return nil
+	}
+
+	granted, err := s.users.ListOrgAccess(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(granted, team.OrgID) {
+		return domain.ErrTeamMemberOrg
+	}
+	return nil
+}
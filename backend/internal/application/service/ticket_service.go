@@ -2,45 +2,81 @@ package service
 
 import (
 	"context"
+	"errors"
 	"log"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/nickhildpac/ticket-management-app/internal/application/authorization"
 	"github.com/nickhildpac/ticket-management-app/internal/domain"
 	"github.com/nickhildpac/ticket-management-app/internal/ports"
+	"github.com/nickhildpac/ticket-management-app/pkg/configs"
+)
+
+const (
+	maxDuplicateSuggestions = 5
+	// duplicateCandidates is fetched before visibility filtering so that
+	// tickets the viewer can't see don't crowd out the ones they can
+	duplicateCandidates = 50
 )
 
 type TicketService struct {
-	repo ports.TicketRepository
+	repo         ports.TicketRepository
+	events       ports.TicketEventRepository
+	matrix       ports.PriorityMatrixRepository
+	checklist    ports.ChecklistRepository
+	approvals    ports.ApprovalRepository
+	teams        ports.TeamRepository
+	availability ports.AvailabilityService
+	csat         ports.CSATService
+	mentions     ports.MentionService
+	tx           ports.Transactor
+	verification domain.VerificationPolicy
 }
 
-func NewTicketService(repo ports.TicketRepository) *TicketService {
-	return &TicketService{repo: repo}
+func NewTicketService(repo ports.TicketRepository, events ports.TicketEventRepository, matrix ports.PriorityMatrixRepository, checklist ports.ChecklistRepository, approvals ports.ApprovalRepository, teams ports.TeamRepository, availability ports.AvailabilityService, csat ports.CSATService, mentions ports.MentionService, tx ports.Transactor, conf *configs.Config) *TicketService {
+	return &TicketService{repo: repo, events: events, matrix: matrix, checklist: checklist, approvals: approvals, teams: teams, availability: availability, csat: csat, mentions: mentions, tx: tx, verification: domain.VerificationPolicy(conf.VerificationPolicy)}
+}
+
+// scope returns the tickets a role may list: admins see everything, users
+// see what they created and agents see what is assigned to them or queued
+// to their teams
+func scope(auth authorization.AuthContext) (domain.TicketScope, error) {
+	switch auth.Role {
+	case domain.RoleAdmin:
+		return domain.TicketScope{}, nil
+	case domain.RoleUser:
+		return domain.TicketScope{CreatedBy: &auth.UserID}, nil
+	case domain.RoleAgent:
+		return domain.TicketScope{AssignedTo: &auth.UserID, TeamIDs: auth.TeamIDs}, nil
+	default:
+		return domain.TicketScope{}, authorization.ErrAccessDenied
+	}
 }
 
 func (s *TicketService) ListAll(ctx context.Context, limit, offset int32) ([]domain.Ticket, error) {
+	return s.ListFiltered(ctx, domain.TicketFilter{}, limit, offset)
+}
+
+// ListFiltered lists the tickets matching filter within the caller's scope.
+// "Assigned to me" and "created by me" are resolved against the caller.
+func (s *TicketService) ListFiltered(ctx context.Context, filter domain.TicketFilter, limit, offset int32) ([]domain.Ticket, error) {
 	auth, err := authorization.GetAuthContext(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	// Admins can see all tickets
-	if auth.Role == domain.RoleAdmin {
-		return s.repo.ListAll(ctx, limit, offset)
-	}
-
-	// Users can only see their own tickets
-	if auth.Role == domain.RoleUser {
-		return s.repo.ListByCreator(ctx, auth.UserID, limit, offset)
+	sc, err := scope(auth)
+	if err != nil {
+		return nil, err
 	}
-
-	// Agents can only see assigned tickets
-	if auth.Role == domain.RoleAgent {
-		return s.repo.ListByAssignee(ctx, auth.UserID, limit, offset)
+	if err := filter.Sort.Validate(); err != nil {
+		return nil, err
 	}
 
-	return nil, authorization.ErrAccessDenied
+	return s.repo.List(ctx, sc, filter.Resolve(auth.UserID), limit, offset)
 }
 
 func (s *TicketService) ListByCreator(ctx context.Context, id uuid.UUID, limit, offset int32) ([]domain.Ticket, error) {
@@ -71,6 +107,28 @@ func (s *TicketService) ListByAssignee(ctx context.Context, id uuid.UUID, limit,
 	return s.repo.ListByAssignee(ctx, id, limit, offset)
 }
 
+// ListByTeam lists the tickets queued to a team, or to any of the caller's
+// teams when teamID is nil
+func (s *TicketService) ListByTeam(ctx context.Context, teamID *uuid.UUID, limit, offset int32) ([]domain.Ticket, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	teamIDs := auth.TeamIDs
+	if teamID != nil {
+		if !authorization.CanViewTeamQueue(auth, *teamID) {
+			return nil, authorization.ErrAccessDenied
+		}
+		teamIDs = []uuid.UUID{*teamID}
+	}
+	if len(teamIDs) == 0 {
+		return []domain.Ticket{}, nil
+	}
+
+	return s.repo.ListByTeams(ctx, teamIDs, limit, offset)
+}
+
 func (s *TicketService) GetTicket(ctx context.Context, id uuid.UUID) (*domain.Ticket, error) {
 	auth, err := authorization.GetAuthContext(ctx)
 	if err != nil {
@@ -90,10 +148,66 @@ func (s *TicketService) GetTicket(ctx context.Context, id uuid.UUID) (*domain.Ti
 }
 
 func (s *TicketService) CreateTicket(ctx context.Context, ticket domain.Ticket) (*domain.Ticket, error) {
+	if s.verification == domain.VerificationPolicyTickets {
+		auth, err := authorization.GetAuthContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if auth.Unverified {
+			return nil, domain.ErrEmailNotVerified
+		}
+	}
+
+	if err := domain.CheckDescriptionLength(ticket.Description); err != nil {
+		return nil, err
+	}
+	if ticket.Impact == 0 {
+		ticket.Impact = domain.TicketImpactLow
+	}
+	if ticket.Urgency == 0 {
+		ticket.Urgency = domain.TicketUrgencyLow
+	}
+	if ticket.Type == "" {
+		ticket.Type = domain.DefaultTicketType
+	}
+	if err := domain.ValidateTicketType(ticket.Type); err != nil {
+		return nil, err
+	}
+
+	matrix, err := s.matrix.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rank, err := s.bottomRank(ctx, domain.TicketStateOpen)
+	if err != nil {
+		return nil, err
+	}
+
 	ticket.State = domain.TicketStateOpen
-	ticket.Priority = domain.TicketPriorityLow
+	ticket.Priority = matrix.Priority(ticket.Impact, ticket.Urgency)
+	ticket.Rank = rank
 	ticket.UpdatedAt = time.Now()
-	return s.repo.Create(ctx, ticket)
+	// The ticket is only created with the approval steps of its type
+	var created *domain.Ticket
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if created, err = s.repo.Create(ctx, ticket); err != nil {
+			return err
+		}
+		return s.approvals.CreateForTicket(ctx, created.ID, created.Type)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Mentions are best effort and must not fail ticket creation
+	s.tx.AfterCommit(ctx, func(ctx context.Context) {
+		if err := s.mentions.Record(ctx, created, nil); err != nil {
+			log.Printf("failed to record mentions on ticket %s: %v", created.ID, err)
+		}
+	})
+	return created, nil
 }
 
 func (s *TicketService) UpdateTicket(ctx context.Context, ticket domain.Ticket, updatedFields []string) (*domain.Ticket, error) {
@@ -123,10 +237,48 @@ func (s *TicketService) UpdateTicket(ctx context.Context, ticket domain.Ticket,
 			if !authorization.CanUpdateTicketPriority(auth, prev) {
 				return nil, authorization.ErrAccessDenied
 			}
-		case "assigned_to":
+		case "impact", "urgency":
+			if !authorization.CanUpdateTicketImpact(auth, prev) {
+				return nil, authorization.ErrAccessDenied
+			}
+		case "team_id":
 			if !authorization.CanAssignTicket(auth, prev) {
 				return nil, authorization.ErrAccessDenied
 			}
+		case "assigned_to":
+			if authorization.CanAssignTicket(auth, prev) {
+				break
+			}
+			// Agents may claim a ticket queued to their team, and nothing more
+			if !authorization.CanClaimTicket(auth, prev) || !domain.IsClaim(prev.AssignedTo, ticket.AssignedTo, auth.UserID) {
+				return nil, authorization.ErrAccessDenied
+			}
+			if err := s.checkTeamMember(ctx, *prev.TeamID, auth.UserID); err != nil {
+				return nil, err
+			}
+		case "rank":
+			if !authorization.CanRankTicket(auth, prev) {
+				return nil, authorization.ErrAccessDenied
+			}
+		}
+	}
+
+	// A ticket can only be queued to a team of its own organization
+	if slices.Contains(updatedFields, "team_id") && ticket.TeamID != nil {
+		team, err := s.teams.Get(ctx, *ticket.TeamID)
+		if err != nil {
+			return nil, err
+		}
+		if team.OrgID != prev.OrgID {
+			return nil, domain.ErrTeamOrgMismatch
+		}
+	}
+
+	// New assignees who are out of office are swapped for their delegate
+	if slices.Contains(updatedFields, "assigned_to") {
+		ticket.AssignedTo, err = s.availability.RouteAssignees(ctx, prev.AssignedTo, ticket.AssignedTo)
+		if err != nil {
+			return nil, err
 		}
 	}
 
@@ -136,16 +288,168 @@ func (s *TicketService) UpdateTicket(ctx context.Context, ticket domain.Ticket,
 		if ok := domain.CanTransition(prev.State, ticket.State); !ok {
 			return nil, domain.ErrInvalidStatusTransition
 		}
+
+		var code domain.ResolutionCode
+		var reason string
+		if slices.Contains(updatedFields, "resolution_code") {
+			code = ticket.ResolutionCode
+		}
+		if slices.Contains(updatedFields, "reason") {
+			reason = ticket.StateReason
+		}
+		if err := domain.ValidateTransitionReason(prev.State, ticket.State, code, reason); err != nil {
+			return nil, err
+		}
+
+		if ticket.State == domain.TicketStatePending {
+			if err := s.checkApprovals(ctx, ticket.ID); err != nil {
+				return nil, err
+			}
+		}
+
+		if ticket.State == domain.TicketStateResolved {
+			progress, err := s.checklist.Progress(ctx, ticket.ID)
+			if err != nil {
+				return nil, err
+			}
+			if progress.RequiredOpen > 0 {
+				return nil, domain.ErrChecklistIncomplete
+			}
+		}
+
+		// Closing keeps the resolution it was resolved with; reopening clears it
+		switch {
+		case ticket.State.RequiresResolutionCode():
+			ticket.ResolutionCode = code
+		case ticket.State == domain.TicketStateClosed:
+			ticket.ResolutionCode = prev.ResolutionCode
+		default:
+			ticket.ResolutionCode = ""
+		}
+		ticket.StateReason = reason
+	} else {
+		ticket.ResolutionCode = prev.ResolutionCode
+		ticket.StateReason = prev.StateReason
 	}
 
-	// Auto-transition to pending when assigned
+	// Priority follows impact and urgency unless an admin has overridden it
+	if !ticket.PriorityOverridden {
+		matrix, err := s.matrix.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		ticket.Priority = matrix.Priority(ticket.Impact, ticket.Urgency)
+	}
+
+	// Auto-transition to pending when assigned, unless the ticket is still
+	// waiting on approval, in which case it keeps the assignee and stays open
 	if len(ticket.AssignedTo) > 0 && len(prev.AssignedTo) == 0 {
-		ticket.State = domain.TicketStatePending
+		err := s.checkApprovals(ctx, ticket.ID)
+		switch {
+		case err == nil:
+			ticket.State = domain.TicketStatePending
+		case !errors.Is(err, domain.ErrApprovalRequired) && !errors.Is(err, domain.ErrApprovalRejected):
+			return nil, err
+		}
+	}
+
+	// A ticket that changes column without being placed goes to the bottom
+	if !slices.Contains(updatedFields, "rank") {
+		ticket.Rank = prev.Rank
+		if ticket.State != prev.State {
+			if ticket.Rank, err = s.bottomRank(ctx, ticket.State); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if ticket.Description != prev.Description {
+		if err := domain.CheckDescriptionLength(ticket.Description); err != nil {
+			return nil, err
+		}
 	}
 
 	ticket.CreatedAt = prev.CreatedAt
 	ticket.UpdatedAt = time.Now()
-	return s.repo.Update(ctx, ticket)
+	// The change and its history are saved together or not at all
+	var updated *domain.Ticket
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if updated, err = s.repo.Update(ctx, ticket); err != nil {
+			return err
+		}
+
+		if updated.State != prev.State {
+			_, err := s.events.Create(ctx, domain.TicketEvent{
+				TicketID: updated.ID,
+				ActorID:  auth.UserID,
+				Kind:     domain.TicketEventStateChanged,
+				OldValue: prev.State.String(),
+				NewValue: updated.State.String(),
+				Reason:   string(updated.ResolutionCode),
+				Note:     updated.StateReason,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if slices.Contains(updatedFields, "priority") &&
+			(updated.Priority != prev.Priority || updated.PriorityOverridden != prev.PriorityOverridden) {
+			newValue := updated.Priority.String()
+			if !updated.PriorityOverridden {
+				newValue = "auto (" + newValue + ")"
+			}
+			_, err := s.events.Create(ctx, domain.TicketEvent{
+				TicketID: updated.ID,
+				ActorID:  auth.UserID,
+				Kind:     domain.TicketEventPriorityOverridden,
+				OldValue: prev.Priority.String(),
+				NewValue: newValue,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Mentions and surveys wait until the update is committed, as the
+	// update may be part of a comment or macro that is still being applied
+	if updated.Description != prev.Description {
+		s.tx.AfterCommit(ctx, func(ctx context.Context) {
+			if err := s.mentions.Record(ctx, updated, nil); err != nil {
+				log.Printf("failed to record mentions on ticket %s: %v", updated.ID, err)
+			}
+		})
+	}
+
+	// Ask the creator to rate the resolution; a failed survey must not fail the update
+	if prev.State != domain.TicketStateResolved && updated.State == domain.TicketStateResolved {
+		s.tx.AfterCommit(ctx, func(ctx context.Context) {
+			if err := s.csat.SendSurvey(ctx, *updated); err != nil {
+				log.Printf("failed to send CSAT survey for ticket %s: %v", updated.ID, err)
+			}
+		})
+	}
+
+	return updated, nil
+}
+
+// checkTeamMember confirms the user is still on the team. The teams in the
+// caller's token may be out of date.
+func (s *TicketService) checkTeamMember(ctx context.Context, teamID, userID uuid.UUID) error {
+	team, err := s.teams.Get(ctx, teamID)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(team.Members, userID) {
+		return authorization.ErrAccessDenied
+	}
+	return nil
 }
 
 func (s *TicketService) DeleteTicket(ctx context.Context, id uuid.UUID) error {
@@ -165,3 +469,168 @@ func (s *TicketService) DeleteTicket(ctx context.Context, id uuid.UUID) error {
 
 	return s.repo.Delete(ctx, id)
 }
+
+func (s *TicketService) ListEvents(ctx context.Context, ticketID uuid.UUID, limit, offset int32) ([]domain.TicketEvent, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ticket, err := s.repo.Get(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !authorization.CanViewTicket(auth, ticket) {
+		return nil, authorization.ErrAccessDenied
+	}
+
+	events, err := s.events.ListByTicket(ctx, ticketID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (s *TicketService) ResolutionReport(ctx context.Context) ([]domain.ResolutionCount, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !authorization.CanViewReports(auth) {
+		return nil, authorization.ErrAccessDenied
+	}
+
+	return s.repo.CountByResolution(ctx)
+}
+
+// FindDuplicates suggests open tickets that resemble the given text, limited
+// to the ones the caller is allowed to view
+func (s *TicketService) FindDuplicates(ctx context.Context, title, description string) ([]domain.TicketMatch, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(title) == "" && strings.TrimSpace(description) == "" {
+		return []domain.TicketMatch{}, nil
+	}
+
+	candidates, err := s.repo.FindSimilar(ctx, title, description, duplicateCandidates)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([]domain.TicketMatch, 0, maxDuplicateSuggestions)
+	for _, match := range candidates {
+		if !authorization.CanViewTicket(auth, &match.Ticket) {
+			continue
+		}
+		matches = append(matches, match)
+		if len(matches) == maxDuplicateSuggestions {
+			break
+		}
+	}
+	return matches, nil
+}
+
+// Board returns the caller's tickets grouped by state, each column in rank
+// order and holding at most limit tickets
+func (s *TicketService) Board(ctx context.Context, limit int32) ([]domain.BoardColumn, error) {
+	board := make([]domain.BoardColumn, 0, len(domain.BoardColumns))
+	for _, state := range domain.BoardColumns {
+		filter := domain.TicketFilter{States: []domain.TicketState{state}, Sort: "rank"}
+		tickets, err := s.ListFiltered(ctx, filter, limit, 0)
+		if err != nil {
+			return nil, err
+		}
+		board = append(board, domain.BoardColumn{State: state, Tickets: tickets})
+	}
+	return board, nil
+}
+
+// MoveTicket changes the state of a ticket and places it between its new
+// neighbours in one update, so the usual transition rules still apply
+func (s *TicketService) MoveTicket(ctx context.Context, move domain.TicketMove) (*domain.Ticket, error) {
+	ticket, err := s.GetTicket(ctx, move.TicketID)
+	if err != nil {
+		return nil, err
+	}
+
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	prevRank, err := s.neighbourRank(ctx, auth, move, move.AfterID)
+	if err != nil {
+		return nil, err
+	}
+	nextRank, err := s.neighbourRank(ctx, auth, move, move.BeforeID)
+	if err != nil {
+		return nil, err
+	}
+	if move.AfterID == nil && move.BeforeID == nil {
+		if prevRank, err = s.repo.LastRank(ctx, move.State); err != nil {
+			return nil, err
+		}
+	}
+
+	rank, err := domain.RankBetween(prevRank, nextRank)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := []string{"rank"}
+	if move.State != ticket.State {
+		fields = append(fields, "state", "resolution_code", "reason")
+	}
+	ticket.State = move.State
+	ticket.ResolutionCode = move.ResolutionCode
+	ticket.StateReason = move.Reason
+	ticket.Rank = rank
+	return s.UpdateTicket(ctx, *ticket, fields)
+}
+
+// neighbourRank returns the rank of a ticket next to the drop point, or ""
+// when there is none. A neighbour the caller can't see is reported as not
+// found, so moves can't be used to learn about other tickets.
+func (s *TicketService) neighbourRank(ctx context.Context, auth authorization.AuthContext, move domain.TicketMove, id *uuid.UUID) (string, error) {
+	if id == nil {
+		return "", nil
+	}
+	if *id == move.TicketID {
+		return "", domain.ErrInvalidPosition
+	}
+
+	neighbour, err := s.repo.Get(ctx, *id)
+	if err != nil {
+		return "", err
+	}
+	if !authorization.CanViewTicket(auth, neighbour) {
+		return "", domain.ErrTicketNotFound
+	}
+	if neighbour.State != move.State {
+		return "", domain.ErrInvalidPosition
+	}
+	return neighbour.Rank, nil
+}
+
+// checkApprovals returns nil once every approval step of the ticket is approved
+func (s *TicketService) checkApprovals(ctx context.Context, ticketID uuid.UUID) error {
+	approvals, err := s.approvals.ListByTicket(ctx, ticketID)
+	if err != nil {
+		return err
+	}
+	return domain.CheckApprovals(approvals)
+}
+
+// bottomRank returns a rank below every ticket in the column
+func (s *TicketService) bottomRank(ctx context.Context, state domain.TicketState) (string, error) {
+	last, err := s.repo.LastRank(ctx, state)
+	if err != nil {
+		return "", err
+	}
+	return domain.RankBetween(last, "")
+}
@@ -0,0 +1,272 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nickhildpac/ticket-management-app/internal/application/authorization"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+	"github.com/nickhildpac/ticket-management-app/internal/ports"
+)
+
+type TransferService struct {
+	repo       ports.TransferRepository
+	ticketRepo ports.TicketRepository
+	teams      ports.TeamRepository
+	users      ports.UserRepository
+	events     ports.TicketEventRepository
+}
+
+func NewTransferService(r ports.TransferRepository, tr ports.TicketRepository, tm ports.TeamRepository, ur ports.UserRepository, er ports.TicketEventRepository) *TransferService {
+	return &TransferService{repo: r, ticketRepo: tr, teams: tm, users: ur, events: er}
+}
+
+func (s *TransferService) RequestTransfer(ctx context.Context, transfer domain.Transfer) (*domain.Transfer, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ticket, err := s.ticketRepo.Get(ctx, transfer.TicketID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !authorization.CanTransferTicket(auth, ticket) {
+		return nil, authorization.ErrAccessDenied
+	}
+
+	transfer.FromUserID = auth.UserID
+	if err := transfer.Validate(); err != nil {
+		return nil, err
+	}
+	if ticket.State != domain.TicketStateOpen && ticket.State != domain.TicketStatePending {
+		return nil, domain.ErrTransferTicketClosed
+	}
+	if err := s.checkReceiver(ctx, ticket, &transfer); err != nil {
+		return nil, err
+	}
+
+	pending, err := s.repo.GetPending(ctx, ticket.ID)
+	if err != nil {
+		return nil, err
+	}
+	if pending != nil {
+		return nil, domain.ErrTransferPending
+	}
+
+	created, err := s.repo.Create(ctx, transfer)
+	if err != nil {
+		return nil, err
+	}
+
+	receiver := created.ToTeamID
+	if created.ToUserID != nil {
+		receiver = created.ToUserID
+	}
+	_, err = s.events.Create(ctx, domain.TicketEvent{
+		TicketID: ticket.ID,
+		ActorID:  auth.UserID,
+		Kind:     domain.TicketEventTransferRequested,
+		OldValue: auth.UserID.String(),
+		NewValue: receiver.String(),
+		Note:     created.Note,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// Decide accepts or declines the transfer. The ticket only changes hands
+// once the transfer has been marked accepted, so two team members accepting
+// at once can't both take it.
+func (s *TransferService) Decide(ctx context.Context, id uuid.UUID, accept bool, reason string) (*domain.Transfer, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	transfer, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	ticket, err := s.ticketRepo.Get(ctx, transfer.TicketID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !authorization.CanDecideTransfer(auth, ticket, transfer) {
+		return nil, authorization.ErrAccessDenied
+	}
+
+	if transfer.Status != domain.TransferPending {
+		return nil, domain.ErrNoPendingTransfer
+	}
+
+	transfer.Status = domain.TransferDeclined
+	if accept {
+		transfer.Status = domain.TransferAccepted
+	}
+	decided, err := s.decide(ctx, transfer, auth.UserID, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	if accept {
+		if _, err := s.ticketRepo.HandOff(ctx, ticket.ID, decided.FromUserID, auth.UserID, decided.ToTeamID); err != nil {
+			return nil, err
+		}
+	}
+	return decided, nil
+}
+
+func (s *TransferService) CancelTransfer(ctx context.Context, id uuid.UUID) (*domain.Transfer, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	transfer, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	ticket, err := s.ticketRepo.Get(ctx, transfer.TicketID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !authorization.CanCancelTransfer(auth, ticket, transfer) {
+		return nil, authorization.ErrAccessDenied
+	}
+
+	if transfer.Status != domain.TransferPending {
+		return nil, domain.ErrNoPendingTransfer
+	}
+
+	transfer.Status = domain.TransferCancelled
+	return s.decide(ctx, transfer, auth.UserID, "")
+}
+
+// ListForTicket returns the transfers of a ticket to anyone who can see the
+// ticket or is receiving one of them
+func (s *TransferService) ListForTicket(ctx context.Context, ticketID uuid.UUID) ([]domain.Transfer, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ticket, err := s.ticketRepo.Get(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	transfers, err := s.repo.ListByTicket(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	if authorization.CanViewTicket(auth, ticket) {
+		return transfers, nil
+	}
+	for _, t := range transfers {
+		if authorization.CanDecideTransfer(auth, ticket, &t) {
+			return transfers, nil
+		}
+	}
+	return nil, authorization.ErrAccessDenied
+}
+
+func (s *TransferService) Pending(ctx context.Context, ticketID uuid.UUID) (*domain.Transfer, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ticket, err := s.ticketRepo.Get(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !authorization.CanViewTicket(auth, ticket) {
+		return nil, authorization.ErrAccessDenied
+	}
+
+	return s.repo.GetPending(ctx, ticketID)
+}
+
+func (s *TransferService) ListIncoming(ctx context.Context) ([]domain.Transfer, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListIncoming(ctx, auth.UserID, auth.TeamIDs)
+}
+
+// decide records the outcome of a pending transfer on it and in the
+// ticket's history
+func (s *TransferService) decide(ctx context.Context, transfer *domain.Transfer, actorID uuid.UUID, reason string) (*domain.Transfer, error) {
+	now := time.Now()
+	transfer.DecidedBy = &actorID
+	transfer.Reason = strings.TrimSpace(reason)
+	transfer.DecidedAt = &now
+
+	decided, err := s.repo.Decide(ctx, *transfer)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.events.Create(ctx, domain.TicketEvent{
+		TicketID: decided.TicketID,
+		ActorID:  actorID,
+		Kind:     domain.TicketEventTransferDecided,
+		OldValue: string(domain.TransferPending),
+		NewValue: string(decided.Status),
+		Note:     decided.Reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return decided, nil
+}
+
+// checkReceiver returns an error unless the transfer goes to an agent or
+// admin who can work in the ticket's organization, or to one of its teams
+func (s *TransferService) checkReceiver(ctx context.Context, ticket *domain.Ticket, transfer *domain.Transfer) error {
+	if transfer.ToTeamID != nil {
+		team, err := s.teams.Get(ctx, *transfer.ToTeamID)
+		if err != nil {
+			return err
+		}
+		if team.OrgID != ticket.OrgID {
+			return domain.ErrTeamOrgMismatch
+		}
+		return nil
+	}
+
+	user, err := s.users.GetUserByID(ctx, *transfer.ToUserID)
+	if err != nil {
+		return err
+	}
+	if user.Role != domain.RoleAgent && user.Role != domain.RoleAdmin {
+		return domain.ErrTransferNotStaff
+	}
+	if user.OrgID == ticket.OrgID {
+		return nil
+	}
+
+	granted, err := s.users.ListOrgAccess(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(granted, ticket.OrgID) {
+		return domain.ErrTransferOrg
+	}
+	return nil
+}
@@ -4,21 +4,32 @@ import (
 	"context"
 	"errors"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/nickhildpac/ticket-management-app/internal/application/authorization"
 	"github.com/nickhildpac/ticket-management-app/internal/domain"
 	"github.com/nickhildpac/ticket-management-app/internal/ports"
+	"github.com/nickhildpac/ticket-management-app/pkg/configs"
 )
 
+// maxUserSearchResults caps the suggestions offered while typing a mention
+const maxUserSearchResults = 10
+
 type UserService struct {
-	repo ports.UserRepository
+	repo     ports.UserRepository
+	verifier ports.VerificationService
+	sessions ports.SessionService
+	config   *configs.Config
 }
 
-func NewUserService(r ports.UserRepository) *UserService {
+func NewUserService(r ports.UserRepository, v ports.VerificationService, sessions ports.SessionService, conf *configs.Config) *UserService {
 	return &UserService{
-		repo: r,
+		repo:     r,
+		verifier: v,
+		sessions: sessions,
+		config:   conf,
 	}
 }
 
@@ -30,13 +41,31 @@ func (s *UserService) GetUserByID(ctx context.Context, id uuid.UUID) (*domain.Us
 	return s.repo.GetUserByID(ctx, id)
 }
 
+// CreateUser signs a user up into the organization that claims their email
+// domain, or the default organization if none does, and mails them a link
+// to verify their address. When SignupDomains is set, only those domains
+// may sign up.
+// CreateUser signs a user up in the default organization. They join the
+// organization that claims their email domain once they verify the address,
+// so an address they don't own can't get them into it.
 func (s *UserService) CreateUser(ctx context.Context, u domain.User) (*domain.User, error) {
+	if !domain.SignupAllowed(u.Email, s.config.SignupDomains) {
+		return nil, domain.ErrSignupDomainNotAllowed
+	}
+
+	u.OrgID = domain.DefaultOrganizationID
+
 	u.UpdatedAt = time.Now()
 	user, err := s.repo.CreateUser(ctx, u)
 	if err != nil {
 		log.Println("Error creating userserver:", err.Error())
 		return nil, err
 	}
+
+	// The account is kept if the mail fails; an admin can resend the link
+	if err := s.verifier.SendVerification(ctx, user); err != nil {
+		log.Printf("failed to send verification to user %s: %v", user.ID, err)
+	}
 	return user, nil
 }
 
@@ -54,8 +83,18 @@ func (s *UserService) GetAllUsers(ctx context.Context) ([]domain.User, error) {
 }
 
 // GetAllUsersForAssignment returns all users for ticket assignment purposes
-// This method is accessible to all authenticated users, not just admins
+// This method is accessible to all authenticated users, not just admins,
+// once they have verified their email address
 func (s *UserService) GetAllUsersForAssignment(ctx context.Context) ([]domain.User, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !authorization.CanListUsers(auth) {
+		return nil, authorization.ErrAccessDenied
+	}
+
 	return s.repo.GetAllUsers(ctx)
 }
 
@@ -73,11 +112,25 @@ func (s *UserService) UpdateUserRole(ctx context.Context, id uuid.UUID, role dom
 	if err != nil {
 		return nil, err
 	}
+	if !authorization.CanManageUser(auth, user) {
+		return nil, authorization.ErrAccessDenied
+	}
 
+	prevRole := user.Role
 	user.Role = role
 	user.UpdatedAt = time.Now()
 
-	return s.repo.UpdateUser(ctx, user)
+	updated, err := s.repo.UpdateUser(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	// The role is in the user's tokens, so make them log in again
+	if updated.Role != prevRole {
+		if err := s.sessions.RevokeUser(ctx, updated.ID); err != nil {
+			return nil, err
+		}
+	}
+	return updated, nil
 }
 
 func (s *UserService) DeleteUser(ctx context.Context, id uuid.UUID) error {
@@ -95,5 +148,61 @@ func (s *UserService) DeleteUser(ctx context.Context, id uuid.UUID) error {
 		return errors.New("cannot delete your own account")
 	}
 
+	user, err := s.repo.GetUserByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !authorization.CanManageUser(auth, user) {
+		return authorization.ErrAccessDenied
+	}
+
 	return s.repo.DeleteUser(ctx, id)
 }
+
+// OrgIDs returns every organization the user may access: their own, plus
+// any they were granted while an agent or admin. It backs the token claims.
+func (s *UserService) OrgIDs(ctx context.Context, user *domain.User) ([]uuid.UUID, error) {
+	orgs := []uuid.UUID{user.OrgID}
+	if user.Role == domain.RoleUser {
+		return orgs, nil
+	}
+
+	granted, err := s.repo.ListOrgAccess(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range granted {
+		if id != user.OrgID {
+			orgs = append(orgs, id)
+		}
+	}
+	return orgs, nil
+}
+
+// TeamIDs returns the teams the user is a member of. It backs the token
+// claims, so changes take effect when the token is next refreshed.
+func (s *UserService) TeamIDs(ctx context.Context, user *domain.User) ([]uuid.UUID, error) {
+	if user.Role == domain.RoleUser {
+		return []uuid.UUID{}, nil
+	}
+	return s.repo.ListTeamIDs(ctx, user.ID)
+}
+
+// SearchUsers returns users in the caller's organizations. Like the user
+// list for assignment, it is open to every verified user.
+func (s *UserService) SearchUsers(ctx context.Context, query string) ([]domain.User, error) {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !authorization.CanListUsers(auth) {
+		return nil, authorization.ErrAccessDenied
+	}
+
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return []domain.User{}, nil
+	}
+	return s.repo.Search(ctx, query, maxUserSearchResults)
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nickhildpac/ticket-management-app/internal/application/authorization"
+	"github.com/nickhildpac/ticket-management-app/internal/domain"
+	"github.com/nickhildpac/ticket-management-app/internal/ports"
+	"github.com/nickhildpac/ticket-management-app/pkg/configs"
+	"github.com/nickhildpac/ticket-management-app/pkg/util"
+)
+
+type VerificationService struct {
+	userRepo  ports.UserRepository
+	tokenRepo ports.UserTokenRepository
+	orgs      ports.OrganizationRepository
+	mailer    ports.Mailer
+	tx        ports.Transactor
+	config    *configs.Config
+}
+
+func NewVerificationService(ur ports.UserRepository, tr ports.UserTokenRepository, orgs ports.OrganizationRepository, m ports.Mailer, tx ports.Transactor, conf *configs.Config) *VerificationService {
+	return &VerificationService{
+		userRepo:  ur,
+		tokenRepo: tr,
+		orgs:      orgs,
+		mailer:    m,
+		tx:        tx,
+		config:    conf,
+	}
+}
+
+// SendVerification mails the user a link that verifies their address.
+// Links mailed before stop working.
+func (s *VerificationService) SendVerification(ctx context.Context, user *domain.User) error {
+	token, expiresAt, err := issueUserToken(ctx, s.tokenRepo, s.tx, user.ID, domain.TokenEmailVerification, s.config.VerificationExpiry)
+	if err != nil {
+		return err
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "Hi %s,\n\n", user.FirstName)
+	body.WriteString("Please confirm this is your email address by opening this link:\n\n")
+	fmt.Fprintf(&body, "%s/api/v1/email/verify?token=%s\n\n", s.config.BaseURL, url.QueryEscape(token))
+	fmt.Fprintf(&body, "The link expires on %s. If you didn't sign up, you can ignore this email.\n", expiresAt.Format(time.RFC1123))
+
+	return s.mailer.Send(ctx, user.Email, "Confirm your email address", body.String())
+}
+
+// VerifyEmail verifies the address and moves a user who signed up into the
+// organization that claims their email domain
+func (s *VerificationService) VerifyEmail(ctx context.Context, token string) (*domain.User, error) {
+	var user *domain.User
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		t, err := s.tokenRepo.Consume(ctx, util.HashSecretToken(token), domain.TokenEmailVerification)
+		if err != nil {
+			return err
+		}
+		if user, err = s.userRepo.GetUserByID(ctx, t.UserID); err != nil {
+			return err
+		}
+		if user.Verified() {
+			return nil
+		}
+		if user, err = s.userRepo.VerifyEmail(ctx, user.ID); err != nil {
+			return err
+		}
+		if user.OrgID != domain.DefaultOrganizationID {
+			return nil
+		}
+		org, err := s.orgs.GetByDomain(ctx, domain.EmailDomain(user.Email))
+		if err != nil || org == nil || org.ID == user.OrgID {
+			return err
+		}
+		user, err = s.orgs.MoveUser(ctx, user.ID, org.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *VerificationService) ResendVerification(ctx context.Context, userID uuid.UUID) error {
+	auth, err := authorization.GetAuthContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !authorization.CanManageUser(auth, user) {
+		return authorization.ErrAccessDenied
+	}
+	if user.Verified() {
+		return domain.ErrEmailAlreadyVerified
+	}
+	return s.SendVerification(ctx, user)
+}
@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultTicketType is used when a ticket is opened without a type
+const DefaultTicketType = "general"
+
+var ticketTypePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,49}$`)
+
+var (
+	ErrInvalidTicketType     = errors.New("ticket type must be a lowercase slug such as access_request")
+	ErrInvalidApprover       = errors.New("approval step needs exactly one of approver_id or approver_role")
+	ErrApprovalRequired      = errors.New("ticket needs approval before work can start")
+	ErrApprovalRejected      = errors.New("ticket approval was rejected")
+	ErrNoPendingApproval     = errors.New("ticket has no pending approval for you to decide")
+	ErrRejectionNeedsComment = errors.New("a comment is required when rejecting")
+	ErrApprovalTicketNotOpen = errors.New("approvals can only be decided while the ticket is open")
+)
+
+func ValidateTicketType(t string) error {
+	if !ticketTypePattern.MatchString(t) {
+		return ErrInvalidTicketType
+	}
+	return nil
+}
+
+type ApprovalStatus string
+
+const (
+	ApprovalPending  ApprovalStatus = "pending"
+	ApprovalApproved ApprovalStatus = "approved"
+	ApprovalRejected ApprovalStatus = "rejected"
+)
+
+// ApprovalStep is one approval a ticket type needs. Steps are decided in
+// position order; each names either a user or a role.
+type ApprovalStep struct {
+	ID           uuid.UUID  `json:"id"`
+	TicketType   string     `json:"ticket_type"`
+	Position     int        `json:"position"`
+	ApproverID   *uuid.UUID `json:"approver_id"`
+	ApproverRole *UserRole  `json:"approver_role"`
+}
+
+func (s ApprovalStep) Validate() error {
+	if (s.ApproverID == nil) == (s.ApproverRole == nil) {
+		return ErrInvalidApprover
+	}
+	return nil
+}
+
+// TicketApproval is an approval step as copied onto a ticket, along with
+// its decision
+type TicketApproval struct {
+	ID           uuid.UUID      `json:"id"`
+	TicketID     uuid.UUID      `json:"ticket_id"`
+	Position     int            `json:"position"`
+	ApproverID   *uuid.UUID     `json:"approver_id"`
+	ApproverRole *UserRole      `json:"approver_role"`
+	Status       ApprovalStatus `json:"status"`
+	DecidedBy    *uuid.UUID     `json:"decided_by"`
+	Comment      string         `json:"comment"`
+	DecidedAt    *time.Time     `json:"decided_at"`
+	CreatedAt    time.Time      `json:"created_at"`
+}
+
+// IsApprover reports whether the user may decide this step
+func (a TicketApproval) IsApprover(userID uuid.UUID, role UserRole) bool {
+	if a.ApproverID != nil {
+		return *a.ApproverID == userID
+	}
+	return a.ApproverRole != nil && *a.ApproverRole == role
+}
+
+// CurrentApproval returns the first step that isn't approved yet, or nil
+// once every step is. approvals must be in position order.
+func CurrentApproval(approvals []TicketApproval) *TicketApproval {
+	for i := range approvals {
+		if approvals[i].Status != ApprovalApproved {
+			return &approvals[i]
+		}
+	}
+	return nil
+}
+
+// CheckApprovals returns nil when work on the ticket may start
+func CheckApprovals(approvals []TicketApproval) error {
+	current := CurrentApproval(approvals)
+	switch {
+	case current == nil:
+		return nil
+	case current.Status == ApprovalRejected:
+		return ErrApprovalRejected
+	default:
+		return ErrApprovalRequired
+	}
+}
@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestCheckApprovals(t *testing.T) {
+	approved := TicketApproval{Status: ApprovalApproved}
+	pending := TicketApproval{Status: ApprovalPending}
+	rejected := TicketApproval{Status: ApprovalRejected}
+
+	tests := []struct {
+		name      string
+		approvals []TicketApproval
+		expected  error
+	}{
+		{"No approval steps", nil, nil},
+		{"All approved", []TicketApproval{approved, approved}, nil},
+		{"First step pending", []TicketApproval{pending, pending}, ErrApprovalRequired},
+		{"Second step pending", []TicketApproval{approved, pending}, ErrApprovalRequired},
+		{"Rejected step", []TicketApproval{approved, rejected, pending}, ErrApprovalRejected},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CheckApprovals(tt.approvals); err != tt.expected {
+				t.Errorf("CheckApprovals() = %v; want %v", err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCurrentApproval(t *testing.T) {
+	approvals := []TicketApproval{
+		{Position: 1, Status: ApprovalApproved},
+		{Position: 2, Status: ApprovalPending},
+		{Position: 3, Status: ApprovalPending},
+	}
+
+	current := CurrentApproval(approvals)
+	if current == nil || current.Position != 2 {
+		t.Fatalf("CurrentApproval() = %v; want position 2", current)
+	}
+
+	// The returned step must be the one in the slice, not a copy
+	current.Status = ApprovalApproved
+	if next := CurrentApproval(approvals); next == nil || next.Position != 3 {
+		t.Errorf("CurrentApproval() = %v; want position 3", next)
+	}
+}
+
+func TestIsApprover(t *testing.T) {
+	user := uuid.New()
+	agent := RoleAgent
+
+	byUser := TicketApproval{ApproverID: &user}
+	if !byUser.IsApprover(user, RoleUser) {
+		t.Error("named approver should be able to decide")
+	}
+	if byUser.IsApprover(uuid.New(), RoleAdmin) {
+		t.Error("only the named approver should be able to decide")
+	}
+
+	byRole := TicketApproval{ApproverRole: &agent}
+	if !byRole.IsApprover(uuid.New(), RoleAgent) {
+		t.Error("any member of the role should be able to decide")
+	}
+	if byRole.IsApprover(uuid.New(), RoleUser) {
+		t.Error("other roles should not be able to decide")
+	}
+}
+
+func TestApprovalStepValidate(t *testing.T) {
+	id := uuid.New()
+	role := RoleAdmin
+
+	if err := (ApprovalStep{ApproverID: &id}).Validate(); err != nil {
+		t.Errorf("user step returned error: %v", err)
+	}
+	if err := (ApprovalStep{ApproverRole: &role}).Validate(); err != nil {
+		t.Errorf("role step returned error: %v", err)
+	}
+	if err := (ApprovalStep{}).Validate(); err != ErrInvalidApprover {
+		t.Errorf("empty step error = %v; want %v", err, ErrInvalidApprover)
+	}
+	if err := (ApprovalStep{ApproverID: &id, ApproverRole: &role}).Validate(); err != ErrInvalidApprover {
+		t.Errorf("step with both approvers error = %v; want %v", err, ErrInvalidApprover)
+	}
+}
+
+func TestValidateTicketType(t *testing.T) {
+	for _, valid := range []string{"general", "access_request", "prod-change", "p1"} {
+		if err := ValidateTicketType(valid); err != nil {
+			t.Errorf("ValidateTicketType(%q) returned error: %v", valid, err)
+		}
+	}
+	for _, invalid := range []string{"", "Access", "has space", "_leading"} {
+		if err := ValidateTicketType(invalid); err != ErrInvalidTicketType {
+			t.Errorf("ValidateTicketType(%q) error = %v; want %v", invalid, err, ErrInvalidTicketType)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AvailabilityStatus string
+
+const (
+	AvailabilityAvailable AvailabilityStatus = "available"
+	// AvailabilityBusy is shown to whoever assigns work but doesn't stop it
+	AvailabilityBusy AvailabilityStatus = "busy"
+	AvailabilityAway AvailabilityStatus = "away"
+)
+
+func GetAvailabilityStatus(s string) (AvailabilityStatus, error) {
+	switch AvailabilityStatus(strings.ToLower(s)) {
+	case AvailabilityAvailable:
+		return AvailabilityAvailable, nil
+	case AvailabilityBusy:
+		return AvailabilityBusy, nil
+	case AvailabilityAway:
+		return AvailabilityAway, nil
+	default:
+		return "", fmt.Errorf("invalid availability status: %s", s)
+	}
+}
+
+// AssignmentPolicy decides what happens when a ticket is assigned to an
+// agent who is unavailable: either the assignment goes ahead with a warning
+// or it is refused
+type AssignmentPolicy string
+
+const (
+	AssignmentPolicyWarn   AssignmentPolicy = "warn"
+	AssignmentPolicyRefuse AssignmentPolicy = "refuse"
+)
+
+var (
+	ErrNotAgent           = errors.New("only agents and admins have availability")
+	ErrInvalidCapacity    = errors.New("max open tickets cannot be negative")
+	ErrInvalidOutOfOffice = errors.New("out of office must end after it starts")
+	ErrSelfDelegate       = errors.New("agents cannot delegate to themselves")
+	ErrAgentOutOfOffice   = errors.New("agent is out of office")
+	ErrAgentAway          = errors.New("agent is away")
+	ErrAgentAtCapacity    = errors.New("agent already has their maximum number of open tickets")
+)
+
+// Availability is whether an agent is taking new work. MaxOpenTickets of
+// zero means no limit. OpenTickets and OutOfOffice describe the agent right
+// now and are not stored.
+type Availability struct {
+	UserID         uuid.UUID          `json:"user_id"`
+	Status         AvailabilityStatus `json:"status"`
+	MaxOpenTickets int                `json:"max_open_tickets"`
+	OpenTickets    int                `json:"open_tickets"`
+	OutOfOffice    *OutOfOffice       `json:"out_of_office"`
+	UpdatedAt      time.Time          `json:"updated_at"`
+}
+
+func (a Availability) Validate() error {
+	if a.MaxOpenTickets < 0 {
+		return ErrInvalidCapacity
+	}
+	return nil
+}
+
+// Problem returns why the agent shouldn't be given another ticket, or nil
+func (a Availability) Problem() error {
+	switch {
+	case a.OutOfOffice != nil:
+		return ErrAgentOutOfOffice
+	case a.Status == AvailabilityAway:
+		return ErrAgentAway
+	case a.MaxOpenTickets > 0 && a.OpenTickets >= a.MaxOpenTickets:
+		return ErrAgentAtCapacity
+	default:
+		return nil
+	}
+}
+
+// OutOfOffice is a planned absence. While it is in effect, tickets assigned
+// to the agent are forwarded to DelegateID if one is set.
+type OutOfOffice struct {
+	ID         uuid.UUID  `json:"id"`
+	UserID     uuid.UUID  `json:"user_id"`
+	StartsAt   time.Time  `json:"starts_at"`
+	EndsAt     time.Time  `json:"ends_at"`
+	DelegateID *uuid.UUID `json:"delegate_id"`
+	Note       string     `json:"note"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+func (o OutOfOffice) Validate() error {
+	if !o.EndsAt.After(o.StartsAt) {
+		return ErrInvalidOutOfOffice
+	}
+	if o.DelegateID != nil && *o.DelegateID == o.UserID {
+		return ErrSelfDelegate
+	}
+	return nil
+}
+
+// Covers reports whether the absence is in effect at t
+func (o OutOfOffice) Covers(t time.Time) bool {
+	return !t.Before(o.StartsAt) && t.Before(o.EndsAt)
+}
+
+// AssignmentWarning flags an assignee who was given a ticket although they
+// are unavailable
+type AssignmentWarning struct {
+	UserID uuid.UUID `json:"user_id"`
+	Reason string    `json:"reason"`
+}
@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestGetAvailabilityStatus(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected AvailabilityStatus
+		wantErr  bool
+	}{
+		{"available", AvailabilityAvailable, false},
+		{"Busy", AvailabilityBusy, false},
+		{"AWAY", AvailabilityAway, false},
+		{"vacation", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := GetAvailabilityStatus(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetAvailabilityStatus(%q) error = %v; wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Errorf("GetAvailabilityStatus(%q) = %q; want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAvailabilityProblem(t *testing.T) {
+	away := &OutOfOffice{}
+	tests := []struct {
+		name     string
+		a        Availability
+		expected error
+	}{
+		{"Available", Availability{Status: AvailabilityAvailable}, nil},
+		{"Busy", Availability{Status: AvailabilityBusy}, nil},
+		{"Away", Availability{Status: AvailabilityAway}, ErrAgentAway},
+		{"Out of office", Availability{Status: AvailabilityAvailable, OutOfOffice: away}, ErrAgentOutOfOffice},
+		{"Below capacity", Availability{Status: AvailabilityAvailable, MaxOpenTickets: 3, OpenTickets: 2}, nil},
+		{"At capacity", Availability{Status: AvailabilityAvailable, MaxOpenTickets: 3, OpenTickets: 3}, ErrAgentAtCapacity},
+		{"No limit", Availability{Status: AvailabilityAvailable, OpenTickets: 50}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.Problem(); got != tt.expected {
+				t.Errorf("Problem() = %v; want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestOutOfOfficeValidate(t *testing.T) {
+	user := uuid.New()
+	other := uuid.New()
+	start := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		o        OutOfOffice
+		expected error
+	}{
+		{"Valid", OutOfOffice{UserID: user, StartsAt: start, EndsAt: start.Add(48 * time.Hour), DelegateID: &other}, nil},
+		{"No delegate", OutOfOffice{UserID: user, StartsAt: start, EndsAt: start.Add(time.Hour)}, nil},
+		{"Ends before start", OutOfOffice{UserID: user, StartsAt: start, EndsAt: start.Add(-time.Hour)}, ErrInvalidOutOfOffice},
+		{"Empty range", OutOfOffice{UserID: user, StartsAt: start, EndsAt: start}, ErrInvalidOutOfOffice},
+		{"Self delegate", OutOfOffice{UserID: user, StartsAt: start, EndsAt: start.Add(time.Hour), DelegateID: &user}, ErrSelfDelegate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.o.Validate(); got != tt.expected {
+				t.Errorf("Validate() = %v; want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestOutOfOfficeCovers(t *testing.T) {
+	start := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	o := OutOfOffice{StartsAt: start, EndsAt: start.Add(24 * time.Hour)}
+
+	tests := []struct {
+		name     string
+		at       time.Time
+		expected bool
+	}{
+		{"Before", start.Add(-time.Second), false},
+		{"Start", start, true},
+		{"During", start.Add(12 * time.Hour), true},
+		{"End", start.Add(24 * time.Hour), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := o.Covers(tt.at); got != tt.expected {
+				t.Errorf("Covers(%v) = %v; want %v", tt.at, got, tt.expected)
+			}
+		})
+	}
+}
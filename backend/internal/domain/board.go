@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidRank     = errors.New("invalid rank bounds")
+	ErrInvalidPosition = errors.New("neighbouring tickets must be in the target column")
+)
+
+// rankDigits are ordered by byte value so ranks sort correctly as plain
+// strings
+const rankDigits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// BoardColumns lists the board columns in display order
+var BoardColumns = []TicketState{
+	TicketStateOpen,
+	TicketStatePending,
+	TicketStateResolved,
+	TicketStateClosed,
+	TicketStateCancelled,
+}
+
+type BoardColumn struct {
+	State   TicketState `json:"state"`
+	Tickets []Ticket    `json:"tickets"`
+}
+
+// TicketMove drops a ticket into a column between two neighbours. Either
+// neighbour may be omitted at the top or bottom of the column; omitting both
+// places the ticket at the bottom.
+type TicketMove struct {
+	TicketID       uuid.UUID
+	State          TicketState
+	AfterID        *uuid.UUID
+	BeforeID       *uuid.UUID
+	ResolutionCode ResolutionCode
+	Reason         string
+}
+
+// RankBetween returns a rank that sorts strictly between prev and next. An
+// empty prev means the top of the column and an empty next the bottom.
+//
+// Ranks are base-36 fractions, so there is always room for another one
+// between two neighbours and a move only ever rewrites the moved ticket.
+// Generated ranks never end in "0", which keeps every rank distinct from
+// its own extensions.
+func RankBetween(prev, next string) (string, error) {
+	if !validRank(prev) || !validRank(next) || (next != "" && prev >= next) {
+		return "", ErrInvalidRank
+	}
+
+	var out []byte
+	bounded := next != ""
+	for i := 0; ; i++ {
+		lo := 0
+		if i < len(prev) {
+			lo = strings.IndexByte(rankDigits, prev[i])
+		}
+		hi := len(rankDigits)
+		if bounded {
+			hi = strings.IndexByte(rankDigits, next[i])
+		}
+
+		if hi-lo > 1 {
+			return string(append(out, rankDigits[(lo+hi)/2])), nil
+		}
+		out = append(out, rankDigits[lo])
+		if hi > lo {
+			// Already below next, so only prev constrains what follows
+			bounded = false
+		}
+	}
+}
+
+func validRank(r string) bool {
+	for i := 0; i < len(r); i++ {
+		if strings.IndexByte(rankDigits, r[i]) < 0 {
+			return false
+		}
+	}
+	return !strings.HasSuffix(r, "0")
+}
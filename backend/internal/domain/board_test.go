@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestRankBetween(t *testing.T) {
+	tests := []struct {
+		name string
+		prev string
+		next string
+	}{
+		{"Empty column", "", ""},
+		{"Top of column", "", "i"},
+		{"Bottom of column", "i", ""},
+		{"Between neighbours", "a", "c"},
+		{"Adjacent digits", "a", "b"},
+		{"Prefix of next", "a", "a01"},
+		{"After last digit", "z", ""},
+		{"Before first rank", "", "0000000001i"},
+		{"Backfilled neighbours", "0000000001i", "0000000002i"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rank, err := RankBetween(tt.prev, tt.next)
+			if err != nil {
+				t.Fatalf("RankBetween(%q, %q) returned error: %v", tt.prev, tt.next, err)
+			}
+			if rank <= tt.prev || (tt.next != "" && rank >= tt.next) {
+				t.Errorf("RankBetween(%q, %q) = %q; not between", tt.prev, tt.next, rank)
+			}
+			if !validRank(rank) {
+				t.Errorf("RankBetween(%q, %q) = %q; not a valid rank", tt.prev, tt.next, rank)
+			}
+		})
+	}
+}
+
+func TestRankBetweenRepeatedInserts(t *testing.T) {
+	// Dragging into the same gap over and over must keep finding room
+	prev, next := "a", "b"
+	for i := 0; i < 200; i++ {
+		rank, err := RankBetween(prev, next)
+		if err != nil {
+			t.Fatalf("insert %d: %v", i, err)
+		}
+		if rank <= prev || rank >= next {
+			t.Fatalf("insert %d: %q not between %q and %q", i, rank, prev, next)
+		}
+		next = rank
+	}
+}
+
+func TestRankBetweenInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		prev string
+		next string
+	}{
+		{"Equal bounds", "a", "a"},
+		{"Reversed bounds", "c", "a"},
+		{"Invalid digit", "A", ""},
+		{"Trailing zero", "a0", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := RankBetween(tt.prev, tt.next); err != ErrInvalidRank {
+				t.Errorf("RankBetween(%q, %q) error = %v; want %v", tt.prev, tt.next, err, ErrInvalidRank)
+			}
+		})
+	}
+}
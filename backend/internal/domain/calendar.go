@@ -0,0 +1,200 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const minutesPerDay = 24 * 60
+
+var (
+	ErrEmptyCalendarName   = errors.New("calendar name is required")
+	ErrInvalidTimeZone     = errors.New("time zone must be an IANA name such as Europe/Berlin")
+	ErrNoWorkingHours      = errors.New("calendar needs at least one block of working hours")
+	ErrInvalidWorkingHour  = errors.New("working hours must fall within one day and start before they end")
+	ErrOverlappingHours    = errors.New("working hours on the same day cannot overlap")
+	ErrInvalidHoliday      = errors.New("holidays must be dates like 2025-12-25")
+	ErrCalendarOrgMismatch = errors.New("calendar belongs to a different organization")
+)
+
+// WorkingHours is one block of work on a weekday, in minutes after local
+// midnight. A day with a lunch break has two blocks.
+type WorkingHours struct {
+	Weekday     time.Weekday `json:"weekday"`
+	StartMinute int          `json:"start_minute"`
+	EndMinute   int          `json:"end_minute"`
+}
+
+type Holiday struct {
+	Date string `json:"date"`
+	Name string `json:"name"`
+}
+
+// BusinessCalendar says when an organization or team is at work. Times are
+// interpreted in TimeZone, so a calendar follows its region's daylight
+// saving changes.
+type BusinessCalendar struct {
+	ID        uuid.UUID      `json:"id"`
+	OrgID     uuid.UUID      `json:"org_id"`
+	Name      string         `json:"name"`
+	TimeZone  string         `json:"time_zone"`
+	Hours     []WorkingHours `json:"hours"`
+	Holidays  []Holiday      `json:"holidays"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+// Normalize trims the name, defaults the time zone to UTC, sorts the hours
+// and holidays and validates them all
+func (c *BusinessCalendar) Normalize() error {
+	c.Name = strings.TrimSpace(c.Name)
+	if c.Name == "" {
+		return ErrEmptyCalendarName
+	}
+	c.TimeZone = strings.TrimSpace(c.TimeZone)
+	if c.TimeZone == "" {
+		c.TimeZone = "UTC"
+	}
+	if _, err := time.LoadLocation(c.TimeZone); err != nil {
+		return ErrInvalidTimeZone
+	}
+
+	if len(c.Hours) == 0 {
+		return ErrNoWorkingHours
+	}
+	slices.SortFunc(c.Hours, compareHours)
+	for i, h := range c.Hours {
+		if h.Weekday < time.Sunday || h.Weekday > time.Saturday ||
+			h.StartMinute < 0 || h.StartMinute >= h.EndMinute || h.EndMinute > minutesPerDay {
+			return ErrInvalidWorkingHour
+		}
+		if i > 0 && c.Hours[i-1].Weekday == h.Weekday && c.Hours[i-1].EndMinute > h.StartMinute {
+			return ErrOverlappingHours
+		}
+	}
+
+	for i := range c.Holidays {
+		c.Holidays[i].Name = strings.TrimSpace(c.Holidays[i].Name)
+		if _, err := time.Parse(time.DateOnly, c.Holidays[i].Date); err != nil {
+			return ErrInvalidHoliday
+		}
+	}
+	slices.SortFunc(c.Holidays, func(a, b Holiday) int {
+		return strings.Compare(a.Date, b.Date)
+	})
+	c.Holidays = slices.CompactFunc(c.Holidays, func(a, b Holiday) bool {
+		return a.Date == b.Date
+	})
+	return nil
+}
+
+// Elapsed returns the working time between from and to. A nil calendar, or
+// one without hours, counts every minute.
+func (c *BusinessCalendar) Elapsed(from, to time.Time) time.Duration {
+	if !to.After(from) {
+		return 0
+	}
+	if c == nil || len(c.Hours) == 0 {
+		return to.Sub(from)
+	}
+
+	var total time.Duration
+	c.eachBlock(from, func(start, end time.Time) bool {
+		if !start.Before(to) {
+			return false
+		}
+		total += minTime(end, to).Sub(maxTime(start, from))
+		return true
+	})
+	return total
+}
+
+// Deadline returns the moment d of working time after from. A nil calendar,
+// or one without hours, counts every minute.
+func (c *BusinessCalendar) Deadline(from time.Time, d time.Duration) time.Time {
+	if d <= 0 {
+		return from
+	}
+	if c == nil || len(c.Hours) == 0 {
+		return from.Add(d)
+	}
+
+	var deadline time.Time
+	c.eachBlock(from, func(start, end time.Time) bool {
+		start = maxTime(start, from)
+		if block := end.Sub(start); block < d {
+			d -= block
+			return true
+		}
+		deadline = start.Add(d)
+		return false
+	})
+	return deadline
+}
+
+// eachBlock calls fn with every block of working time that ends after from,
+// in order, until fn returns false. Holidays are skipped.
+func (c *BusinessCalendar) eachBlock(from time.Time, fn func(start, end time.Time) bool) {
+	loc, err := time.LoadLocation(c.TimeZone)
+	if err != nil {
+		loc = time.UTC
+	}
+	hours := slices.Clone(c.Hours)
+	slices.SortFunc(hours, compareHours)
+	holidays := make(map[string]bool, len(c.Holidays))
+	for _, h := range c.Holidays {
+		holidays[h.Date] = true
+	}
+
+	local := from.In(loc)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	for {
+		if !holidays[day.Format(time.DateOnly)] {
+			for _, h := range hours {
+				if h.Weekday != day.Weekday() {
+					continue
+				}
+				start := clock(day, h.StartMinute)
+				end := clock(day, h.EndMinute)
+				if !end.After(from) {
+					continue
+				}
+				if !fn(start, end) {
+					return
+				}
+			}
+		}
+		day = time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, 0, loc)
+	}
+}
+
+func compareHours(a, b WorkingHours) int {
+	if a.Weekday != b.Weekday {
+		return int(a.Weekday) - int(b.Weekday)
+	}
+	return a.StartMinute - b.StartMinute
+}
+
+// clock returns the wall-clock time minutes after midnight on day. Going
+// through time.Date keeps it right across daylight saving changes.
+func clock(day time.Time, minutes int) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), minutes/60, minutes%60, 0, 0, day.Location())
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
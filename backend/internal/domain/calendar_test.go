@@ -0,0 +1,161 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+// officeCalendar works 09:00-12:00 and 13:00-17:00 on weekdays in New York
+func officeCalendar() *BusinessCalendar {
+	c := &BusinessCalendar{
+		Name:     "Office",
+		TimeZone: "America/New_York",
+		Holidays: []Holiday{{Date: "2025-12-25", Name: "Christmas"}},
+	}
+	for day := time.Monday; day <= time.Friday; day++ {
+		c.Hours = append(c.Hours,
+			WorkingHours{Weekday: day, StartMinute: 13 * 60, EndMinute: 17 * 60},
+			WorkingHours{Weekday: day, StartMinute: 9 * 60, EndMinute: 12 * 60},
+		)
+	}
+	return c
+}
+
+func TestBusinessCalendarNormalize(t *testing.T) {
+	c := officeCalendar()
+	if err := c.Normalize(); err != nil {
+		t.Fatalf("Normalize() = %v", err)
+	}
+	if first := c.Hours[0]; first.Weekday != time.Monday || first.StartMinute != 9*60 {
+		t.Errorf("Normalize() left hours unsorted; first block is %+v", first)
+	}
+
+	tests := []struct {
+		name     string
+		edit     func(c *BusinessCalendar)
+		expected error
+	}{
+		{"Blank name", func(c *BusinessCalendar) { c.Name = " " }, ErrEmptyCalendarName},
+		{"Unknown zone", func(c *BusinessCalendar) { c.TimeZone = "Mars/Olympus" }, ErrInvalidTimeZone},
+		{"No hours", func(c *BusinessCalendar) { c.Hours = nil }, ErrNoWorkingHours},
+		{"Past midnight", func(c *BusinessCalendar) { c.Hours[0].EndMinute = 25 * 60 }, ErrInvalidWorkingHour},
+		{"Ends before start", func(c *BusinessCalendar) { c.Hours[0].EndMinute = c.Hours[0].StartMinute }, ErrInvalidWorkingHour},
+		{"Overlap", func(c *BusinessCalendar) {
+			c.Hours = append(c.Hours, WorkingHours{Weekday: time.Monday, StartMinute: 11 * 60, EndMinute: 14 * 60})
+		}, ErrOverlappingHours},
+		{"Bad holiday", func(c *BusinessCalendar) { c.Holidays[0].Date = "25/12/2025" }, ErrInvalidHoliday},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := officeCalendar()
+			tt.edit(c)
+			if err := c.Normalize(); err != tt.expected {
+				t.Errorf("Normalize() = %v; want %v", err, tt.expected)
+			}
+		})
+	}
+
+	utc := BusinessCalendar{Name: "Default", Hours: []WorkingHours{{Weekday: time.Monday, EndMinute: 60}}}
+	if err := utc.Normalize(); err != nil || utc.TimeZone != "UTC" {
+		t.Errorf("Normalize() = %v with zone %q; want nil with %q", err, utc.TimeZone, "UTC")
+	}
+}
+
+func TestBusinessCalendarElapsed(t *testing.T) {
+	c := officeCalendar()
+	ny, err := time.LoadLocation(c.TimeZone)
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2025, time.December, day, hour, minute, 0, 0, ny)
+	}
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+		expected time.Duration
+	}{
+		{"Within a morning", at(1, 9, 30), at(1, 11, 0), 90 * time.Minute},
+		{"Across lunch", at(1, 11, 0), at(1, 14, 0), 2 * time.Hour},
+		{"Overnight", at(1, 16, 0), at(2, 10, 0), 2 * time.Hour},
+		{"Over a weekend", at(5, 16, 0), at(8, 10, 0), 2 * time.Hour},
+		{"Over a holiday", at(24, 16, 0), at(26, 10, 0), 2 * time.Hour},
+		{"Outside hours", at(6, 10, 0), at(7, 18, 0), 0},
+		{"Full week", at(1, 0, 0), at(8, 0, 0), 35 * time.Hour},
+		{"Backwards", at(2, 10, 0), at(1, 10, 0), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.Elapsed(tt.from, tt.to); got != tt.expected {
+				t.Errorf("Elapsed() = %v; want %v", got, tt.expected)
+			}
+		})
+	}
+
+	var always *BusinessCalendar
+	if got := always.Elapsed(at(6, 0, 0), at(7, 0, 0)); got != 24*time.Hour {
+		t.Errorf("nil calendar Elapsed() = %v; want %v", got, 24*time.Hour)
+	}
+}
+
+func TestBusinessCalendarDeadline(t *testing.T) {
+	c := officeCalendar()
+	ny, err := time.LoadLocation(c.TimeZone)
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2025, time.December, day, hour, minute, 0, 0, ny)
+	}
+
+	tests := []struct {
+		name     string
+		from     time.Time
+		d        time.Duration
+		expected time.Time
+	}{
+		{"Same morning", at(1, 9, 0), 2 * time.Hour, at(1, 11, 0)},
+		{"Skips lunch", at(1, 11, 0), 2 * time.Hour, at(1, 14, 0)},
+		{"Starts before hours", at(1, 7, 0), time.Hour, at(1, 10, 0)},
+		{"Next day", at(1, 16, 0), 2 * time.Hour, at(2, 10, 0)},
+		{"Over a weekend", at(5, 16, 0), 2 * time.Hour, at(8, 10, 0)},
+		{"Over a holiday", at(24, 16, 0), 2 * time.Hour, at(26, 10, 0)},
+		{"Ends a block exactly", at(1, 9, 0), 3 * time.Hour, at(1, 12, 0)},
+		{"Zero", at(6, 10, 0), 0, at(6, 10, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := c.Deadline(tt.from, tt.d)
+			if !got.Equal(tt.expected) {
+				t.Errorf("Deadline() = %v; want %v", got, tt.expected)
+			}
+			if elapsed := c.Elapsed(tt.from, got); elapsed != tt.d {
+				t.Errorf("Elapsed(from, Deadline()) = %v; want %v", elapsed, tt.d)
+			}
+		})
+	}
+}
+
+func TestBusinessCalendarDaylightSaving(t *testing.T) {
+	c := &BusinessCalendar{
+		Name:     "Night shift",
+		TimeZone: "America/New_York",
+		Hours:    []WorkingHours{{Weekday: time.Sunday, StartMinute: 0, EndMinute: 6 * 60}},
+	}
+	ny, err := time.LoadLocation(c.TimeZone)
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	// Clocks jumped from 02:00 to 03:00 on 9 March 2025, so the shift was an
+	// hour short
+	from := time.Date(2025, time.March, 9, 0, 0, 0, 0, ny)
+	to := time.Date(2025, time.March, 9, 12, 0, 0, 0, ny)
+	if got := c.Elapsed(from, to); got != 5*time.Hour {
+		t.Errorf("Elapsed() = %v; want %v", got, 5*time.Hour)
+	}
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrChecklistIncomplete   = errors.New("ticket has required checklist items that are not done")
+	ErrInvalidChecklistOrder = errors.New("checklist order must list every item of the ticket exactly once")
+	ErrEmptyChecklistItem    = errors.New("checklist item text is required")
+	ErrChecklistItemNotFound = errors.New("checklist item not found")
+)
+
+// ChecklistItem is one ordered step of a ticket. Required items must be done
+// before the ticket can be resolved.
+type ChecklistItem struct {
+	ID         uuid.UUID  `json:"id"`
+	TicketID   uuid.UUID  `json:"ticket_id"`
+	Position   int        `json:"position"`
+	Text       string     `json:"text"`
+	Done       bool       `json:"done"`
+	Required   bool       `json:"required"`
+	AssigneeID *uuid.UUID `json:"assignee_id"`
+	DueAt      *time.Time `json:"due_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+type ChecklistProgress struct {
+	Total        int `json:"total"`
+	Done         int `json:"done"`
+	RequiredOpen int `json:"required_open"`
+}
+
+// IsPermutation reports whether ids lists exactly the given items, each once
+func IsPermutation(items []ChecklistItem, ids []uuid.UUID) bool {
+	if len(items) != len(ids) {
+		return false
+	}
+	seen := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			return false
+		}
+		seen[id] = true
+	}
+	for _, item := range items {
+		if !seen[item.ID] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,228 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidCommand  = errors.New("invalid command")
+	ErrUnknownAssignee = errors.New("no single user matches the assignee")
+)
+
+// CommandName is a slash command that may start a comment
+type CommandName string
+
+const (
+	CommandClose    CommandName = "close"
+	CommandResolve  CommandName = "resolve"
+	CommandCancel   CommandName = "cancel"
+	CommandReopen   CommandName = "reopen"
+	CommandAssign   CommandName = "assign"
+	CommandUnassign CommandName = "unassign"
+	CommandPriority CommandName = "priority"
+	CommandLabel    CommandName = "label"
+	CommandUnlabel  CommandName = "unlabel"
+)
+
+var commandNames = []CommandName{
+	CommandClose, CommandResolve, CommandCancel, CommandReopen, CommandAssign,
+	CommandUnassign, CommandPriority, CommandLabel, CommandUnlabel,
+}
+
+// Command is one parsed slash command, e.g. "/assign @bob" or
+// "/resolve fixed". Text is the line as written.
+type Command struct {
+	Name CommandName
+	Args []string
+	Text string
+}
+
+// CommandResult records what a command changed, with the field's value
+// before and after in the form the ticket's events use
+type CommandResult struct {
+	Command  string `json:"command"`
+	Field    string `json:"field"`
+	OldValue string `json:"old_value"`
+	NewValue string `json:"new_value"`
+}
+
+// ParseCommands reads the slash commands on the lines a comment starts with.
+// Parsing stops at the first line that isn't a known command, so text such
+// as a path ("/var/log is full") is left alone. Arguments are checked here;
+// whether the caller may run the commands is up to the ticket service.
+func ParseCommands(text string) ([]Command, error) {
+	var commands []Command
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "/") {
+			break
+		}
+		fields := strings.Fields(line[1:])
+		if len(fields) == 0 {
+			break
+		}
+		name := CommandName(strings.ToLower(fields[0]))
+		if !slices.Contains(commandNames, name) {
+			break
+		}
+		cmd := Command{Name: name, Args: fields[1:], Text: line}
+		if err := cmd.validate(); err != nil {
+			return nil, err
+		}
+		commands = append(commands, cmd)
+	}
+	return commands, nil
+}
+
+func (c Command) validate() error {
+	switch c.Name {
+	case CommandClose:
+		if len(c.Args) > 0 {
+			return fmt.Errorf("%w: /%s takes no arguments", ErrInvalidCommand, c.Name)
+		}
+	case CommandResolve, CommandCancel:
+		if len(c.Args) == 0 {
+			return fmt.Errorf("%w: /%s needs a resolution code: %w", ErrInvalidCommand, c.Name, ErrResolutionCodeRequired)
+		}
+		if _, err := GetResolutionCode(strings.Join(c.Args, " ")); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidCommand, err)
+		}
+	case CommandReopen:
+		if len(c.Args) == 0 {
+			return fmt.Errorf("%w: /%s needs a reason: %w", ErrInvalidCommand, c.Name, ErrReopenReasonRequired)
+		}
+	case CommandAssign:
+		if len(c.Args) == 0 {
+			return fmt.Errorf("%w: /%s needs at least one @user", ErrInvalidCommand, c.Name)
+		}
+	case CommandPriority:
+		if len(c.Args) != 1 || (GetTicketPriority(c.Args[0]) < 0 && !strings.EqualFold(c.Args[0], "auto")) {
+			return fmt.Errorf("%w: /%s takes one of critical, high, medium, low or auto", ErrInvalidCommand, c.Name)
+		}
+	case CommandLabel, CommandUnlabel:
+		if len(NormalizeLabels(c.Args)) == 0 {
+			return fmt.Errorf("%w: /%s needs at least one label", ErrInvalidCommand, c.Name)
+		}
+	}
+	return nil
+}
+
+// Handles returns the user handles the commands name, lowercased and
+// without their "@", for resolving to users
+func (c Command) Handles() []string {
+	if c.Name != CommandAssign && c.Name != CommandUnassign {
+		return nil
+	}
+	handles := make([]string, 0, len(c.Args))
+	for _, arg := range c.Args {
+		handles = append(handles, strings.ToLower(strings.TrimPrefix(arg, "@")))
+	}
+	return handles
+}
+
+// ApplyCommands makes the commands' changes to the ticket, in order, and
+// returns the fields they updated, as the ticket service expects them,
+// together with what each command did. users maps the handles from
+// Handles to the users they name.
+func ApplyCommands(t *Ticket, commands []Command, users map[string]uuid.UUID) ([]string, []CommandResult, error) {
+	var fields []string
+	results := make([]CommandResult, 0, len(commands))
+	touch := func(names ...string) {
+		for _, n := range names {
+			if !slices.Contains(fields, n) {
+				fields = append(fields, n)
+			}
+		}
+	}
+
+	for _, c := range commands {
+		result := CommandResult{Command: c.Text}
+		switch c.Name {
+		case CommandClose, CommandResolve, CommandCancel, CommandReopen:
+			result.Field, result.OldValue = "state", t.State.String()
+			switch c.Name {
+			case CommandClose:
+				t.State = TicketStateClosed
+			case CommandResolve:
+				t.State = TicketStateResolved
+			case CommandCancel:
+				t.State = TicketStateCancelled
+			case CommandReopen:
+				t.State = TicketStateOpen
+			}
+			t.ResolutionCode, t.StateReason = "", ""
+			if c.Name == CommandResolve || c.Name == CommandCancel {
+				t.ResolutionCode, _ = GetResolutionCode(strings.Join(c.Args, " "))
+			}
+			if c.Name == CommandReopen {
+				t.StateReason = strings.Join(c.Args, " ")
+			}
+			result.NewValue = t.State.String()
+			touch("state", "resolution_code", "reason")
+		case CommandAssign, CommandUnassign:
+			result.Field, result.OldValue = "assigned_to", joinIDs(t.AssignedTo)
+			ids := make([]uuid.UUID, 0, len(c.Args))
+			for _, h := range c.Handles() {
+				id, ok := users[h]
+				if !ok {
+					return nil, nil, fmt.Errorf("%w: @%s", ErrUnknownAssignee, h)
+				}
+				ids = append(ids, id)
+			}
+			if c.Name == CommandAssign {
+				t.AssignedTo = slices.Clone(t.AssignedTo)
+				for _, id := range ids {
+					if !slices.Contains(t.AssignedTo, id) {
+						t.AssignedTo = append(t.AssignedTo, id)
+					}
+				}
+			} else if len(ids) == 0 {
+				t.AssignedTo = []uuid.UUID{}
+			} else {
+				t.AssignedTo = slices.DeleteFunc(slices.Clone(t.AssignedTo), func(id uuid.UUID) bool {
+					return slices.Contains(ids, id)
+				})
+			}
+			result.NewValue = joinIDs(t.AssignedTo)
+			touch("assigned_to")
+		case CommandPriority:
+			result.Field, result.OldValue = "priority", t.Priority.String()
+			if strings.EqualFold(c.Args[0], "auto") {
+				t.PriorityOverridden = false
+				result.NewValue = "auto"
+			} else {
+				t.Priority = GetTicketPriority(c.Args[0])
+				t.PriorityOverridden = true
+				result.NewValue = t.Priority.String()
+			}
+			touch("priority")
+		case CommandLabel, CommandUnlabel:
+			result.Field, result.OldValue = "labels", strings.Join(t.Labels, ",")
+			labels := NormalizeLabels(c.Args)
+			if c.Name == CommandLabel {
+				t.Labels = NormalizeLabels(append(slices.Clone(t.Labels), labels...))
+			} else {
+				t.Labels = slices.DeleteFunc(NormalizeLabels(t.Labels), func(l string) bool {
+					return slices.Contains(labels, l)
+				})
+			}
+			result.NewValue = strings.Join(t.Labels, ",")
+			touch("labels")
+		}
+		results = append(results, result)
+	}
+	return fields, results, nil
+}
+
+func joinIDs(ids []uuid.UUID) string {
+	s := make([]string, len(ids))
+	for i, id := range ids {
+		s[i] = id.String()
+	}
+	return strings.Join(s, ",")
+}
@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestParseCommands(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected []CommandName
+		err      error
+	}{
+		{"No commands", "Looks good to me", nil, nil},
+		{"Single", "/close", []CommandName{CommandClose}, nil},
+		{"Several then text", "/assign @bob\n/priority HIGH\n/label billing vpn\nOn it.", []CommandName{CommandAssign, CommandPriority, CommandLabel}, nil},
+		{"Stops at text", "Done.\n/close", nil, nil},
+		{"Path is not a command", "/var/log is full", nil, nil},
+		{"Unknown stops parsing", "/shrug\n/close", nil, nil},
+		{"Case insensitive", "/Resolve won't fix", []CommandName{CommandResolve}, nil},
+		{"Resolve without code", "/resolve", nil, ErrResolutionCodeRequired},
+		{"Bad resolution code", "/cancel because", nil, ErrInvalidResolutionCode},
+		{"Reopen without reason", "/reopen", nil, ErrReopenReasonRequired},
+		{"Bad priority", "/priority urgent", nil, ErrInvalidCommand},
+		{"Assign nobody", "/assign", nil, ErrInvalidCommand},
+		{"Close with arguments", "/close now", nil, ErrInvalidCommand},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commands, err := ParseCommands(tt.text)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) || !errors.Is(err, ErrInvalidCommand) {
+					t.Fatalf("ParseCommands(%q) error = %v; want %v", tt.text, err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseCommands(%q) error = %v", tt.text, err)
+			}
+			var names []CommandName
+			for _, c := range commands {
+				names = append(names, c.Name)
+			}
+			if !slices.Equal(names, tt.expected) {
+				t.Errorf("ParseCommands(%q) = %v; want %v", tt.text, names, tt.expected)
+			}
+		})
+	}
+}
+
+func TestApplyCommands(t *testing.T) {
+	bob, eve := uuid.New(), uuid.New()
+	ticket := Ticket{
+		State:      TicketStatePending,
+		Priority:   TicketPriorityLow,
+		AssignedTo: []uuid.UUID{eve},
+		Labels:     []string{"vpn"},
+	}
+	commands, err := ParseCommands("/assign @Bob\n/unassign @eve\n/priority high\n/label Billing\n/unlabel vpn\n/resolve fixed")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fields, results, err := ApplyCommands(&ticket, commands, map[string]uuid.UUID{"bob": bob, "eve": eve})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"assigned_to", "priority", "labels", "state", "resolution_code", "reason"}
+	if !slices.Equal(fields, expected) {
+		t.Errorf("fields = %v; want %v", fields, expected)
+	}
+	if !slices.Equal(ticket.AssignedTo, []uuid.UUID{bob}) {
+		t.Errorf("AssignedTo = %v; want [%v]", ticket.AssignedTo, bob)
+	}
+	if ticket.Priority != TicketPriorityHigh || !ticket.PriorityOverridden {
+		t.Errorf("Priority = %v (overridden %v); want high overridden", ticket.Priority, ticket.PriorityOverridden)
+	}
+	if !slices.Equal(ticket.Labels, []string{"billing"}) {
+		t.Errorf("Labels = %v; want [billing]", ticket.Labels)
+	}
+	if ticket.State != TicketStateResolved || ticket.ResolutionCode != ResolutionFixed {
+		t.Errorf("State = %v (%v); want resolved (fixed)", ticket.State, ticket.ResolutionCode)
+	}
+
+	if len(results) != len(commands) {
+		t.Fatalf("got %d results; want %d", len(results), len(commands))
+	}
+	last := results[len(results)-1]
+	if last.Command != "/resolve fixed" || last.Field != "state" || last.OldValue != "pending" || last.NewValue != "resolved" {
+		t.Errorf("last result = %+v", last)
+	}
+}
+
+func TestApplyCommandsUnknownAssignee(t *testing.T) {
+	ticket := Ticket{}
+	commands, err := ParseCommands("/assign @nobody")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := ApplyCommands(&ticket, commands, nil); !errors.Is(err, ErrUnknownAssignee) {
+		t.Errorf("ApplyCommands() error = %v; want %v", err, ErrUnknownAssignee)
+	}
+}
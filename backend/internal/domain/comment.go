@@ -1,16 +1,191 @@
 package domain
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 )
 
+var (
+	ErrCommentNotFound          = errors.New("comment not found")
+	ErrEmptyComment             = errors.New("comment cannot be empty")
+	ErrCommentEditExpired       = errors.New("comment can no longer be edited")
+	ErrInvalidCommentVisibility = errors.New("invalid comment visibility")
+	ErrInvalidCommentParent     = errors.New("replies must be to a comment on the same ticket")
+	ErrCommentTooDeep           = errors.New("replies are nested too deeply")
+	ErrCommentTooLong           = fmt.Errorf("comment must be at most %d characters", MaxCommentLength)
+)
+
+// MaxCommentDepth is how deeply replies may nest. A comment that starts a
+// thread has depth 0.
+const MaxCommentDepth = 4
+
+// MaxCommentLength caps the text of a comment, which is rendered as
+// Markdown every time the ticket is viewed
+const MaxCommentLength = 10000
+
+// CommentVisibility decides who besides agents and admins sees a comment.
+// Internal notes are for staff only; public replies are also shown to the
+// ticket's creator.
+type CommentVisibility string
+
+const (
+	CommentPublic   CommentVisibility = "public"
+	CommentInternal CommentVisibility = "internal"
+)
+
+// GetCommentVisibility parses a visibility, treating an empty one as public
+func GetCommentVisibility(s string) (CommentVisibility, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "public":
+		return CommentPublic, nil
+	case "internal":
+		return CommentInternal, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrInvalidCommentVisibility, s)
+	}
+}
+
 type Comment struct {
-	ID          uuid.UUID `json:"id"`
-	TicketID    uuid.UUID `json:"ticket_id"`
-	CreatedBy   uuid.UUID `json:"created_by"`
-	Description string    `json:"description"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          uuid.UUID         `json:"id"`
+	TicketID    uuid.UUID         `json:"ticket_id"`
+	CreatedBy   uuid.UUID         `json:"created_by"`
+	Description string            `json:"description"`
+	Visibility  CommentVisibility `json:"visibility"`
+	EditedAt    *time.Time        `json:"edited_at"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+	// ParentID is the comment this one replies to, and ThreadID the comment
+	// that started the thread. Both are nil for a comment that starts one.
+	ParentID *uuid.UUID `json:"parent_id"`
+	ThreadID *uuid.UUID `json:"thread_id"`
+	Depth    int        `json:"depth"`
+	// DeletedAt is set once the comment is deleted. It is then hidden, but
+	// kept with its revisions.
+	DeletedAt *time.Time `json:"deleted_at"`
+}
+
+// Editable reports whether the comment is still within the window in which
+// its author may change it
+func (c Comment) Editable(now time.Time, window time.Duration) bool {
+	return now.Before(c.CreatedAt.Add(window))
+}
+
+// ReplyTo places the comment in parent's thread, one level below it. A reply
+// to an internal note is internal too, so no public reply hangs off a note
+// its readers can't see.
+func (c *Comment) ReplyTo(parent Comment) error {
+	if parent.TicketID != c.TicketID {
+		return ErrInvalidCommentParent
+	}
+	if parent.Depth >= MaxCommentDepth {
+		return ErrCommentTooDeep
+	}
+	thread := parent.ID
+	if parent.ThreadID != nil {
+		thread = *parent.ThreadID
+	}
+	c.ParentID = &parent.ID
+	c.ThreadID = &thread
+	c.Depth = parent.Depth + 1
+	if parent.Visibility == CommentInternal {
+		c.Visibility = CommentInternal
+	}
+	return nil
+}
+
+// CommentNode is a comment with the replies to it
+type CommentNode struct {
+	Comment
+	Replies []*CommentNode `json:"replies"`
+}
+
+// BuildCommentTree nests comments under the ones they reply to, keeping the
+// order they are given in. A reply whose parent isn't among the comments is
+// placed at the top level.
+func BuildCommentTree(comments []Comment) []*CommentNode {
+	nodes := make(map[uuid.UUID]*CommentNode, len(comments))
+	for _, c := range comments {
+		nodes[c.ID] = &CommentNode{Comment: c, Replies: []*CommentNode{}}
+	}
+
+	roots := []*CommentNode{}
+	for _, c := range comments {
+		node := nodes[c.ID]
+		if c.ParentID != nil {
+			if parent, ok := nodes[*c.ParentID]; ok && parent != node {
+				parent.Replies = append(parent.Replies, node)
+				continue
+			}
+		}
+		roots = append(roots, node)
+	}
+	return roots
+}
+
+// FlattenCommentTree lists the comments of a tree depth first, so each reply
+// comes after its parent and the earlier replies to it
+func FlattenCommentTree(nodes []*CommentNode) []Comment {
+	out := []Comment{}
+	var walk func([]*CommentNode)
+	walk = func(nodes []*CommentNode) {
+		for _, n := range nodes {
+			out = append(out, n.Comment)
+			walk(n.Replies)
+		}
+	}
+	walk(nodes)
+	return out
+}
+
+// PruneDeletedComments drops deleted comments from a tree unless replies
+// that are not deleted hang off them. Those that stay are placeholders,
+// with their text removed, so the replies keep their place in the thread.
+func PruneDeletedComments(nodes []*CommentNode) []*CommentNode {
+	kept := []*CommentNode{}
+	for _, n := range nodes {
+		n.Replies = PruneDeletedComments(n.Replies)
+		if n.DeletedAt != nil {
+			if len(n.Replies) == 0 {
+				continue
+			}
+			n.Description = ""
+		}
+		kept = append(kept, n)
+	}
+	return kept
+}
+
+// CommentRevision is the text a comment had before one of its edits
+type CommentRevision struct {
+	ID          uuid.UUID  `json:"id"`
+	CommentID   uuid.UUID  `json:"comment_id"`
+	Description string     `json:"description"`
+	EditedBy    *uuid.UUID `json:"edited_by"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// NormalizeCommentText trims the text of a comment and rejects it if nothing
+// is left or it is too long
+func NormalizeCommentText(text string) (string, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", ErrEmptyComment
+	}
+	if err := CheckCommentLength(text); err != nil {
+		return "", err
+	}
+	return text, nil
+}
+
+// CheckCommentLength rejects comment text longer than MaxCommentLength
+func CheckCommentLength(text string) error {
+	if utf8.RuneCountInString(text) > MaxCommentLength {
+		return ErrCommentTooLong
+	}
+	return nil
 }
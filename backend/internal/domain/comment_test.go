@@ -0,0 +1,180 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestCommentEditable(t *testing.T) {
+	created := time.Date(2025, time.March, 3, 10, 0, 0, 0, time.UTC)
+	comment := Comment{CreatedAt: created}
+	window := 15 * time.Minute
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		expected bool
+	}{
+		{"Just posted", created.Add(time.Second), true},
+		{"Inside window", created.Add(14 * time.Minute), true},
+		{"Window closed", created.Add(window), false},
+		{"Long after", created.Add(24 * time.Hour), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := comment.Editable(tt.now, window); got != tt.expected {
+				t.Errorf("Editable() = %v; want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNormalizeCommentText(t *testing.T) {
+	if got, err := NormalizeCommentText("  fixed the typo \n"); err != nil || got != "fixed the typo" {
+		t.Errorf("NormalizeCommentText() = %q, %v; want %q, nil", got, err, "fixed the typo")
+	}
+	if _, err := NormalizeCommentText(" \t "); err != ErrEmptyComment {
+		t.Errorf("NormalizeCommentText() = %v; want %v", err, ErrEmptyComment)
+	}
+	if _, err := NormalizeCommentText(strings.Repeat("é", MaxCommentLength)); err != nil {
+		t.Errorf("NormalizeCommentText() = %v; want nil", err)
+	}
+	if _, err := NormalizeCommentText(strings.Repeat("a", MaxCommentLength+1)); err != ErrCommentTooLong {
+		t.Errorf("NormalizeCommentText() = %v; want %v", err, ErrCommentTooLong)
+	}
+}
+
+func TestGetCommentVisibility(t *testing.T) {
+	tests := []struct {
+		in       string
+		expected CommentVisibility
+		valid    bool
+	}{
+		{"", CommentPublic, true},
+		{"public", CommentPublic, true},
+		{" Internal ", CommentInternal, true},
+		{"private", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := GetCommentVisibility(tt.in)
+			if got != tt.expected || (err == nil) != tt.valid {
+				t.Errorf("GetCommentVisibility(%q) = %q, %v; want %q", tt.in, got, err, tt.expected)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidCommentVisibility) {
+				t.Errorf("GetCommentVisibility(%q) error = %v; want %v", tt.in, err, ErrInvalidCommentVisibility)
+			}
+		})
+	}
+}
+
+func TestCommentReplyTo(t *testing.T) {
+	ticket := uuid.New()
+	root := Comment{ID: uuid.New(), TicketID: ticket, Visibility: CommentPublic}
+	reply := Comment{TicketID: ticket, Visibility: CommentPublic}
+	if err := reply.ReplyTo(root); err != nil {
+		t.Fatalf("ReplyTo(root) = %v", err)
+	}
+	if *reply.ParentID != root.ID || *reply.ThreadID != root.ID || reply.Depth != 1 {
+		t.Errorf("ReplyTo(root) = parent %v, thread %v, depth %d; want %v, %v, 1", reply.ParentID, reply.ThreadID, reply.Depth, root.ID, root.ID)
+	}
+
+	reply.ID = uuid.New()
+	nested := Comment{TicketID: ticket, Visibility: CommentPublic}
+	if err := nested.ReplyTo(reply); err != nil {
+		t.Fatalf("ReplyTo(reply) = %v", err)
+	}
+	if *nested.ParentID != reply.ID || *nested.ThreadID != root.ID || nested.Depth != 2 {
+		t.Errorf("ReplyTo(reply) = parent %v, thread %v, depth %d; want %v, %v, 2", nested.ParentID, nested.ThreadID, nested.Depth, reply.ID, root.ID)
+	}
+
+	note := Comment{ID: uuid.New(), TicketID: ticket, Visibility: CommentInternal}
+	answer := Comment{TicketID: ticket, Visibility: CommentPublic}
+	if err := answer.ReplyTo(note); err != nil || answer.Visibility != CommentInternal {
+		t.Errorf("ReplyTo(internal note) = %v, visibility %q; want nil, %q", err, answer.Visibility, CommentInternal)
+	}
+
+	deepest := Comment{ID: uuid.New(), TicketID: ticket, ThreadID: &root.ID, Depth: MaxCommentDepth}
+	if err := (&Comment{TicketID: ticket}).ReplyTo(deepest); err != ErrCommentTooDeep {
+		t.Errorf("ReplyTo(depth %d) = %v; want %v", MaxCommentDepth, err, ErrCommentTooDeep)
+	}
+	if err := (&Comment{TicketID: uuid.New()}).ReplyTo(root); err != ErrInvalidCommentParent {
+		t.Errorf("ReplyTo(other ticket) = %v; want %v", err, ErrInvalidCommentParent)
+	}
+}
+
+func TestCommentTree(t *testing.T) {
+	id := func() uuid.UUID { return uuid.New() }
+	a, b, a1, b1, a2, a11, orphan := id(), id(), id(), id(), id(), id(), id()
+	missing := id()
+	// Oldest first, as the repository returns them
+	comments := []Comment{
+		{ID: a},
+		{ID: b},
+		{ID: a1, ParentID: &a},
+		{ID: b1, ParentID: &b},
+		{ID: a2, ParentID: &a},
+		{ID: a11, ParentID: &a1},
+		{ID: orphan, ParentID: &missing},
+	}
+
+	tree := BuildCommentTree(comments)
+	if len(tree) != 3 || tree[0].ID != a || tree[1].ID != b || tree[2].ID != orphan {
+		t.Fatalf("BuildCommentTree() has %d top-level comments; want a, b and the orphan", len(tree))
+	}
+	if len(tree[0].Replies) != 2 || tree[0].Replies[0].ID != a1 || tree[0].Replies[1].ID != a2 {
+		t.Errorf("BuildCommentTree() replies to a = %d; want a1, a2", len(tree[0].Replies))
+	}
+	if len(tree[0].Replies[0].Replies) != 1 || tree[0].Replies[0].Replies[0].ID != a11 {
+		t.Errorf("BuildCommentTree() replies to a1 = %d; want a11", len(tree[0].Replies[0].Replies))
+	}
+
+	var got []uuid.UUID
+	for _, c := range FlattenCommentTree(tree) {
+		got = append(got, c.ID)
+	}
+	expected := []uuid.UUID{a, a1, a11, a2, b, b1, orphan}
+	if !slices.Equal(got, expected) {
+		t.Errorf("FlattenCommentTree() = %v; want %v", got, expected)
+	}
+}
+
+func TestPruneDeletedComments(t *testing.T) {
+	id := func() uuid.UUID { return uuid.New() }
+	now := time.Now()
+	a, a1, a11, b, b1, c := id(), id(), id(), id(), id(), id()
+	// a is deleted but has a live reply beneath a deleted one; b and its
+	// only reply are both deleted; c was never deleted
+	comments := []Comment{
+		{ID: a, Description: "secret", DeletedAt: &now},
+		{ID: b, Description: "gone", DeletedAt: &now},
+		{ID: c, Description: "kept"},
+		{ID: a1, ParentID: &a, Description: "also gone", DeletedAt: &now},
+		{ID: b1, ParentID: &b, Description: "gone too", DeletedAt: &now},
+		{ID: a11, ParentID: &a1, Description: "reply"},
+	}
+
+	got := FlattenCommentTree(PruneDeletedComments(BuildCommentTree(comments)))
+	var ids []uuid.UUID
+	for _, c := range got {
+		ids = append(ids, c.ID)
+	}
+	if expected := []uuid.UUID{a, a1, a11, c}; !slices.Equal(ids, expected) {
+		t.Fatalf("PruneDeletedComments() = %v; want %v", ids, expected)
+	}
+	for _, c := range got {
+		if c.DeletedAt != nil && c.Description != "" {
+			t.Errorf("PruneDeletedComments() kept the text of deleted comment %s: %q", c.ID, c.Description)
+		}
+	}
+	if got[2].Description != "reply" || got[3].Description != "kept" {
+		t.Errorf("PruneDeletedComments() changed comments that weren't deleted: %q, %q", got[2].Description, got[3].Description)
+	}
+}
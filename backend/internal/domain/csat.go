@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MinCSATRating = 1
+	MaxCSATRating = 5
+)
+
+var (
+	ErrInvalidRating = errors.New("rating must be between 1 and 5")
+	ErrSurveyClosed  = errors.New("survey is no longer accepting responses")
+)
+
+// CSATResponse is the creator's satisfaction rating for a resolved ticket.
+// AssignedTo is a snapshot of the assignees at the time of rating so scores
+// can be attributed per agent even after reassignment.
+type CSATResponse struct {
+	ID         uuid.UUID   `json:"id"`
+	TicketID   uuid.UUID   `json:"ticket_id"`
+	AssignedTo []uuid.UUID `json:"assigned_to"`
+	Rating     int         `json:"rating"`
+	Comment    string      `json:"comment"`
+	CreatedAt  time.Time   `json:"created_at"`
+	UpdatedAt  time.Time   `json:"updated_at"`
+}
+
+// CSATSummary aggregates ratings. Satisfied counts ratings of 4 or 5.
+type CSATSummary struct {
+	Responses     int64   `json:"responses"`
+	AverageRating float64 `json:"average_rating"`
+	Satisfied     int64   `json:"satisfied"`
+}
+
+type CSATAssigneeSummary struct {
+	AssigneeID uuid.UUID `json:"assignee_id"`
+	CSATSummary
+}
+
+func ValidateRating(rating int) error {
+	if rating < MinCSATRating || rating > MaxCSATRating {
+		return ErrInvalidRating
+	}
+	return nil
+}
+
+// AcceptsSurvey reports whether a ticket in this state can still be rated
+func (s TicketState) AcceptsSurvey() bool {
+	return s == TicketStateResolved || s == TicketStateClosed
+}
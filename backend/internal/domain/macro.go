@@ -0,0 +1,174 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrMacroNotFound      = errors.New("macro not found")
+	ErrEmptyMacroName     = errors.New("macro name is required")
+	ErrEmptyMacro         = errors.New("macro must have a reply or an action")
+	ErrUnknownPlaceholder = errors.New("unknown placeholder")
+)
+
+// Macro is a canned reply, optionally bundled with changes to the ticket it
+// is applied to. Macros are personal unless shared with the whole
+// organization, in which case they have no owner.
+type Macro struct {
+	ID         uuid.UUID         `json:"id"`
+	OrgID      uuid.UUID         `json:"org_id"`
+	OwnerID    *uuid.UUID        `json:"owner_id"`
+	CreatedBy  *uuid.UUID        `json:"created_by"`
+	Name       string            `json:"name"`
+	Reply      string            `json:"reply"`
+	Visibility CommentVisibility `json:"visibility"`
+	Actions    MacroActions      `json:"actions"`
+	CreatedAt  time.Time         `json:"created_at"`
+	UpdatedAt  time.Time         `json:"updated_at"`
+}
+
+// MacroApplication is what applying a macro produced: the reply it posted,
+// if it has one, and the ticket after its actions
+type MacroApplication struct {
+	Comment *Comment `json:"comment"`
+	Ticket  *Ticket  `json:"ticket"`
+}
+
+// Shared reports whether everyone in the macro's organization can use it
+func (m Macro) Shared() bool {
+	return m.OwnerID == nil
+}
+
+// MacroActions are the ticket changes a macro makes. Nil fields leave the
+// ticket as it is; labels are added to the ticket's own.
+type MacroActions struct {
+	State          *TicketState    `json:"state"`
+	ResolutionCode ResolutionCode  `json:"resolution_code,omitempty"`
+	Priority       *TicketPriority `json:"priority"`
+	AssignedTo     []uuid.UUID     `json:"assigned_to"`
+	Labels         []string        `json:"labels"`
+}
+
+// Empty reports whether the actions leave a ticket unchanged
+func (a MacroActions) Empty() bool {
+	return a.State == nil && a.Priority == nil && a.AssignedTo == nil && len(a.Labels) == 0
+}
+
+// Apply makes the changes to the ticket and returns the fields it updated,
+// as the ticket service expects them. Setting a priority overrides the
+// priority matrix.
+func (a MacroActions) Apply(t *Ticket) []string {
+	var fields []string
+	if a.State != nil {
+		t.State = *a.State
+		t.ResolutionCode = a.ResolutionCode
+		fields = append(fields, "state", "resolution_code")
+	}
+	if a.Priority != nil {
+		t.Priority = *a.Priority
+		t.PriorityOverridden = true
+		fields = append(fields, "priority")
+	}
+	if a.AssignedTo != nil {
+		t.AssignedTo = a.AssignedTo
+		fields = append(fields, "assigned_to")
+	}
+	if len(a.Labels) > 0 {
+		t.Labels = NormalizeLabels(append(t.Labels, a.Labels...))
+		fields = append(fields, "labels")
+	}
+	return fields
+}
+
+// Placeholders fill a macro's reply in for the ticket it is applied to.
+// Creator is whoever opened the ticket and Agent whoever applies the macro.
+type Placeholders struct {
+	Ticket  *Ticket
+	Creator *User
+	Agent   *User
+}
+
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([a-z_]+\.[a-z_]+)\s*\}\}`)
+
+var placeholderValues = map[string]func(p Placeholders) string{
+	"ticket.key":         func(p Placeholders) string { return p.Ticket.Key() },
+	"ticket.title":       func(p Placeholders) string { return p.Ticket.Title },
+	"ticket.state":       func(p Placeholders) string { return p.Ticket.State.String() },
+	"ticket.priority":    func(p Placeholders) string { return p.Ticket.Priority.String() },
+	"creator.first_name": func(p Placeholders) string { return p.Creator.FirstName },
+	"creator.last_name":  func(p Placeholders) string { return p.Creator.LastName },
+	"creator.email":      func(p Placeholders) string { return p.Creator.Email },
+	"agent.first_name":   func(p Placeholders) string { return p.Agent.FirstName },
+	"agent.last_name":    func(p Placeholders) string { return p.Agent.LastName },
+	"agent.email":        func(p Placeholders) string { return p.Agent.Email },
+}
+
+// ValidatePlaceholders checks that every placeholder in the reply is one
+// that Expand knows how to fill
+func ValidatePlaceholders(reply string) error {
+	for _, m := range placeholderPattern.FindAllStringSubmatch(reply, -1) {
+		if _, ok := placeholderValues[m[1]]; !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownPlaceholder, m[0])
+		}
+	}
+	return nil
+}
+
+// Expand fills the placeholders in reply. Unknown placeholders, and those
+// whose subject is missing, are left as written. A value that would start a
+// line with "/" has it escaped, so that text such as a ticket title can't
+// add slash commands to the reply.
+func (p Placeholders) Expand(reply string) string {
+	var b strings.Builder
+	last := 0
+	for _, m := range placeholderPattern.FindAllStringSubmatchIndex(reply, -1) {
+		b.WriteString(reply[last:m[0]])
+		last = m[1]
+		name := reply[m[2]:m[3]]
+		value, ok := placeholderValues[name]
+		if !ok || !p.has(name) {
+			b.WriteString(reply[m[0]:m[1]])
+			continue
+		}
+		line := b.String()[strings.LastIndexByte(b.String(), '\n')+1:]
+		b.WriteString(escapeCommands(value(p), strings.TrimSpace(line) == ""))
+	}
+	b.WriteString(reply[last:])
+	return b.String()
+}
+
+// escapeCommands puts a backslash before a "/" that starts a line of value,
+// counting its first line only if it is written at the start of one. Markdown
+// renders "\/" as "/", but ParseCommands doesn't read it as a command.
+func escapeCommands(value string, lineStart bool) string {
+	lines := strings.Split(value, "\n")
+	for i, line := range lines {
+		if i == 0 && !lineStart {
+			continue
+		}
+		rest := strings.TrimLeft(line, " \t")
+		if strings.HasPrefix(rest, "/") {
+			lines[i] = line[:len(line)-len(rest)] + "\\" + rest
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (p Placeholders) has(name string) bool {
+	switch subject, _, _ := strings.Cut(name, "."); subject {
+	case "ticket":
+		return p.Ticket != nil
+	case "creator":
+		return p.Creator != nil
+	case "agent":
+		return p.Agent != nil
+	default:
+		return false
+	}
+}
@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestPlaceholdersExpand(t *testing.T) {
+	ticket := &Ticket{ID: uuid.MustParse("0a1b2c3d-0000-0000-0000-000000000000"), Title: "VPN down", State: TicketStateOpen}
+	creator := &User{FirstName: "Ada", LastName: "Lovelace", Email: "ada@example.com"}
+	agent := &User{FirstName: "Grace"}
+
+	tests := []struct {
+		name     string
+		p        Placeholders
+		reply    string
+		expected string
+	}{
+		{"No placeholders", Placeholders{}, "Thanks!", "Thanks!"},
+		{
+			"Filled",
+			Placeholders{Ticket: ticket, Creator: creator, Agent: agent},
+			"Hi {{creator.first_name}}, {{ticket.key}} ({{ ticket.title }}) is {{ticket.state}}. {{agent.first_name}}",
+			"Hi Ada, 0A1B2C3D (VPN down) is open. Grace",
+		},
+		{"Missing subject", Placeholders{Ticket: ticket}, "Hi {{creator.first_name}}", "Hi {{creator.first_name}}"},
+		{"Unknown", Placeholders{Ticket: ticket}, "{{ticket.secret}}", "{{ticket.secret}}"},
+		{"Not a placeholder", Placeholders{Ticket: ticket}, "{{ticket.key", "{{ticket.key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.Expand(tt.reply); got != tt.expected {
+				t.Errorf("Expand(%q) = %q; want %q", tt.reply, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPlaceholdersExpandEscapesCommands(t *testing.T) {
+	tests := []struct {
+		name     string
+		title    string
+		reply    string
+		expected string
+	}{
+		{"Title as the reply", "/close", "{{ticket.title}}", `\/close`},
+		{"Title after a command", "/cancel duplicate", "/label vpn\n  {{ticket.title}}", "/label vpn\n  \\/cancel duplicate"},
+		{"Title with a new line", "Help\n/assign me", "Re: {{ticket.title}}", "Re: Help\n\\/assign me"},
+		{"Title inside a line", "/var/log is full", "Re: {{ticket.title}}", "Re: /var/log is full"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := Placeholders{Ticket: &Ticket{Title: tt.title}}
+			got := p.Expand(tt.reply)
+			if got != tt.expected {
+				t.Errorf("Expand(%q) = %q; want %q", tt.reply, got, tt.expected)
+			}
+			commands, err := ParseCommands(got)
+			if err != nil {
+				t.Fatalf("ParseCommands(%q) = %v", got, err)
+			}
+			for _, c := range commands {
+				if c.Name != CommandLabel {
+					t.Errorf("ParseCommands(%q) found /%s from the title", got, c.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestValidatePlaceholders(t *testing.T) {
+	if err := ValidatePlaceholders("Hi {{creator.first_name}}, re {{ticket.key}}"); err != nil {
+		t.Errorf("ValidatePlaceholders() = %v; want nil", err)
+	}
+	if err := ValidatePlaceholders("Hi {{creator.password}}"); !errors.Is(err, ErrUnknownPlaceholder) {
+		t.Errorf("ValidatePlaceholders() = %v; want %v", err, ErrUnknownPlaceholder)
+	}
+}
+
+func TestMacroActionsApply(t *testing.T) {
+	agent := uuid.New()
+	resolved := TicketStateResolved
+	low := TicketPriorityLow
+
+	ticket := Ticket{State: TicketStatePending, Priority: TicketPriorityHigh, Labels: []string{"vpn"}}
+	fields := MacroActions{
+		State:          &resolved,
+		ResolutionCode: ResolutionFixed,
+		Priority:       &low,
+		AssignedTo:     []uuid.UUID{agent},
+		Labels:         []string{" VPN ", "Network"},
+	}.Apply(&ticket)
+
+	expected := []string{"state", "resolution_code", "priority", "assigned_to", "labels"}
+	if !slices.Equal(fields, expected) {
+		t.Errorf("Apply() fields = %v; want %v", fields, expected)
+	}
+	if ticket.State != resolved || ticket.ResolutionCode != ResolutionFixed {
+		t.Errorf("State = %v (%v); want %v (%v)", ticket.State, ticket.ResolutionCode, resolved, ResolutionFixed)
+	}
+	if ticket.Priority != low || !ticket.PriorityOverridden {
+		t.Errorf("Priority = %v (overridden %v); want %v overridden", ticket.Priority, ticket.PriorityOverridden, low)
+	}
+	if !slices.Equal(ticket.AssignedTo, []uuid.UUID{agent}) {
+		t.Errorf("AssignedTo = %v; want [%v]", ticket.AssignedTo, agent)
+	}
+	if !slices.Equal(ticket.Labels, []string{"vpn", "network"}) {
+		t.Errorf("Labels = %v; want [vpn network]", ticket.Labels)
+	}
+}
+
+func TestMacroActionsApplyNothing(t *testing.T) {
+	ticket := Ticket{State: TicketStateOpen}
+	actions := MacroActions{}
+	if !actions.Empty() {
+		t.Error("Empty() = false; want true")
+	}
+	if fields := actions.Apply(&ticket); len(fields) != 0 {
+		t.Errorf("Apply() fields = %v; want none", fields)
+	}
+	if ticket.State != TicketStateOpen {
+		t.Errorf("State = %v; want %v", ticket.State, TicketStateOpen)
+	}
+}
+
+func TestNormalizeLabels(t *testing.T) {
+	got := NormalizeLabels([]string{" Billing", "billing", "", "VPN "})
+	if !slices.Equal(got, []string{"billing", "vpn"}) {
+		t.Errorf("NormalizeLabels() = %v; want [billing vpn]", got)
+	}
+	if got := NormalizeLabels(nil); got == nil {
+		t.Error("NormalizeLabels(nil) = nil; want empty")
+	}
+}
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// MentionPolicy decides whether mentioning someone on a ticket they can't
+// see also lets them read it, or only notifies them
+type MentionPolicy string
+
+const (
+	MentionPolicyGrant  MentionPolicy = "grant"
+	MentionPolicyNotify MentionPolicy = "notify"
+)
+
+// Mention records that a user was mentioned in a ticket's description, or in
+// one of its comments when CommentID is set
+type Mention struct {
+	ID        uuid.UUID  `json:"id"`
+	TicketID  uuid.UUID  `json:"ticket_id"`
+	CommentID *uuid.UUID `json:"comment_id"`
+	UserID    uuid.UUID  `json:"user_id"`
+	CreatedBy *uuid.UUID `json:"created_by"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// ParseMentions returns the distinct handles mentioned in text, lowercased
+// and in order of appearance. A handle is "@" followed by an email address
+// or by a username, the part of an email address before the "@". The "@"
+// must not follow a letter or digit, so plain email addresses in the text
+// are not mentions.
+func ParseMentions(text string) []string {
+	var handles []string
+	seen := map[string]bool{}
+	runes := []rune(text)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '@' || (i > 0 && isHandleRune(runes[i-1])) {
+			continue
+		}
+		j := i + 1
+		for j < len(runes) && (isHandleRune(runes[j]) || runes[j] == '@') {
+			j++
+		}
+		handle := strings.ToLower(strings.TrimRight(string(runes[i+1:j]), ".-@"))
+		i = j - 1
+		if handle == "" || !validHandle(handle) || seen[handle] {
+			continue
+		}
+		seen[handle] = true
+		handles = append(handles, handle)
+	}
+	return handles
+}
+
+func isHandleRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("._%+-", r)
+}
+
+// validHandle rejects handles with more than one "@" or an empty name or
+// domain
+func validHandle(handle string) bool {
+	name, domainName, isEmail := strings.Cut(handle, "@")
+	if !isEmail {
+		return true
+	}
+	return name != "" && domainName != "" && !strings.Contains(domainName, "@")
+}
+
+// ResolveMentions matches handles against candidate users. Email handles
+// match an email address exactly; usernames match the part of an email
+// address before the "@", and are skipped when more than one user shares
+// it. Each user is returned once.
+func ResolveMentions(handles []string, candidates []User) []User {
+	var out []User
+	seen := map[uuid.UUID]bool{}
+	for _, handle := range handles {
+		var matches []User
+		for _, u := range candidates {
+			email := strings.ToLower(u.Email)
+			if strings.Contains(handle, "@") && email == handle {
+				matches = append(matches, u)
+			}
+			if name, _, _ := strings.Cut(email, "@"); !strings.Contains(handle, "@") && name == handle {
+				matches = append(matches, u)
+			}
+		}
+		if len(matches) != 1 || seen[matches[0].ID] {
+			continue
+		}
+		seen[matches[0].ID] = true
+		out = append(out, matches[0])
+	}
+	return out
+}
+
+// SplitMentionHandles separates email handles from usernames
+func SplitMentionHandles(handles []string) (emails, names []string) {
+	emails, names = []string{}, []string{}
+	for _, handle := range handles {
+		if strings.Contains(handle, "@") {
+			emails = append(emails, handle)
+		} else {
+			names = append(names, handle)
+		}
+	}
+	return emails, names
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestParseMentions(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{"No mentions", "printer is on fire", nil},
+		{"Username", "@jane can you look?", []string{"jane"}},
+		{"Email", "cc @Bob.Smith@Example.com", []string{"bob.smith@example.com"}},
+		{"Trailing punctuation", "thanks @jane. and (@bob), also @ops-team!", []string{"jane", "bob", "ops-team"}},
+		{"Plain email address", "write to help@example.com", nil},
+		{"Duplicates", "@jane @JANE @jane", []string{"jane"}},
+		{"Lone at sign", "meet @ 10 or @@ noon", nil},
+		{"Empty email domain", "@jane@ said", []string{"jane"}},
+		{"Across lines", "first\n@jane\n@bob@example.com", []string{"jane", "bob@example.com"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseMentions(tt.text); !slices.Equal(got, tt.expected) {
+				t.Errorf("ParseMentions(%q) = %q; want %q", tt.text, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestResolveMentions(t *testing.T) {
+	jane := User{ID: uuid.New(), Email: "Jane@example.com"}
+	janeOther := User{ID: uuid.New(), Email: "jane@other.example"}
+	bob := User{ID: uuid.New(), Email: "bob@example.com"}
+	candidates := []User{jane, janeOther, bob}
+
+	tests := []struct {
+		name     string
+		handles  []string
+		expected []uuid.UUID
+	}{
+		{"Email", []string{"jane@example.com"}, []uuid.UUID{jane.ID}},
+		{"Username", []string{"bob"}, []uuid.UUID{bob.ID}},
+		{"Ambiguous username", []string{"jane"}, nil},
+		{"Unknown", []string{"carol", "carol@example.com"}, nil},
+		{"Same user twice", []string{"bob", "bob@example.com"}, []uuid.UUID{bob.ID}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []uuid.UUID
+			for _, u := range ResolveMentions(tt.handles, candidates) {
+				got = append(got, u.ID)
+			}
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("ResolveMentions(%q) = %v; want %v", tt.handles, got, tt.expected)
+			}
+		})
+	}
+}
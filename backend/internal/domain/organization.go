@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultOrganizationID is the organization that held all users and tickets
+// before organizations existed. Its admins manage the other organizations.
+var DefaultOrganizationID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+var (
+	ErrEmptyOrganizationName = errors.New("organization name is required")
+	ErrInvalidEmailDomain    = errors.New("email domain must look like example.com")
+	ErrOrgAccessNotStaff     = errors.New("only agents and admins can work in other organizations")
+)
+
+// Organization is a tenant. Users belong to one organization and tickets to
+// their creator's; people signing up with an address at EmailDomain join it.
+// CalendarID is its business calendar, used unless a ticket's team has one.
+type Organization struct {
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	EmailDomain string     `json:"email_domain"`
+	CalendarID  *uuid.UUID `json:"calendar_id"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// Normalize trims the name, lower-cases the domain and validates both
+func (o *Organization) Normalize() error {
+	o.Name = strings.TrimSpace(o.Name)
+	o.EmailDomain = strings.ToLower(strings.TrimSpace(o.EmailDomain))
+	if o.Name == "" {
+		return ErrEmptyOrganizationName
+	}
+	if o.EmailDomain == "" {
+		return nil
+	}
+	if strings.ContainsAny(o.EmailDomain, "@ /") || !strings.Contains(o.EmailDomain, ".") ||
+		strings.HasPrefix(o.EmailDomain, ".") || strings.HasSuffix(o.EmailDomain, ".") {
+		return ErrInvalidEmailDomain
+	}
+	return nil
+}
+
+// EmailDomain returns the lower-cased domain of an address, or "" if it has none
+func EmailDomain(email string) string {
+	at := strings.LastIndexByte(email, '@')
+	if at < 0 {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(email[at+1:]))
+}
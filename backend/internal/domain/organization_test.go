@@ -0,0 +1,55 @@
+package domain
+
+import "testing"
+
+func TestEmailDomain(t *testing.T) {
+	tests := []struct {
+		email    string
+		expected string
+	}{
+		{"jane@Example.com", "example.com"},
+		{"odd@name@corp.example.org", "corp.example.org"},
+		{"no-domain", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.email, func(t *testing.T) {
+			if got := EmailDomain(tt.email); got != tt.expected {
+				t.Errorf("EmailDomain(%q) = %q; want %q", tt.email, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestOrganizationNormalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		org      Organization
+		expected error
+	}{
+		{"Name only", Organization{Name: "Acme"}, nil},
+		{"Name and domain", Organization{Name: "Acme", EmailDomain: "acme.com"}, nil},
+		{"Blank name", Organization{Name: "  ", EmailDomain: "acme.com"}, ErrEmptyOrganizationName},
+		{"Address instead of domain", Organization{Name: "Acme", EmailDomain: "it@acme.com"}, ErrInvalidEmailDomain},
+		{"No dot", Organization{Name: "Acme", EmailDomain: "acme"}, ErrInvalidEmailDomain},
+		{"Leading dot", Organization{Name: "Acme", EmailDomain: ".acme.com"}, ErrInvalidEmailDomain},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			org := tt.org
+			if err := org.Normalize(); err != tt.expected {
+				t.Errorf("Normalize() = %v; want %v", err, tt.expected)
+			}
+		})
+	}
+
+	org := Organization{Name: " Acme ", EmailDomain: " ACME.com "}
+	if err := org.Normalize(); err != nil {
+		t.Fatalf("Normalize() = %v", err)
+	}
+	if org.Name != "Acme" || org.EmailDomain != "acme.com" {
+		t.Errorf("Normalize() left %q, %q; want %q, %q", org.Name, org.EmailDomain, "Acme", "acme.com")
+	}
+}
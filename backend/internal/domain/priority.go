@@ -0,0 +1,175 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type TicketImpact int
+type TicketUrgency int
+
+const (
+	// Impacts
+	TicketImpactHigh   TicketImpact = iota + 1 // 1
+	TicketImpactMedium                         // 2
+	TicketImpactLow                            // 3
+)
+
+const (
+	// Urgencies
+	TicketUrgencyHigh   TicketUrgency = iota + 1 // 1
+	TicketUrgencyMedium                          // 2
+	TicketUrgencyLow                             // 3
+)
+
+var (
+	ErrInvalidImpact            = errors.New("invalid impact")
+	ErrInvalidUrgency           = errors.New("invalid urgency")
+	ErrInvalidPriority          = errors.New("invalid priority")
+	ErrIncompletePriorityMatrix = errors.New("priority matrix must cover every impact and urgency")
+)
+
+// Impacts and Urgencies list every level, most severe first
+var (
+	Impacts   = []TicketImpact{TicketImpactHigh, TicketImpactMedium, TicketImpactLow}
+	Urgencies = []TicketUrgency{TicketUrgencyHigh, TicketUrgencyMedium, TicketUrgencyLow}
+)
+
+func (i TicketImpact) String() string {
+	switch i {
+	case TicketImpactHigh:
+		return "high"
+	case TicketImpactMedium:
+		return "medium"
+	case TicketImpactLow:
+		return "low"
+	default:
+		return "unknown"
+	}
+}
+
+func GetTicketImpact(s string) (TicketImpact, error) {
+	switch strings.ToLower(s) {
+	case "high":
+		return TicketImpactHigh, nil
+	case "medium":
+		return TicketImpactMedium, nil
+	case "low":
+		return TicketImpactLow, nil
+	default:
+		return 0, fmt.Errorf("%w: %s", ErrInvalidImpact, s)
+	}
+}
+
+func (u TicketUrgency) String() string {
+	switch u {
+	case TicketUrgencyHigh:
+		return "high"
+	case TicketUrgencyMedium:
+		return "medium"
+	case TicketUrgencyLow:
+		return "low"
+	default:
+		return "unknown"
+	}
+}
+
+func GetTicketUrgency(s string) (TicketUrgency, error) {
+	switch strings.ToLower(s) {
+	case "high":
+		return TicketUrgencyHigh, nil
+	case "medium":
+		return TicketUrgencyMedium, nil
+	case "low":
+		return TicketUrgencyLow, nil
+	default:
+		return 0, fmt.Errorf("%w: %s", ErrInvalidUrgency, s)
+	}
+}
+
+// PriorityMatrixEntry maps one impact and urgency pair to a priority
+type PriorityMatrixEntry struct {
+	Impact   TicketImpact   `json:"impact"`
+	Urgency  TicketUrgency  `json:"urgency"`
+	Priority TicketPriority `json:"priority"`
+}
+
+type PriorityMatrix map[TicketImpact]map[TicketUrgency]TicketPriority
+
+// DefaultPriorityMatrix is used until an admin configures one
+func DefaultPriorityMatrix() PriorityMatrix {
+	return PriorityMatrix{
+		TicketImpactHigh: {
+			TicketUrgencyHigh:   TicketPriorityCritical,
+			TicketUrgencyMedium: TicketPriorityHigh,
+			TicketUrgencyLow:    TicketPriorityMedium,
+		},
+		TicketImpactMedium: {
+			TicketUrgencyHigh:   TicketPriorityHigh,
+			TicketUrgencyMedium: TicketPriorityMedium,
+			TicketUrgencyLow:    TicketPriorityLow,
+		},
+		TicketImpactLow: {
+			TicketUrgencyHigh:   TicketPriorityMedium,
+			TicketUrgencyMedium: TicketPriorityLow,
+			TicketUrgencyLow:    TicketPriorityLow,
+		},
+	}
+}
+
+// NewPriorityMatrix builds a matrix from entries, rejecting unknown levels
+// and matrices that leave any combination undefined
+func NewPriorityMatrix(entries []PriorityMatrixEntry) (PriorityMatrix, error) {
+	m := PriorityMatrix{}
+	for _, e := range entries {
+		if e.Impact.String() == "unknown" {
+			return nil, ErrInvalidImpact
+		}
+		if e.Urgency.String() == "unknown" {
+			return nil, ErrInvalidUrgency
+		}
+		if e.Priority.String() == "unknown" {
+			return nil, ErrInvalidPriority
+		}
+		if m[e.Impact] == nil {
+			m[e.Impact] = map[TicketUrgency]TicketPriority{}
+		}
+		m[e.Impact][e.Urgency] = e.Priority
+	}
+	for _, impact := range Impacts {
+		for _, urgency := range Urgencies {
+			if _, ok := m[impact][urgency]; !ok {
+				return nil, ErrIncompletePriorityMatrix
+			}
+		}
+	}
+	return m, nil
+}
+
+// Priority derives a priority from impact and urgency, falling back to the
+// default matrix for combinations this matrix does not define
+func (m PriorityMatrix) Priority(impact TicketImpact, urgency TicketUrgency) TicketPriority {
+	if p, ok := m[impact][urgency]; ok {
+		return p
+	}
+	if p, ok := DefaultPriorityMatrix()[impact][urgency]; ok {
+		return p
+	}
+	return TicketPriorityLow
+}
+
+// Entries flattens the matrix, most severe combinations first
+func (m PriorityMatrix) Entries() []PriorityMatrixEntry {
+	entries := make([]PriorityMatrixEntry, 0, len(Impacts)*len(Urgencies))
+	for _, impact := range Impacts {
+		for _, urgency := range Urgencies {
+			entries = append(entries, PriorityMatrixEntry{
+				Impact:   impact,
+				Urgency:  urgency,
+				Priority: m.Priority(impact, urgency),
+			})
+		}
+	}
+	return entries
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestDefaultPriorityMatrix(t *testing.T) {
+	tests := []struct {
+		name     string
+		impact   TicketImpact
+		urgency  TicketUrgency
+		expected TicketPriority
+	}{
+		{"High impact, high urgency", TicketImpactHigh, TicketUrgencyHigh, TicketPriorityCritical},
+		{"High impact, low urgency", TicketImpactHigh, TicketUrgencyLow, TicketPriorityMedium},
+		{"Medium impact, high urgency", TicketImpactMedium, TicketUrgencyHigh, TicketPriorityHigh},
+		{"Medium impact, medium urgency", TicketImpactMedium, TicketUrgencyMedium, TicketPriorityMedium},
+		{"Low impact, high urgency", TicketImpactLow, TicketUrgencyHigh, TicketPriorityMedium},
+		{"Low impact, low urgency", TicketImpactLow, TicketUrgencyLow, TicketPriorityLow},
+	}
+
+	m := DefaultPriorityMatrix()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := m.Priority(tt.impact, tt.urgency)
+			if result != tt.expected {
+				t.Errorf("Priority(%v, %v) = %v; want %v", tt.impact, tt.urgency, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNewPriorityMatrix(t *testing.T) {
+	entries := DefaultPriorityMatrix().Entries()
+	entries[len(entries)-1].Priority = TicketPriorityHigh
+
+	m, err := NewPriorityMatrix(entries)
+	if err != nil {
+		t.Fatalf("NewPriorityMatrix() returned error: %v", err)
+	}
+	if p := m.Priority(TicketImpactLow, TicketUrgencyLow); p != TicketPriorityHigh {
+		t.Errorf("Priority(low, low) = %v; want %v", p, TicketPriorityHigh)
+	}
+
+	if _, err := NewPriorityMatrix(entries[1:]); err != ErrIncompletePriorityMatrix {
+		t.Errorf("NewPriorityMatrix() with missing entry error = %v; want %v", err, ErrIncompletePriorityMatrix)
+	}
+
+	entries[0].Priority = TicketPriority(9)
+	if _, err := NewPriorityMatrix(entries); err != ErrInvalidPriority {
+		t.Errorf("NewPriorityMatrix() with invalid priority error = %v; want %v", err, ErrInvalidPriority)
+	}
+}
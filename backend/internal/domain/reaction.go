@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+var ErrInvalidReaction = errors.New("reaction must be a single emoji")
+
+// Reaction is how many people reacted to a comment with an emoji, and
+// whether the caller is one of them
+type Reaction struct {
+	Emoji       string `json:"emoji"`
+	Count       int    `json:"count"`
+	ReactedByMe bool   `json:"reacted_by_me"`
+}
+
+const (
+	zeroWidthJoiner   = 0x200D
+	variationSelector = 0xFE0F
+	combiningKeycap   = 0x20E3
+)
+
+// NormalizeReaction trims an emoji and checks that it is exactly one. An
+// emoji may carry a skin tone, join others into one with zero-width joiners
+// (as family emoji do) or be a flag made of two regional indicators.
+func NormalizeReaction(emoji string) (string, error) {
+	emoji = strings.TrimSpace(emoji)
+	bases := 0
+	joined, flagHalf := false, false
+	for _, r := range emoji {
+		switch {
+		case r == zeroWidthJoiner:
+			joined = true
+			continue
+		case r == variationSelector, r == combiningKeycap, r >= 0x1F3FB && r <= 0x1F3FF, r >= 0xE0020 && r <= 0xE007F:
+			// Presentation selectors, keycaps, skin tones and the tags of
+			// subdivision flags modify the emoji before them
+		case r >= 0x1F1E6 && r <= 0x1F1FF:
+			// The second regional indicator of a flag doesn't start a new
+			// emoji
+			if !flagHalf {
+				bases++
+			}
+			flagHalf = !flagHalf
+		case unicode.Is(unicode.So, r):
+			if !joined {
+				bases++
+			}
+		default:
+			return "", ErrInvalidReaction
+		}
+		joined = false
+	}
+	if bases != 1 || flagHalf {
+		return "", ErrInvalidReaction
+	}
+	return emoji, nil
+}
@@ -0,0 +1,37 @@
+package domain
+
+import "testing"
+
+func TestNormalizeReaction(t *testing.T) {
+	tests := []struct {
+		name     string
+		emoji    string
+		expected string
+		valid    bool
+	}{
+		{"Thumbs up", "👍", "👍", true},
+		{"Trimmed", " 👀 ", "👀", true},
+		{"Skin tone", "👍🏽", "👍🏽", true},
+		{"Presentation selector", "❤️", "❤️", true},
+		{"Joined", "👩‍💻", "👩‍💻", true},
+		{"Flag", "🇳🇱", "🇳🇱", true},
+		{"Empty", "  ", "", false},
+		{"Text", "+1", "", false},
+		{"Two emoji", "👍👍", "", false},
+		{"Emoji and text", "👍 ok", "", false},
+		{"Half a flag", "🇳", "", false},
+		{"Markup", "<b>", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeReaction(tt.emoji)
+			if got != tt.expected || (err == nil) != tt.valid {
+				t.Errorf("NormalizeReaction(%q) = %q, %v; want %q", tt.emoji, got, err, tt.expected)
+			}
+			if err != nil && err != ErrInvalidReaction {
+				t.Errorf("NormalizeReaction(%q) error = %v; want %v", tt.emoji, err, ErrInvalidReaction)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrEmptyViewName       = errors.New("view name is required")
+	ErrInvalidTicketSort   = errors.New("invalid ticket sort")
+	ErrInvalidViewColumn   = errors.New("invalid view column")
+	ErrViewTeamOrgMismatch = errors.New("team belongs to a different organization than the view")
+)
+
+// TicketSort names the order of a ticket listing. A leading "-" sorts
+// descending; the empty sort orders by id and "rank" by board position.
+type TicketSort string
+
+var validTicketSorts = []TicketSort{
+	"", "priority", "-priority", "created_at", "-created_at", "updated_at", "-updated_at", "rank",
+}
+
+func (s TicketSort) Validate() error {
+	if !slices.Contains(validTicketSorts, s) {
+		return ErrInvalidTicketSort
+	}
+	return nil
+}
+
+// TicketColumns are the ticket fields a view may choose to display
+var TicketColumns = []string{
+	"id", "title", "state", "priority", "impact", "urgency",
+	"assigned_to", "created_by", "created_at", "updated_at",
+}
+
+func ValidateColumns(columns []string) error {
+	for _, c := range columns {
+		if !slices.Contains(TicketColumns, c) {
+			return ErrInvalidViewColumn
+		}
+	}
+	return nil
+}
+
+// TicketScope restricts a listing to the tickets a role may see. The zero
+// value is unrestricted. Tickets queued to one of TeamIDs are in scope
+// alongside those assigned to AssignedTo.
+type TicketScope struct {
+	CreatedBy  *uuid.UUID
+	AssignedTo *uuid.UUID
+	TeamIDs    []uuid.UUID
+}
+
+// TicketFilter narrows a listing within its scope. Empty fields match
+// everything. AssignedToMe and CreatedByMe are stored on views so that a
+// shared view follows whoever opens it; Resolve turns them into ids.
+type TicketFilter struct {
+	States       []TicketState    `json:"states"`
+	Priorities   []TicketPriority `json:"priorities"`
+	AssigneeID   *uuid.UUID       `json:"assignee_id"`
+	AssignedToMe bool             `json:"assigned_to_me"`
+	Unassigned   bool             `json:"unassigned"`
+	CreatedBy    *uuid.UUID       `json:"created_by"`
+	CreatedByMe  bool             `json:"created_by_me"`
+	Search       string           `json:"search"`
+	Sort         TicketSort       `json:"sort"`
+}
+
+// Resolve returns the filter as seen by the given user
+func (f TicketFilter) Resolve(viewer uuid.UUID) TicketFilter {
+	if f.AssignedToMe {
+		f.AssigneeID = &viewer
+	}
+	if f.CreatedByMe {
+		f.CreatedBy = &viewer
+	}
+	f.AssignedToMe, f.CreatedByMe = false, false
+	return f
+}
+
+// SavedView is a named filter, sort and column layout. Views are private to
+// their owner unless shared with a role within the owner's organization or
+// with a team.
+type SavedView struct {
+	ID           uuid.UUID    `json:"id"`
+	OwnerID      uuid.UUID    `json:"owner_id"`
+	OrgID        uuid.UUID    `json:"org_id"`
+	Name         string       `json:"name"`
+	Filter       TicketFilter `json:"filter"`
+	Columns      []string     `json:"columns"`
+	SharedRole   *UserRole    `json:"shared_role"`
+	SharedTeamID *uuid.UUID   `json:"shared_team_id"`
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestTicketFilterResolve(t *testing.T) {
+	viewer := uuid.New()
+	other := uuid.New()
+
+	f := TicketFilter{AssignedToMe: true, CreatedBy: &other}.Resolve(viewer)
+	if f.AssigneeID == nil || *f.AssigneeID != viewer {
+		t.Errorf("AssigneeID = %v; want %v", f.AssigneeID, viewer)
+	}
+	if f.CreatedBy == nil || *f.CreatedBy != other {
+		t.Errorf("CreatedBy = %v; want %v", f.CreatedBy, other)
+	}
+	if f.AssignedToMe || f.CreatedByMe {
+		t.Error("Resolve() should clear the relative flags")
+	}
+
+	f = TicketFilter{CreatedByMe: true}.Resolve(viewer)
+	if f.CreatedBy == nil || *f.CreatedBy != viewer {
+		t.Errorf("CreatedBy = %v; want %v", f.CreatedBy, viewer)
+	}
+	if f.AssigneeID != nil {
+		t.Errorf("AssigneeID = %v; want nil", f.AssigneeID)
+	}
+}
+
+func TestTicketSortValidate(t *testing.T) {
+	tests := []struct {
+		sort  TicketSort
+		valid bool
+	}{
+		{"", true},
+		{"priority", true},
+		{"-updated_at", true},
+		{"title", false},
+		{"-id; DROP TABLE tickets", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.sort), func(t *testing.T) {
+			err := tt.sort.Validate()
+			if (err == nil) != tt.valid {
+				t.Errorf("Validate(%q) error = %v; want valid %v", tt.sort, err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestValidateColumns(t *testing.T) {
+	if err := ValidateColumns([]string{"title", "state", "priority"}); err != nil {
+		t.Errorf("ValidateColumns() returned error: %v", err)
+	}
+	if err := ValidateColumns([]string{"title", "password"}); err != ErrInvalidViewColumn {
+		t.Errorf("ValidateColumns() error = %v; want %v", err, ErrInvalidViewColumn)
+	}
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token was already used")
+)
+
+// RefreshToken is the stored record of a refresh token. Every refresh uses
+// the token up and issues another in the same family; a family starts at
+// login and ends at logout, or when a used token is presented again, which
+// means it was stolen.
+type RefreshToken struct {
+	ID        uuid.UUID  `json:"id"`
+	UserID    uuid.UUID  `json:"user_id"`
+	FamilyID  uuid.UUID  `json:"family_id"`
+	TokenHash string     `json:"-"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// Check reports whether the token may be exchanged for a new one at now.
+// A used token gives ErrRefreshTokenReused, upon which the caller revokes
+// its family.
+func (t RefreshToken) Check(now time.Time) error {
+	if t.RevokedAt != nil || !now.Before(t.ExpiresAt) {
+		return ErrInvalidRefreshToken
+	}
+	if t.UsedAt != nil {
+		return ErrRefreshTokenReused
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRefreshTokenCheck(t *testing.T) {
+	now := time.Now()
+	earlier := now.Add(-time.Minute)
+
+	tests := []struct {
+		name     string
+		token    RefreshToken
+		expected error
+	}{
+		{"Fresh", RefreshToken{ExpiresAt: now.Add(time.Hour)}, nil},
+		{"Expired", RefreshToken{ExpiresAt: now}, ErrInvalidRefreshToken},
+		{"Revoked", RefreshToken{ExpiresAt: now.Add(time.Hour), RevokedAt: &earlier}, ErrInvalidRefreshToken},
+		{"Used", RefreshToken{ExpiresAt: now.Add(time.Hour), UsedAt: &earlier}, ErrRefreshTokenReused},
+		{"Used then revoked", RefreshToken{ExpiresAt: now.Add(time.Hour), UsedAt: &earlier, RevokedAt: &earlier}, ErrInvalidRefreshToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.token.Check(now); err != tt.expected {
+				t.Errorf("Check() = %v; want %v", err, tt.expected)
+			}
+		})
+	}
+}
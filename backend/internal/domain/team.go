@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrEmptyTeamName      = errors.New("team name is required")
+	ErrTeamMemberNotStaff = errors.New("only agents and admins can join a team")
+	ErrTeamMemberOrg      = errors.New("user cannot work in the team's organization")
+	ErrRemoveTeamLead     = errors.New("choose another team lead before removing this member")
+	ErrTeamOrgMismatch    = errors.New("team belongs to a different organization than the ticket")
+)
+
+// Team is a group of agents in one organization. Tickets queued to a team
+// are visible to all of its members; the lead manages who is on it.
+type Team struct {
+	ID         uuid.UUID   `json:"id"`
+	OrgID      uuid.UUID   `json:"org_id"`
+	Name       string      `json:"name"`
+	LeadID     *uuid.UUID  `json:"lead_id"`
+	Members    []uuid.UUID `json:"members"`
+	CalendarID *uuid.UUID  `json:"calendar_id"`
+	CreatedAt  time.Time   `json:"created_at"`
+	UpdatedAt  time.Time   `json:"updated_at"`
+}
+
+// Normalize trims the name and validates it
+func (t *Team) Normalize() error {
+	t.Name = strings.TrimSpace(t.Name)
+	if t.Name == "" {
+		return ErrEmptyTeamName
+	}
+	return nil
+}
+
+func (t Team) IsLead(userID uuid.UUID) bool {
+	return t.LeadID != nil && *t.LeadID == userID
+}
+
+func (t Team) IsMember(userID uuid.UUID) bool {
+	for _, id := range t.Members {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestTeamNormalize(t *testing.T) {
+	team := Team{Name: "  Networking "}
+	if err := team.Normalize(); err != nil {
+		t.Fatalf("Normalize() = %v", err)
+	}
+	if team.Name != "Networking" {
+		t.Errorf("Normalize() left %q; want %q", team.Name, "Networking")
+	}
+
+	blank := Team{Name: " "}
+	if err := blank.Normalize(); err != ErrEmptyTeamName {
+		t.Errorf("Normalize() = %v; want %v", err, ErrEmptyTeamName)
+	}
+}
+
+func TestTeamMembership(t *testing.T) {
+	lead := uuid.New()
+	member := uuid.New()
+	outsider := uuid.New()
+	team := Team{LeadID: &lead, Members: []uuid.UUID{lead, member}}
+
+	tests := []struct {
+		name   string
+		user   uuid.UUID
+		lead   bool
+		member bool
+	}{
+		{"Lead", lead, true, true},
+		{"Member", member, false, true},
+		{"Outsider", outsider, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := team.IsLead(tt.user); got != tt.lead {
+				t.Errorf("IsLead() = %v; want %v", got, tt.lead)
+			}
+			if got := team.IsMember(tt.user); got != tt.member {
+				t.Errorf("IsMember() = %v; want %v", got, tt.member)
+			}
+		})
+	}
+
+	if (Team{}).IsLead(uuid.Nil) {
+		t.Error("IsLead() = true for a team without a lead")
+	}
+}
@@ -3,8 +3,10 @@ package domain
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 )
@@ -77,15 +79,90 @@ func (p TicketPriority) String() string {
 }
 
 type Ticket struct {
-	ID          uuid.UUID      `json:"id" db:"id"`
-	CreatedBy   uuid.UUID      `json:"created_by" db:"created_by"`
-	AssignedTo  []uuid.UUID    `json:"assigned_to" db:"assigned_to"`
-	Title       string         `json:"title" db:"title"`
-	Description string         `json:"description" db:"description"`
-	State       TicketState    `json:"state" db:"state"`
-	Priority    TicketPriority `json:"priority" db:"priority"`
-	CreatedAt   time.Time      `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at" db:"updated_at"`
+	ID             uuid.UUID      `json:"id" db:"id"`
+	OrgID          uuid.UUID      `json:"org_id" db:"org_id"`
+	CreatedBy      uuid.UUID      `json:"created_by" db:"created_by"`
+	AssignedTo     []uuid.UUID    `json:"assigned_to" db:"assigned_to"`
+	TeamID         *uuid.UUID     `json:"team_id" db:"team_id"`
+	Title          string         `json:"title" db:"title"`
+	Description    string         `json:"description" db:"description"`
+	Type           string         `json:"type" db:"type"`
+	State          TicketState    `json:"state" db:"state"`
+	Priority       TicketPriority `json:"priority" db:"priority"`
+	ResolutionCode ResolutionCode `json:"resolution_code" db:"resolution_code"`
+	StateReason    string         `json:"state_reason" db:"state_reason"`
+	Impact         TicketImpact   `json:"impact" db:"impact"`
+	Urgency        TicketUrgency  `json:"urgency" db:"urgency"`
+	// PriorityOverridden is set when an admin picked the priority by hand
+	// instead of deriving it from the priority matrix
+	PriorityOverridden bool `json:"priority_overridden" db:"priority_overridden"`
+	// Rank orders the ticket within its state column on the board
+	Rank string `json:"rank" db:"rank"`
+	// Labels are free-form tags, stored lowercased
+	Labels []string `json:"labels" db:"labels"`
+	// Readers may see the ticket without having created it or being
+	// assigned to it, e.g. because they were mentioned on it
+	Readers   []uuid.UUID `json:"readers" db:"readers"`
+	CreatedAt time.Time   `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at" db:"updated_at"`
+}
+
+// MaxDescriptionLength caps a ticket's description, which is rendered as
+// Markdown every time the ticket is viewed
+const MaxDescriptionLength = 20000
+
+var ErrDescriptionTooLong = fmt.Errorf("description must be at most %d characters", MaxDescriptionLength)
+
+// CheckDescriptionLength rejects a description longer than
+// MaxDescriptionLength
+func CheckDescriptionLength(description string) error {
+	if utf8.RuneCountInString(description) > MaxDescriptionLength {
+		return ErrDescriptionTooLong
+	}
+	return nil
+}
+
+// Key is the short reference people quote for a ticket, e.g. in replies
+func (t Ticket) Key() string {
+	return strings.ToUpper(t.ID.String()[:8])
+}
+
+// IsClaim reports whether changing a ticket's assignees from before to after
+// does nothing but add userID
+func IsClaim(before, after []uuid.UUID, userID uuid.UUID) bool {
+	if slices.Contains(before, userID) || !slices.Contains(after, userID) {
+		return false
+	}
+	others := slices.DeleteFunc(slices.Clone(after), func(id uuid.UUID) bool { return id == userID })
+	if len(others) != len(before) {
+		return false
+	}
+	for _, id := range before {
+		if !slices.Contains(others, id) {
+			return false
+		}
+	}
+	return true
+}
+
+// NormalizeLabels trims and lowercases labels, dropping blanks and
+// duplicates. The result is never nil.
+func NormalizeLabels(labels []string) []string {
+	out := make([]string, 0, len(labels))
+	for _, l := range labels {
+		l = strings.ToLower(strings.TrimSpace(l))
+		if l != "" && !slices.Contains(out, l) {
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+// TicketMatch is a ticket that looks like a possible duplicate, scored from
+// 0 to 1 by text similarity
+type TicketMatch struct {
+	Ticket Ticket  `json:"ticket"`
+	Score  float64 `json:"score"`
 }
 
 var allowedTransitions = map[TicketState]map[TicketState]struct{}{
@@ -161,9 +238,56 @@ func GetValidTransitions(from TicketState) []TicketState {
 
 var (
 	ErrInvalidStatusTransition = errors.New("invalid status transition")
+	ErrInvalidResolutionCode   = errors.New("invalid resolution code")
+	ErrResolutionCodeRequired  = errors.New("a resolution code is required to resolve or cancel a ticket")
+	ErrReopenReasonRequired    = errors.New("a reason is required to move a ticket back to open")
+	ErrTicketNotFound          = errors.New("ticket not found")
 )
 
 // GetTransitionError returns a more descriptive error for invalid transitions
 func GetTransitionError(from TicketState, to TicketState) error {
 	return fmt.Errorf("cannot transition ticket from %s to %s: %w", from.String(), to.String(), ErrInvalidStatusTransition)
 }
+
+type ResolutionCode string
+
+const (
+	ResolutionFixed           ResolutionCode = "fixed"
+	ResolutionWontFix         ResolutionCode = "wont_fix"
+	ResolutionDuplicate       ResolutionCode = "duplicate"
+	ResolutionUserError       ResolutionCode = "user_error"
+	ResolutionCannotReproduce ResolutionCode = "cannot_reproduce"
+)
+
+// GetResolutionCode accepts codes in either their stored form or as written
+// by people, e.g. "won't fix" or "User Error"
+func GetResolutionCode(s string) (ResolutionCode, error) {
+	normalized := strings.NewReplacer("'", "", " ", "_", "-", "_").Replace(strings.ToLower(strings.TrimSpace(s)))
+	switch ResolutionCode(normalized) {
+	case ResolutionFixed, ResolutionWontFix, ResolutionDuplicate, ResolutionUserError, ResolutionCannotReproduce:
+		return ResolutionCode(normalized), nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrInvalidResolutionCode, s)
+	}
+}
+
+// RequiresResolutionCode reports whether moving into this state must say why
+func (s TicketState) RequiresResolutionCode() bool {
+	return s == TicketStateResolved || s == TicketStateCancelled
+}
+
+// ValidateTransitionReason checks that a state change carries the reason
+// reporting depends on: a resolution code when resolving or cancelling, and
+// a free-text reason when a ticket is moved back to open
+func ValidateTransitionReason(from TicketState, to TicketState, code ResolutionCode, reason string) error {
+	if from == to {
+		return nil
+	}
+	if to.RequiresResolutionCode() && code == "" {
+		return ErrResolutionCodeRequired
+	}
+	if to == TicketStateOpen && strings.TrimSpace(reason) == "" {
+		return ErrReopenReasonRequired
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TicketEventKind string
+
+const (
+	TicketEventStateChanged       TicketEventKind = "state_changed"
+	TicketEventPriorityOverridden TicketEventKind = "priority_overridden"
+	TicketEventApprovalDecided    TicketEventKind = "approval_decided"
+	TicketEventAssigneeForwarded  TicketEventKind = "assignee_forwarded"
+	TicketEventTransferRequested  TicketEventKind = "transfer_requested"
+	TicketEventTransferDecided    TicketEventKind = "transfer_decided"
+	TicketEventCommentDeleted     TicketEventKind = "comment_deleted"
+	TicketEventCommandApplied     TicketEventKind = "command_applied"
+)
+
+// TicketEvent is an append-only record of a change made to a ticket
+type TicketEvent struct {
+	ID        uuid.UUID       `json:"id"`
+	TicketID  uuid.UUID       `json:"ticket_id"`
+	ActorID   uuid.UUID       `json:"actor_id"`
+	Kind      TicketEventKind `json:"kind"`
+	OldValue  string          `json:"old_value"`
+	NewValue  string          `json:"new_value"`
+	Reason    string          `json:"reason"`
+	Note      string          `json:"note"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type ResolutionCount struct {
+	ResolutionCode ResolutionCode `json:"resolution_code"`
+	Tickets        int64          `json:"tickets"`
+}
@@ -1,7 +1,10 @@
 package domain
 
 import (
+	"strings"
 	"testing"
+
+	"github.com/google/uuid"
 )
 
 func TestCanTransition(t *testing.T) {
@@ -127,3 +130,96 @@ func TestTicketStateString(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateTransitionReason(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     TicketState
+		to       TicketState
+		code     ResolutionCode
+		reason   string
+		expected error
+	}{
+		{"Resolve with code", TicketStatePending, TicketStateResolved, ResolutionFixed, "", nil},
+		{"Resolve without code", TicketStatePending, TicketStateResolved, "", "done", ErrResolutionCodeRequired},
+		{"Cancel with code", TicketStateOpen, TicketStateCancelled, ResolutionDuplicate, "", nil},
+		{"Cancel without code", TicketStateOpen, TicketStateCancelled, "", "", ErrResolutionCodeRequired},
+		{"Reopen with reason", TicketStateResolved, TicketStateOpen, "", "still broken", nil},
+		{"Reopen without reason", TicketStateResolved, TicketStateOpen, "", "  ", ErrReopenReasonRequired},
+		{"Pending back to open without reason", TicketStatePending, TicketStateOpen, "", "", ErrReopenReasonRequired},
+		{"Open to Pending needs nothing", TicketStateOpen, TicketStatePending, "", "", nil},
+		{"Resolved to Closed needs nothing", TicketStateResolved, TicketStateClosed, "", "", nil},
+		{"No change", TicketStateResolved, TicketStateResolved, "", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTransitionReason(tt.from, tt.to, tt.code, tt.reason)
+			if err != tt.expected {
+				t.Errorf("ValidateTransitionReason(%v, %v, %q, %q) = %v; want %v", tt.from, tt.to, tt.code, tt.reason, err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetResolutionCode(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected ResolutionCode
+		wantErr  bool
+	}{
+		{"fixed", ResolutionFixed, false},
+		{"won't fix", ResolutionWontFix, false},
+		{"wont_fix", ResolutionWontFix, false},
+		{"User Error", ResolutionUserError, false},
+		{"cannot-reproduce", ResolutionCannotReproduce, false},
+		{"duplicate", ResolutionDuplicate, false},
+		{"whatever", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			code, err := GetResolutionCode(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetResolutionCode(%q) error = %v; wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if code != tt.expected {
+				t.Errorf("GetResolutionCode(%q) = %q; want %q", tt.input, code, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCheckDescriptionLength(t *testing.T) {
+	if err := CheckDescriptionLength(strings.Repeat("é", MaxDescriptionLength)); err != nil {
+		t.Errorf("CheckDescriptionLength() = %v; want nil", err)
+	}
+	if err := CheckDescriptionLength(strings.Repeat("a", MaxDescriptionLength+1)); err != ErrDescriptionTooLong {
+		t.Errorf("CheckDescriptionLength() = %v; want %v", err, ErrDescriptionTooLong)
+	}
+}
+
+func TestIsClaim(t *testing.T) {
+	me, other, third := uuid.New(), uuid.New(), uuid.New()
+	tests := []struct {
+		name   string
+		before []uuid.UUID
+		after  []uuid.UUID
+		want   bool
+	}{
+		{"unassigned", nil, []uuid.UUID{me}, true},
+		{"joins others", []uuid.UUID{other}, []uuid.UUID{other, me}, true},
+		{"already assigned", []uuid.UUID{me}, []uuid.UUID{me}, false},
+		{"adds someone else", nil, []uuid.UUID{other}, false},
+		{"adds self and another", nil, []uuid.UUID{me, other}, false},
+		{"replaces another", []uuid.UUID{other}, []uuid.UUID{me}, false},
+		{"swaps another", []uuid.UUID{other}, []uuid.UUID{me, third}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsClaim(tt.before, tt.after, me); got != tt.want {
+				t.Errorf("IsClaim() = %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
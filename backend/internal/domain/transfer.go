@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidTransferTarget = errors.New("transfer needs exactly one of to_user_id or to_team_id")
+	ErrTransferToSelf        = errors.New("cannot transfer a ticket to yourself")
+	ErrTransferNotStaff      = errors.New("tickets can only be transferred to agents and admins")
+	ErrTransferOrg           = errors.New("receiver cannot work in the ticket's organization")
+	ErrTransferPending       = errors.New("ticket already has a transfer waiting to be accepted")
+	ErrNoPendingTransfer     = errors.New("transfer has already been decided")
+	ErrTransferTicketClosed  = errors.New("only open and pending tickets can be transferred")
+)
+
+type TransferStatus string
+
+const (
+	TransferPending   TransferStatus = "pending"
+	TransferAccepted  TransferStatus = "accepted"
+	TransferDeclined  TransferStatus = "declined"
+	TransferCancelled TransferStatus = "cancelled"
+)
+
+// Transfer hands a ticket from one of its assignees to another agent or to
+// a team. Nothing changes on the ticket until the receiver accepts; when a
+// team is the receiver, the member who accepts takes the sender's place.
+type Transfer struct {
+	ID         uuid.UUID      `json:"id"`
+	TicketID   uuid.UUID      `json:"ticket_id"`
+	FromUserID uuid.UUID      `json:"from_user_id"`
+	ToUserID   *uuid.UUID     `json:"to_user_id"`
+	ToTeamID   *uuid.UUID     `json:"to_team_id"`
+	Note       string         `json:"note"`
+	Status     TransferStatus `json:"status"`
+	DecidedBy  *uuid.UUID     `json:"decided_by"`
+	Reason     string         `json:"reason"`
+	DecidedAt  *time.Time     `json:"decided_at"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
+
+// Validate trims the note and checks the transfer has a single receiver
+// other than the sender
+func (t *Transfer) Validate() error {
+	t.Note = strings.TrimSpace(t.Note)
+	if (t.ToUserID == nil) == (t.ToTeamID == nil) {
+		return ErrInvalidTransferTarget
+	}
+	if t.ToUserID != nil && *t.ToUserID == t.FromUserID {
+		return ErrTransferToSelf
+	}
+	return nil
+}
+
+// IsReceiver reports whether the user may accept or decline the transfer,
+// given the teams they are on
+func (t Transfer) IsReceiver(userID uuid.UUID, teamIDs []uuid.UUID) bool {
+	if t.ToUserID != nil {
+		return *t.ToUserID == userID
+	}
+	for _, id := range teamIDs {
+		if id == *t.ToTeamID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestTransferValidate(t *testing.T) {
+	sender := uuid.New()
+	receiver := uuid.New()
+	team := uuid.New()
+
+	tests := []struct {
+		name     string
+		transfer Transfer
+		expected error
+	}{
+		{"To an agent", Transfer{FromUserID: sender, ToUserID: &receiver}, nil},
+		{"To a team", Transfer{FromUserID: sender, ToTeamID: &team}, nil},
+		{"No receiver", Transfer{FromUserID: sender}, ErrInvalidTransferTarget},
+		{"Both receivers", Transfer{FromUserID: sender, ToUserID: &receiver, ToTeamID: &team}, ErrInvalidTransferTarget},
+		{"To self", Transfer{FromUserID: sender, ToUserID: &sender}, ErrTransferToSelf},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transfer := tt.transfer
+			if err := transfer.Validate(); err != tt.expected {
+				t.Errorf("Validate() = %v; want %v", err, tt.expected)
+			}
+		})
+	}
+
+	transfer := Transfer{FromUserID: sender, ToUserID: &receiver, Note: "  over to you  "}
+	if err := transfer.Validate(); err != nil || transfer.Note != "over to you" {
+		t.Errorf("Validate() = %v with note %q; want nil with %q", err, transfer.Note, "over to you")
+	}
+}
+
+func TestTransferIsReceiver(t *testing.T) {
+	agent := uuid.New()
+	other := uuid.New()
+	team := uuid.New()
+
+	toAgent := Transfer{ToUserID: &agent}
+	toTeam := Transfer{ToTeamID: &team}
+
+	tests := []struct {
+		name     string
+		transfer Transfer
+		userID   uuid.UUID
+		teamIDs  []uuid.UUID
+		expected bool
+	}{
+		{"Named agent", toAgent, agent, nil, true},
+		{"Other agent", toAgent, other, []uuid.UUID{team}, false},
+		{"Team member", toTeam, other, []uuid.UUID{uuid.New(), team}, true},
+		{"Not on team", toTeam, agent, []uuid.UUID{uuid.New()}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.transfer.IsReceiver(tt.userID, tt.teamIDs); got != tt.expected {
+				t.Errorf("IsReceiver() = %v; want %v", got, tt.expected)
+			}
+		})
+	}
+}
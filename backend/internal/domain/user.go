@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -8,6 +9,13 @@ import (
 	"github.com/google/uuid"
 )
 
+var (
+	ErrUserNotFound           = errors.New("user not found")
+	ErrEmailNotVerified       = errors.New("email address is not verified")
+	ErrEmailAlreadyVerified   = errors.New("email address is already verified")
+	ErrSignupDomainNotAllowed = errors.New("signing up with this email domain is not allowed")
+)
+
 type UserRole string
 
 const (
@@ -23,8 +31,48 @@ type User struct {
 	LastName       string    `json:"last_name"`
 	Email          string    `json:"email"`
 	Role           UserRole  `json:"role"`
-	UpdatedAt      time.Time `json:"updated_at"`
-	CreatedAt      time.Time `json:"created_at"`
+	OrgID          uuid.UUID `json:"org_id"`
+	// SessionVersion is carried by refresh tokens; bumping it invalidates
+	// every token issued before
+	SessionVersion int32 `json:"-"`
+	// EmailVerifiedAt is nil until the user follows the link mailed when
+	// they signed up
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// Verified reports whether the user has shown they receive mail at their
+// address
+func (u User) Verified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// VerificationPolicy decides what users may do before verifying their
+// email address: anything, nothing (they can't log in) or anything but
+// opening tickets
+type VerificationPolicy string
+
+const (
+	VerificationPolicyNone    VerificationPolicy = "none"
+	VerificationPolicyLogin   VerificationPolicy = "login"
+	VerificationPolicyTickets VerificationPolicy = "tickets"
+)
+
+// SignupAllowed reports whether an address may sign up given the allowed
+// email domains. Domains must match exactly, so subdomains are listed
+// separately; an empty list allows every domain.
+func SignupAllowed(email string, domains []string) bool {
+	if len(domains) == 0 {
+		return true
+	}
+	emailDomain := EmailDomain(email)
+	for _, d := range domains {
+		if emailDomain != "" && strings.EqualFold(strings.TrimSpace(d), emailDomain) {
+			return true
+		}
+	}
+	return false
 }
 
 func GetRole(s string) (UserRole, error) {
@@ -0,0 +1,28 @@
+package domain
+
+import "testing"
+
+func TestSignupAllowed(t *testing.T) {
+	tests := []struct {
+		name     string
+		email    string
+		domains  []string
+		expected bool
+	}{
+		{"No allow list", "jane@anywhere.io", nil, true},
+		{"Listed", "jane@acme.com", []string{"example.org", "acme.com"}, true},
+		{"Case insensitive", "Jane@ACME.com", []string{" Acme.com "}, true},
+		{"Not listed", "jane@acme.co", []string{"acme.com"}, false},
+		{"Subdomain not listed", "jane@mail.acme.com", []string{"acme.com"}, false},
+		{"Suffix is not a domain", "jane@notacme.com", []string{"acme.com"}, false},
+		{"No domain", "jane", []string{"acme.com"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SignupAllowed(tt.email, tt.domains); got != tt.expected {
+				t.Errorf("SignupAllowed(%q, %v) = %v; want %v", tt.email, tt.domains, got, tt.expected)
+			}
+		})
+	}
+}
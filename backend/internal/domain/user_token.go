@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"errors"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidUserToken = errors.New("invalid or expired token")
+	ErrPasswordTooShort = errors.New("password must be at least 8 characters")
+	ErrPasswordTooLong  = errors.New("password must be at most 72 bytes")
+)
+
+const (
+	MinPasswordLength = 8
+	// MaxPasswordBytes is as much as bcrypt hashes
+	MaxPasswordBytes = 72
+)
+
+// TokenPurpose says what a user token may be used for
+type TokenPurpose string
+
+const (
+	TokenPasswordReset     TokenPurpose = "password_reset"
+	TokenEmailVerification TokenPurpose = "email_verification"
+)
+
+// UserToken is a single-use secret mailed to a user. Only a hash of the
+// secret is kept, so a leaked table can't be used to take over accounts.
+type UserToken struct {
+	ID        uuid.UUID    `json:"id"`
+	UserID    uuid.UUID    `json:"user_id"`
+	Purpose   TokenPurpose `json:"purpose"`
+	TokenHash string       `json:"-"`
+	ExpiresAt time.Time    `json:"expires_at"`
+	UsedAt    *time.Time   `json:"used_at"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+// ValidatePassword checks a new password's length
+func ValidatePassword(password string) error {
+	if utf8.RuneCountInString(password) < MinPasswordLength {
+		return ErrPasswordTooShort
+	}
+	if len(password) > MaxPasswordBytes {
+		return ErrPasswordTooLong
+	}
+	return nil
+}
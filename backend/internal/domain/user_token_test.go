@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidatePassword(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		err      error
+	}{
+		{"Empty", "", ErrPasswordTooShort},
+		{"Too short", "abc1234", ErrPasswordTooShort},
+		{"Minimum", "abcd1234", nil},
+		{"Multibyte counts runes", "pässwörd", nil},
+		{"Maximum", strings.Repeat("a", MaxPasswordBytes), nil},
+		{"Too long", strings.Repeat("a", MaxPasswordBytes+1), ErrPasswordTooLong},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidatePassword(tt.password); err != tt.err {
+				t.Errorf("ValidatePassword(%q) = %v; want %v", tt.password, err, tt.err)
+			}
+		})
+	}
+}
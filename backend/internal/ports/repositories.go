@@ -13,21 +13,250 @@ type UserRepository interface {
 	CreateUser(ctx context.Context, user domain.User) (*domain.User, error)
 	GetAllUsers(ctx context.Context) ([]domain.User, error)
 	UpdateUser(ctx context.Context, user *domain.User) (*domain.User, error)
+	// UpdatePassword also bumps the user's session version, signing them
+	// out everywhere
+	UpdatePassword(ctx context.Context, id uuid.UUID, hashedPassword string) (*domain.User, error)
+	VerifyEmail(ctx context.Context, id uuid.UUID) (*domain.User, error)
 	DeleteUser(ctx context.Context, id uuid.UUID) error
+	// ListOrgAccess returns the organizations the user was granted besides
+	// their own
+	ListOrgAccess(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error)
+	// ListTeamIDs returns the teams the user is a member of
+	ListTeamIDs(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error)
+	// ListMentionable returns the users who work in the organization and
+	// whose email address, or the part of it before the "@", is one of
+	// those given
+	ListMentionable(ctx context.Context, orgID uuid.UUID, emails, names []string) ([]domain.User, error)
+	// Search returns users whose name or email address starts with query
+	Search(ctx context.Context, query string, limit int32) ([]domain.User, error)
+}
+
+// UserTokenRepository stores the hashes of single-use tokens mailed to users
+type UserTokenRepository interface {
+	Create(ctx context.Context, token domain.UserToken) (*domain.UserToken, error)
+	// Consume marks an unused, unexpired token as used and returns it, or
+	// domain.ErrInvalidUserToken. Of concurrent callers only one succeeds.
+	Consume(ctx context.Context, tokenHash string, purpose domain.TokenPurpose) (*domain.UserToken, error)
+	// Revoke marks the user's unused tokens for the purpose as used
+	Revoke(ctx context.Context, userID uuid.UUID, purpose domain.TokenPurpose) error
+}
+
+// RefreshTokenRepository stores the hashes of issued refresh tokens
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token domain.RefreshToken) (*domain.RefreshToken, error)
+	// GetByHash returns domain.ErrInvalidRefreshToken for unknown tokens
+	GetByHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error)
+	// Use marks the token used, or returns domain.ErrRefreshTokenReused if
+	// it already was or has been revoked. Of concurrent callers only one
+	// succeeds.
+	Use(ctx context.Context, id uuid.UUID) (*domain.RefreshToken, error)
+	RevokeFamily(ctx context.Context, familyID uuid.UUID) error
+	RevokeUser(ctx context.Context, userID uuid.UUID) error
+	DeleteExpired(ctx context.Context) error
+}
+
+// OrganizationRepository manages the tenant registry itself, so unlike every
+// other repository it is not scoped to the caller's organizations
+type OrganizationRepository interface {
+	List(ctx context.Context) ([]domain.Organization, error)
+	Get(ctx context.Context, id uuid.UUID) (*domain.Organization, error)
+	// GetByDomain returns nil if no organization claims the email domain
+	GetByDomain(ctx context.Context, domainName string) (*domain.Organization, error)
+	Create(ctx context.Context, org domain.Organization) (*domain.Organization, error)
+	MoveUser(ctx context.Context, userID, orgID uuid.UUID) (*domain.User, error)
+	GrantAccess(ctx context.Context, userID, orgID uuid.UUID) error
+	RevokeAccess(ctx context.Context, userID, orgID uuid.UUID) error
+	// SetCalendar changes the organization's business calendar; nil clears it
+	SetCalendar(ctx context.Context, orgID uuid.UUID, calendarID *uuid.UUID) (*domain.Organization, error)
 }
 
 type TicketRepository interface {
 	ListAll(ctx context.Context, limit, offset int32) ([]domain.Ticket, error)
 	ListByCreator(ctx context.Context, id uuid.UUID, limit, offset int32) ([]domain.Ticket, error)
 	ListByAssignee(ctx context.Context, id uuid.UUID, limit, offset int32) ([]domain.Ticket, error)
+	// ListByTeams returns the tickets queued to any of the teams, most
+	// urgent first
+	ListByTeams(ctx context.Context, teamIDs []uuid.UUID, limit, offset int32) ([]domain.Ticket, error)
+	List(ctx context.Context, scope domain.TicketScope, filter domain.TicketFilter, limit, offset int32) ([]domain.Ticket, error)
 	Get(ctx context.Context, id uuid.UUID) (*domain.Ticket, error)
 	Create(ctx context.Context, ticket domain.Ticket) (*domain.Ticket, error)
 	Update(ctx context.Context, ticket domain.Ticket) (*domain.Ticket, error)
+	// HandOff puts toUserID in fromUserID's place among the assignees and,
+	// if teamID is set, queues the ticket to that team
+	HandOff(ctx context.Context, id, fromUserID, toUserID uuid.UUID, teamID *uuid.UUID) (*domain.Ticket, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	// AddReaders lets the users read the ticket
+	AddReaders(ctx context.Context, id uuid.UUID, userIDs []uuid.UUID) error
+	// LastRank returns the highest rank in a state column, or "" if it is empty
+	LastRank(ctx context.Context, state domain.TicketState) (string, error)
+	CountByResolution(ctx context.Context) ([]domain.ResolutionCount, error)
+	// FindSimilar returns open and pending tickets whose title or description
+	// resemble the given text, best match first
+	FindSimilar(ctx context.Context, title, description string, limit int32) ([]domain.TicketMatch, error)
+}
+
+type AvailabilityRepository interface {
+	// Get returns the agent's availability, open ticket count and current
+	// absence. The count covers every organization the agent works in.
+	Get(ctx context.Context, userID uuid.UUID) (*domain.Availability, error)
+	Update(ctx context.Context, a domain.Availability) (*domain.Availability, error)
+	// ListOutOfOffice returns the agent's current and upcoming absences
+	ListOutOfOffice(ctx context.Context, userID uuid.UUID) ([]domain.OutOfOffice, error)
+	// ListActiveOutOfOffice returns absences in effect now that have a delegate
+	ListActiveOutOfOffice(ctx context.Context) ([]domain.OutOfOffice, error)
+	CreateOutOfOffice(ctx context.Context, o domain.OutOfOffice) (*domain.OutOfOffice, error)
+	DeleteOutOfOffice(ctx context.Context, userID, id uuid.UUID) error
+	// ForwardTickets reassigns the agent's open and pending tickets to the
+	// delegate and returns their ids
+	ForwardTickets(ctx context.Context, userID, delegateID uuid.UUID) ([]uuid.UUID, error)
+}
+
+type TransferRepository interface {
+	Create(ctx context.Context, t domain.Transfer) (*domain.Transfer, error)
+	Get(ctx context.Context, id uuid.UUID) (*domain.Transfer, error)
+	// GetPending returns nil if the ticket has no transfer waiting
+	GetPending(ctx context.Context, ticketID uuid.UUID) (*domain.Transfer, error)
+	ListByTicket(ctx context.Context, ticketID uuid.UUID) ([]domain.Transfer, error)
+	// ListIncoming returns the pending transfers to the user or their teams
+	ListIncoming(ctx context.Context, userID uuid.UUID, teamIDs []uuid.UUID) ([]domain.Transfer, error)
+	Decide(ctx context.Context, t domain.Transfer) (*domain.Transfer, error)
+}
+
+type TeamRepository interface {
+	List(ctx context.Context) ([]domain.Team, error)
+	Get(ctx context.Context, id uuid.UUID) (*domain.Team, error)
+	Create(ctx context.Context, team domain.Team) (*domain.Team, error)
+	Update(ctx context.Context, team domain.Team) (*domain.Team, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	AddMember(ctx context.Context, teamID, userID uuid.UUID) error
+	RemoveMember(ctx context.Context, teamID, userID uuid.UUID) error
+	// SetCalendar changes the team's business calendar; nil clears it
+	SetCalendar(ctx context.Context, teamID uuid.UUID, calendarID *uuid.UUID) (*domain.Team, error)
+}
+
+// CalendarRepository stores business calendars. Update replaces the working
+// hours and holidays wholesale.
+type CalendarRepository interface {
+	List(ctx context.Context) ([]domain.BusinessCalendar, error)
+	Get(ctx context.Context, id uuid.UUID) (*domain.BusinessCalendar, error)
+	Create(ctx context.Context, calendar domain.BusinessCalendar) (*domain.BusinessCalendar, error)
+	Update(ctx context.Context, calendar domain.BusinessCalendar) (*domain.BusinessCalendar, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 }
 
+type TicketEventRepository interface {
+	Create(ctx context.Context, event domain.TicketEvent) (*domain.TicketEvent, error)
+	ListByTicket(ctx context.Context, ticketID uuid.UUID, limit, offset int32) ([]domain.TicketEvent, error)
+}
+
+// PriorityMatrixRepository persists the matrix. Save also re-derives the
+// priority of open tickets that have not been overridden.
+type PriorityMatrixRepository interface {
+	Get(ctx context.Context) (domain.PriorityMatrix, error)
+	Save(ctx context.Context, matrix domain.PriorityMatrix) error
+}
+
+type ChecklistRepository interface {
+	ListByTicket(ctx context.Context, ticketID uuid.UUID) ([]domain.ChecklistItem, error)
+	Get(ctx context.Context, id uuid.UUID) (*domain.ChecklistItem, error)
+	Create(ctx context.Context, item domain.ChecklistItem) (*domain.ChecklistItem, error)
+	Update(ctx context.Context, item domain.ChecklistItem) (*domain.ChecklistItem, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	Reorder(ctx context.Context, ticketID uuid.UUID, ids []uuid.UUID) error
+	Progress(ctx context.Context, ticketID uuid.UUID) (*domain.ChecklistProgress, error)
+}
+
+// ApprovalRepository stores the approval steps configured per ticket type
+// and the copies made on each ticket
+type ApprovalRepository interface {
+	ListSteps(ctx context.Context, ticketType string) ([]domain.ApprovalStep, error)
+	ListStepTypes(ctx context.Context) ([]string, error)
+	ReplaceSteps(ctx context.Context, ticketType string, steps []domain.ApprovalStep) error
+	CreateForTicket(ctx context.Context, ticketID uuid.UUID, ticketType string) error
+	ListByTicket(ctx context.Context, ticketID uuid.UUID) ([]domain.TicketApproval, error)
+	// Decide records a decision on a pending step; it fails with
+	// domain.ErrNoPendingApproval if the step was already decided
+	Decide(ctx context.Context, approval domain.TicketApproval) (*domain.TicketApproval, error)
+	// ListPending returns the steps currently waiting on the user or their role
+	ListPending(ctx context.Context, userID uuid.UUID, role domain.UserRole) ([]domain.TicketApproval, error)
+}
+
+// SavedViewRepository stores views. ListVisible returns the user's own views,
+// those shared with their role in their organization and those shared with
+// one of their teams.
+type SavedViewRepository interface {
+	ListVisible(ctx context.Context, userID, orgID uuid.UUID, role domain.UserRole, teamIDs []uuid.UUID) ([]domain.SavedView, error)
+	Get(ctx context.Context, id uuid.UUID) (*domain.SavedView, error)
+	Create(ctx context.Context, view domain.SavedView) (*domain.SavedView, error)
+	Update(ctx context.Context, view domain.SavedView) (*domain.SavedView, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// MacroRepository stores macros. ListVisible returns the user's own macros
+// and those shared with their organization.
+type MacroRepository interface {
+	ListVisible(ctx context.Context, userID, orgID uuid.UUID) ([]domain.Macro, error)
+	Get(ctx context.Context, id uuid.UUID) (*domain.Macro, error)
+	Create(ctx context.Context, macro domain.Macro) (*domain.Macro, error)
+	Update(ctx context.Context, macro domain.Macro) (*domain.Macro, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// Transactor runs fn atomically: repository calls made with the context fn
+// is given are committed together or not at all
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+	// AfterCommit runs fn once the transaction the context is in commits,
+	// and not at all if it rolls back. Outside a transaction fn runs right
+	// away. It is for best-effort work, such as sending mail, that must
+	// neither fail the transaction nor happen for changes that are undone.
+	AfterCommit(ctx context.Context, fn func(ctx context.Context))
+}
+
 type CommentRepository interface {
-	ListByTicket(ctx context.Context, ticketID uuid.UUID, limit, offset int32) ([]domain.Comment, error)
+	// ListByTicket returns a page of the comments that start the ticket's
+	// threads, only those with the given visibility if one is set. A
+	// deleted comment is included if its thread has replies that aren't.
+	ListByTicket(ctx context.Context, ticketID uuid.UUID, visibility *domain.CommentVisibility, limit, offset int32) ([]domain.Comment, error)
+	// ListThreadReplies returns every reply in the given threads, oldest
+	// first, including deleted ones so the tree can be pruned
+	ListThreadReplies(ctx context.Context, threadIDs []uuid.UUID, visibility *domain.CommentVisibility) ([]domain.Comment, error)
+	// ListReplies returns a page of the replies in one thread, oldest first
+	ListReplies(ctx context.Context, threadID uuid.UUID, visibility *domain.CommentVisibility, limit, offset int32) ([]domain.Comment, error)
 	Get(ctx context.Context, id uuid.UUID) (*domain.Comment, error)
+	// GetIncludingDeleted is Get for a comment that may have been deleted
+	GetIncludingDeleted(ctx context.Context, id uuid.UUID) (*domain.Comment, error)
 	Create(ctx context.Context, comment domain.Comment) (*domain.Comment, error)
+	// Update changes the text and keeps the previous text as a revision
+	Update(ctx context.Context, comment domain.Comment, editedBy uuid.UUID) (*domain.Comment, error)
+	// Delete marks the comment deleted, after which Get and the lists leave
+	// it out. Its final text is kept as a revision by deletedBy.
+	Delete(ctx context.Context, id uuid.UUID, deletedBy uuid.UUID) error
+	// ListRevisions returns the earlier texts of a comment, oldest first
+	ListRevisions(ctx context.Context, commentID uuid.UUID) ([]domain.CommentRevision, error)
+}
+
+type ReactionRepository interface {
+	// Add records the user's reaction, doing nothing if it exists
+	Add(ctx context.Context, commentID, userID uuid.UUID, emoji string) error
+	Remove(ctx context.Context, commentID, userID uuid.UUID, emoji string) error
+	// Summarize counts the reactions to each comment, oldest emoji first,
+	// marking those the user made
+	Summarize(ctx context.Context, commentIDs []uuid.UUID, userID uuid.UUID) (map[uuid.UUID][]domain.Reaction, error)
+}
+
+type MentionRepository interface {
+	// Create records mentions of the users in the ticket's description, or
+	// in a comment if commentID is set. It returns only the mentions that
+	// weren't recorded before.
+	Create(ctx context.Context, ticketID uuid.UUID, commentID *uuid.UUID, userIDs []uuid.UUID, createdBy uuid.UUID) ([]domain.Mention, error)
+	// ListByUser returns the mentions of the user, newest first
+	ListByUser(ctx context.Context, userID uuid.UUID, limit, offset int32) ([]domain.Mention, error)
+}
+
+type CSATRepository interface {
+	Upsert(ctx context.Context, response domain.CSATResponse) (*domain.CSATResponse, error)
+	GetByTicket(ctx context.Context, ticketID uuid.UUID) (*domain.CSATResponse, error)
+	Summary(ctx context.Context) (*domain.CSATSummary, error)
+	SummaryByAssignee(ctx context.Context) ([]domain.CSATAssigneeSummary, error)
 }
@@ -15,20 +15,229 @@ type UserService interface {
 	GetAllUsersForAssignment(ctx context.Context) ([]domain.User, error)
 	UpdateUserRole(ctx context.Context, id uuid.UUID, role domain.UserRole) (*domain.User, error)
 	DeleteUser(ctx context.Context, id uuid.UUID) error
+	OrgIDs(ctx context.Context, user *domain.User) ([]uuid.UUID, error)
+	TeamIDs(ctx context.Context, user *domain.User) ([]uuid.UUID, error)
+	// SearchUsers returns users the caller can see whose name or email
+	// address starts with query, for mention autocomplete
+	SearchUsers(ctx context.Context, query string) ([]domain.User, error)
+}
+
+type OrganizationService interface {
+	ListOrganizations(ctx context.Context) ([]domain.Organization, error)
+	CreateOrganization(ctx context.Context, org domain.Organization) (*domain.Organization, error)
+	MoveUser(ctx context.Context, userID, orgID uuid.UUID) (*domain.User, error)
+	GrantAccess(ctx context.Context, userID, orgID uuid.UUID) error
+	RevokeAccess(ctx context.Context, userID, orgID uuid.UUID) error
+}
+
+type AvailabilityService interface {
+	GetAvailability(ctx context.Context, userID uuid.UUID) (*domain.Availability, error)
+	UpdateAvailability(ctx context.Context, a domain.Availability) (*domain.Availability, error)
+	ListOutOfOffice(ctx context.Context, userID uuid.UUID) ([]domain.OutOfOffice, error)
+	CreateOutOfOffice(ctx context.Context, o domain.OutOfOffice) (*domain.OutOfOffice, error)
+	DeleteOutOfOffice(ctx context.Context, userID, id uuid.UUID) error
+	// RouteAssignees returns next with agents newly added since prev who are
+	// out of office replaced by their delegates. Under the refuse policy it
+	// fails if a new assignee is unavailable.
+	RouteAssignees(ctx context.Context, prev, next []uuid.UUID) ([]uuid.UUID, error)
+	// Warnings flags the agents newly added since prev who are unavailable
+	Warnings(ctx context.Context, prev, next []uuid.UUID) ([]domain.AssignmentWarning, error)
+	// ForwardOutOfOffice hands the open tickets of every agent who is out of
+	// office to their delegate. It runs in the background without a caller.
+	ForwardOutOfOffice(ctx context.Context) error
+}
+
+type CalendarService interface {
+	ListCalendars(ctx context.Context) ([]domain.BusinessCalendar, error)
+	GetCalendar(ctx context.Context, id uuid.UUID) (*domain.BusinessCalendar, error)
+	CreateCalendar(ctx context.Context, calendar domain.BusinessCalendar) (*domain.BusinessCalendar, error)
+	UpdateCalendar(ctx context.Context, calendar domain.BusinessCalendar) (*domain.BusinessCalendar, error)
+	DeleteCalendar(ctx context.Context, id uuid.UUID) error
+	// SetOrganizationCalendar attaches a calendar to the organization it
+	// belongs to; nil detaches it
+	SetOrganizationCalendar(ctx context.Context, orgID uuid.UUID, calendarID *uuid.UUID) (*domain.Organization, error)
+	// SetTeamCalendar attaches a calendar from the team's organization to
+	// the team; nil detaches it
+	SetTeamCalendar(ctx context.Context, teamID uuid.UUID, calendarID *uuid.UUID) (*domain.Team, error)
+	// ForTicket returns the calendar that times the ticket: its team's if it
+	// has one, otherwise its organization's. Nil means round the clock. It
+	// does not check the caller, so background jobs can use it.
+	ForTicket(ctx context.Context, ticket *domain.Ticket) (*domain.BusinessCalendar, error)
+}
+
+type TransferService interface {
+	// RequestTransfer offers the ticket from the caller, one of its
+	// assignees, to another agent or a team
+	RequestTransfer(ctx context.Context, t domain.Transfer) (*domain.Transfer, error)
+	// Decide accepts or declines a transfer offered to the caller or one of
+	// their teams. Accepting makes the caller an assignee in the sender's place.
+	Decide(ctx context.Context, id uuid.UUID, accept bool, reason string) (*domain.Transfer, error)
+	CancelTransfer(ctx context.Context, id uuid.UUID) (*domain.Transfer, error)
+	ListForTicket(ctx context.Context, ticketID uuid.UUID) ([]domain.Transfer, error)
+	// Pending returns the transfer the ticket is waiting on, or nil
+	Pending(ctx context.Context, ticketID uuid.UUID) (*domain.Transfer, error)
+	// ListIncoming returns the transfers waiting on the caller
+	ListIncoming(ctx context.Context) ([]domain.Transfer, error)
+}
+
+type TeamService interface {
+	ListTeams(ctx context.Context) ([]domain.Team, error)
+	GetTeam(ctx context.Context, id uuid.UUID) (*domain.Team, error)
+	CreateTeam(ctx context.Context, team domain.Team) (*domain.Team, error)
+	UpdateTeam(ctx context.Context, team domain.Team) (*domain.Team, error)
+	DeleteTeam(ctx context.Context, id uuid.UUID) error
+	AddMember(ctx context.Context, teamID, userID uuid.UUID) (*domain.Team, error)
+	RemoveMember(ctx context.Context, teamID, userID uuid.UUID) (*domain.Team, error)
 }
 
 type TicketService interface {
 	ListAll(ctx context.Context, limit, offset int32) ([]domain.Ticket, error)
 	ListByCreator(ctx context.Context, id uuid.UUID, limit, offset int32) ([]domain.Ticket, error)
 	ListByAssignee(ctx context.Context, id uuid.UUID, limit, offset int32) ([]domain.Ticket, error)
+	ListByTeam(ctx context.Context, teamID *uuid.UUID, limit, offset int32) ([]domain.Ticket, error)
+	ListFiltered(ctx context.Context, filter domain.TicketFilter, limit, offset int32) ([]domain.Ticket, error)
 	GetTicket(ctx context.Context, id uuid.UUID) (*domain.Ticket, error)
 	CreateTicket(ctx context.Context, ticket domain.Ticket) (*domain.Ticket, error)
 	UpdateTicket(ctx context.Context, ticket domain.Ticket, updatedFields []string) (*domain.Ticket, error)
 	DeleteTicket(ctx context.Context, id uuid.UUID) error
+	ListEvents(ctx context.Context, ticketID uuid.UUID, limit, offset int32) ([]domain.TicketEvent, error)
+	ResolutionReport(ctx context.Context) ([]domain.ResolutionCount, error)
+	FindDuplicates(ctx context.Context, title, description string) ([]domain.TicketMatch, error)
+	Board(ctx context.Context, limit int32) ([]domain.BoardColumn, error)
+	MoveTicket(ctx context.Context, move domain.TicketMove) (*domain.Ticket, error)
+}
+
+type PriorityMatrixService interface {
+	GetMatrix(ctx context.Context) (domain.PriorityMatrix, error)
+	UpdateMatrix(ctx context.Context, entries []domain.PriorityMatrixEntry) (domain.PriorityMatrix, error)
+}
+
+type ChecklistService interface {
+	ListItems(ctx context.Context, ticketID uuid.UUID) ([]domain.ChecklistItem, error)
+	GetItem(ctx context.Context, ticketID, itemID uuid.UUID) (*domain.ChecklistItem, error)
+	CreateItem(ctx context.Context, item domain.ChecklistItem) (*domain.ChecklistItem, error)
+	UpdateItem(ctx context.Context, item domain.ChecklistItem) (*domain.ChecklistItem, error)
+	DeleteItem(ctx context.Context, ticketID, itemID uuid.UUID) error
+	Reorder(ctx context.Context, ticketID uuid.UUID, ids []uuid.UUID) ([]domain.ChecklistItem, error)
+	Progress(ctx context.Context, ticketID uuid.UUID) (*domain.ChecklistProgress, error)
+}
+
+type ApprovalService interface {
+	ListStepTypes(ctx context.Context) ([]string, error)
+	ListSteps(ctx context.Context, ticketType string) ([]domain.ApprovalStep, error)
+	UpdateSteps(ctx context.Context, ticketType string, steps []domain.ApprovalStep) ([]domain.ApprovalStep, error)
+	ListForTicket(ctx context.Context, ticketID uuid.UUID) ([]domain.TicketApproval, error)
+	Decide(ctx context.Context, ticketID uuid.UUID, approve bool, comment string) (*domain.TicketApproval, error)
+	ListPending(ctx context.Context) ([]domain.TicketApproval, error)
+}
+
+type SavedViewService interface {
+	ListViews(ctx context.Context) ([]domain.SavedView, error)
+	GetView(ctx context.Context, id uuid.UUID) (*domain.SavedView, error)
+	CreateView(ctx context.Context, view domain.SavedView) (*domain.SavedView, error)
+	UpdateView(ctx context.Context, view domain.SavedView) (*domain.SavedView, error)
+	DeleteView(ctx context.Context, id uuid.UUID) error
+	ListTickets(ctx context.Context, id uuid.UUID, limit, offset int32) ([]domain.Ticket, error)
+}
+
+type MacroService interface {
+	ListMacros(ctx context.Context) ([]domain.Macro, error)
+	GetMacro(ctx context.Context, id uuid.UUID) (*domain.Macro, error)
+	// CreateMacro stores a personal macro, or a shared one if shared is set
+	CreateMacro(ctx context.Context, macro domain.Macro, shared bool) (*domain.Macro, error)
+	UpdateMacro(ctx context.Context, macro domain.Macro, shared bool) (*domain.Macro, error)
+	DeleteMacro(ctx context.Context, id uuid.UUID) error
+	// Render fills in the macro's reply for a ticket without applying it
+	Render(ctx context.Context, id, ticketID uuid.UUID) (string, error)
+	// Apply posts the macro's reply to the ticket and makes its changes, all
+	// or nothing
+	Apply(ctx context.Context, id, ticketID uuid.UUID) (*domain.MacroApplication, error)
 }
 
 type CommentService interface {
+	// ListByTicket returns a page of the ticket's threads with all of their
+	// replies, each reply after its parent
 	ListByTicket(ctx context.Context, ticketID uuid.UUID, limit, offset int32) ([]domain.Comment, error)
+	// ListReplies returns a page of the replies in the comment's thread
+	ListReplies(ctx context.Context, id uuid.UUID, limit, offset int32) ([]domain.Comment, error)
 	GetComment(ctx context.Context, id uuid.UUID) (*domain.Comment, error)
+	// CreateComment adds a comment, or a reply when ParentID is set. Slash
+	// commands the comment starts with are applied to the ticket along with
+	// it.
 	CreateComment(ctx context.Context, comment domain.Comment) (*domain.Comment, error)
+	// UpdateComment lets the author change a comment's text within the
+	// configured edit window
+	UpdateComment(ctx context.Context, id uuid.UUID, description string) (*domain.Comment, error)
+	DeleteComment(ctx context.Context, id uuid.UUID) error
+	ListRevisions(ctx context.Context, id uuid.UUID) ([]domain.CommentRevision, error)
+}
+
+type ReactionService interface {
+	// React adds the caller's reaction to a comment and returns the comment's
+	// reactions
+	React(ctx context.Context, commentID uuid.UUID, emoji string) ([]domain.Reaction, error)
+	// Unreact removes the caller's reaction and returns the comment's
+	// reactions
+	Unreact(ctx context.Context, commentID uuid.UUID, emoji string) ([]domain.Reaction, error)
+	// Summarize returns the reactions to comments the caller has already
+	// been allowed to see
+	Summarize(ctx context.Context, commentIDs []uuid.UUID) (map[uuid.UUID][]domain.Reaction, error)
+}
+
+type MentionService interface {
+	// Record stores the mentions the caller made in the ticket's description,
+	// or in the comment if one is given, and notifies the users mentioned
+	// for the first time. It does not check the caller, who has already
+	// written the text.
+	Record(ctx context.Context, ticket *domain.Ticket, comment *domain.Comment) error
+	// ListMine returns the caller's mentions, newest first
+	ListMine(ctx context.Context, limit, offset int32) ([]domain.Mention, error)
+}
+
+// SessionService keeps track of refresh tokens, so they can be used only
+// once and revoked before they expire
+type SessionService interface {
+	// Record stores a refresh token issued to the user in the family
+	Record(ctx context.Context, userID, familyID uuid.UUID, token string) error
+	// Rotate uses up a refresh token so it can be exchanged for a new one
+	// in the same family, and returns its record. A token used before
+	// gives domain.ErrRefreshTokenReused and revokes its family.
+	Rotate(ctx context.Context, token string) (*domain.RefreshToken, error)
+	// Revoke ends the session the refresh token belongs to
+	Revoke(ctx context.Context, token string) error
+	// RevokeUser ends every session of the user
+	RevokeUser(ctx context.Context, userID uuid.UUID) error
+	// PurgeExpired deletes the records of expired tokens
+	PurgeExpired(ctx context.Context) error
+}
+
+// PasswordService lets users who forgot their password set a new one
+// through a link mailed to them
+type PasswordService interface {
+	// RequestReset mails a reset link if the address has an account. It
+	// succeeds either way, so callers can't tell which addresses do.
+	RequestReset(ctx context.Context, email string) error
+	// ResetPassword sets the password of the user the token was issued to
+	// and signs them out everywhere. Each token works once.
+	ResetPassword(ctx context.Context, token, password string) error
+}
+
+// VerificationService confirms that users receive mail at the address they
+// signed up with
+type VerificationService interface {
+	SendVerification(ctx context.Context, user *domain.User) error
+	// VerifyEmail marks the address of the user the token was issued to as
+	// verified. Each token works once.
+	VerifyEmail(ctx context.Context, token string) (*domain.User, error)
+	// ResendVerification mails a new link to a user who hasn't verified
+	// their address yet, on an admin's behalf
+	ResendVerification(ctx context.Context, userID uuid.UUID) error
+}
+
+type CSATService interface {
+	SendSurvey(ctx context.Context, ticket domain.Ticket) error
+	GetSurvey(ctx context.Context, token string) (*domain.Ticket, error)
+	SubmitRating(ctx context.Context, token string, rating int, comment string) (*domain.CSATResponse, error)
+	GetSummary(ctx context.Context) (*domain.CSATSummary, error)
+	GetSummaryByAssignee(ctx context.Context) ([]domain.CSATAssigneeSummary, error)
 }
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS csat_responses;
@@ -0,0 +1,11 @@
+CREATE TABLE "csat_responses" (
+  "id" UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  "ticket_id" UUID UNIQUE NOT NULL,
+  "assigned_to" UUID[] NOT NULL DEFAULT '{}',
+  "rating" INT NOT NULL CHECK ("rating" BETWEEN 1 AND 5),
+  "comment" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL
+);
+
+ALTER TABLE "csat_responses" ADD FOREIGN KEY ("ticket_id") REFERENCES "tickets" ("id") ON DELETE CASCADE;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS ticket_events;
+ALTER TABLE "tickets" DROP COLUMN IF EXISTS "state_reason";
+ALTER TABLE "tickets" DROP COLUMN IF EXISTS "resolution_code";
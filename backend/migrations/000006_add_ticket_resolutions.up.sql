@@ -0,0 +1,18 @@
+ALTER TABLE "tickets" ADD COLUMN "resolution_code" varchar NOT NULL DEFAULT '';
+ALTER TABLE "tickets" ADD COLUMN "state_reason" varchar NOT NULL DEFAULT '';
+
+CREATE TABLE "ticket_events" (
+  "id" UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  "ticket_id" UUID NOT NULL,
+  "actor_id" UUID NOT NULL,
+  "kind" varchar NOT NULL,
+  "old_value" varchar NOT NULL DEFAULT '',
+  "new_value" varchar NOT NULL DEFAULT '',
+  "reason" varchar NOT NULL DEFAULT '',
+  "note" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "ticket_events" ADD FOREIGN KEY ("ticket_id") REFERENCES "tickets" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "ticket_events" ("ticket_id", "created_at");
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS priority_matrix;
+ALTER TABLE "tickets" DROP COLUMN IF EXISTS "priority_overridden";
+ALTER TABLE "tickets" DROP COLUMN IF EXISTS "urgency";
+ALTER TABLE "tickets" DROP COLUMN IF EXISTS "impact";
@@ -0,0 +1,22 @@
+ALTER TABLE "tickets" ADD COLUMN "impact" INT NOT NULL DEFAULT 3;
+ALTER TABLE "tickets" ADD COLUMN "urgency" INT NOT NULL DEFAULT 3;
+ALTER TABLE "tickets" ADD COLUMN "priority_overridden" BOOLEAN NOT NULL DEFAULT false;
+
+CREATE TABLE "priority_matrix" (
+  "impact" INT NOT NULL,
+  "urgency" INT NOT NULL,
+  "priority" INT NOT NULL,
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("impact", "urgency")
+);
+
+INSERT INTO "priority_matrix" ("impact", "urgency", "priority") VALUES
+(1, 1, 1), (1, 2, 2), (1, 3, 3),
+(2, 1, 2), (2, 2, 3), (2, 3, 4),
+(3, 1, 3), (3, 2, 4), (3, 3, 4);
+
+-- Existing priorities were picked by hand. Those the matrix would give the
+-- ticket anyway follow the matrix from now on; the rest are kept as overrides.
+UPDATE "tickets" t SET "priority_overridden" = true
+FROM "priority_matrix" m
+WHERE m."impact" = t."impact" AND m."urgency" = t."urgency" AND t."priority" <> m."priority";
@@ -0,0 +1,18 @@
+CREATE TABLE "checklist_items" (
+  "id" UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  "ticket_id" UUID NOT NULL,
+  "position" INT NOT NULL,
+  "text" varchar NOT NULL,
+  "done" BOOLEAN NOT NULL DEFAULT false,
+  "required" BOOLEAN NOT NULL DEFAULT true,
+  "assignee_id" UUID,
+  "due_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL
+);
+
+ALTER TABLE "checklist_items" ADD FOREIGN KEY ("ticket_id") REFERENCES "tickets" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "checklist_items" ADD FOREIGN KEY ("assignee_id") REFERENCES "users" ("id") ON DELETE SET NULL;
+
+CREATE INDEX ON "checklist_items" ("ticket_id", "position");
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS tickets_description_trgm_idx;
+DROP INDEX IF EXISTS tickets_title_trgm_idx;
@@ -0,0 +1,28 @@
+CREATE TABLE "saved_views" (
+  "id" UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  "owner_id" UUID NOT NULL,
+  "name" varchar NOT NULL,
+  "states" INT[] NOT NULL DEFAULT '{}',
+  "priorities" INT[] NOT NULL DEFAULT '{}',
+  "assignee_id" UUID,
+  "assigned_to_me" BOOLEAN NOT NULL DEFAULT false,
+  "unassigned" BOOLEAN NOT NULL DEFAULT false,
+  "creator_id" UUID,
+  "created_by_me" BOOLEAN NOT NULL DEFAULT false,
+  "search" varchar NOT NULL DEFAULT '',
+  "sort" varchar NOT NULL DEFAULT '',
+  "columns" varchar[] NOT NULL DEFAULT '{}',
+  "shared_role" varchar,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL
+);
+
+ALTER TABLE "saved_views" ADD FOREIGN KEY ("owner_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "saved_views" ADD FOREIGN KEY ("assignee_id") REFERENCES "users" ("id") ON DELETE SET NULL;
+
+ALTER TABLE "saved_views" ADD FOREIGN KEY ("creator_id") REFERENCES "users" ("id") ON DELETE SET NULL;
+
+CREATE INDEX ON "saved_views" ("owner_id");
+
+CREATE INDEX ON "saved_views" ("shared_role");
@@ -0,0 +1,14 @@
+-- Ranks are compared byte-wise so that ordering doesn't depend on the
+-- database locale
+ALTER TABLE "tickets" ADD COLUMN "rank" varchar COLLATE "C" NOT NULL DEFAULT '';
+
+-- Existing tickets keep their creation order within each state
+UPDATE "tickets" t
+SET "rank" = lpad(r.n::text, 10, '0') || 'i'
+FROM (
+  SELECT id, row_number() OVER (PARTITION BY state ORDER BY created_at, id) AS n
+  FROM tickets
+) r
+WHERE t.id = r.id;
+
+CREATE INDEX ON "tickets" ("state", "rank");
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS ticket_approvals;
+DROP TABLE IF EXISTS approval_steps;
+ALTER TABLE "tickets" DROP COLUMN IF EXISTS "type";
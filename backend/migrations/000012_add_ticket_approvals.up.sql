@@ -0,0 +1,42 @@
+ALTER TABLE "tickets" ADD COLUMN "type" varchar NOT NULL DEFAULT 'general';
+
+-- Approval steps a ticket type needs before work starts, in order. Each step
+-- names either a specific approver or a role any member of which may approve.
+CREATE TABLE "approval_steps" (
+  "id" UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  "ticket_type" varchar NOT NULL,
+  "position" INT NOT NULL,
+  "approver_id" UUID,
+  "approver_role" varchar,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK (("approver_id" IS NULL) <> ("approver_role" IS NULL))
+);
+
+ALTER TABLE "approval_steps" ADD FOREIGN KEY ("approver_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "approval_steps" ("ticket_type", "position");
+
+-- Steps are copied onto each ticket when it is created so that later changes
+-- to the configuration don't affect tickets already in flight
+CREATE TABLE "ticket_approvals" (
+  "id" UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  "ticket_id" UUID NOT NULL,
+  "position" INT NOT NULL,
+  "approver_id" UUID,
+  "approver_role" varchar,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "decided_by" UUID,
+  "comment" varchar NOT NULL DEFAULT '',
+  "decided_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "ticket_approvals" ADD FOREIGN KEY ("ticket_id") REFERENCES "tickets" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "ticket_approvals" ADD FOREIGN KEY ("approver_id") REFERENCES "users" ("id") ON DELETE SET NULL;
+
+ALTER TABLE "ticket_approvals" ADD FOREIGN KEY ("decided_by") REFERENCES "users" ("id") ON DELETE SET NULL;
+
+CREATE INDEX ON "ticket_approvals" ("ticket_id", "position");
+
+CREATE INDEX ON "ticket_approvals" ("status", "approver_id");
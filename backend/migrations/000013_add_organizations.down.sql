@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS user_org_access;
+ALTER TABLE "saved_views" DROP COLUMN IF EXISTS "org_id";
+ALTER TABLE "tickets" DROP COLUMN IF EXISTS "org_id";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "org_id";
+DROP TABLE IF EXISTS organizations;
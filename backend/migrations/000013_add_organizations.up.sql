@@ -0,0 +1,43 @@
+-- Organizations are the tenants of a deployment. Users belong to one, and a
+-- ticket belongs to its creator's. Existing data moves into the default
+-- organization, whose admins manage the others.
+CREATE TABLE "organizations" (
+  "id" UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  "name" varchar UNIQUE NOT NULL,
+  "email_domain" varchar UNIQUE,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+INSERT INTO "organizations" ("id", "name") VALUES ('00000000-0000-0000-0000-000000000001', 'Default');
+
+ALTER TABLE "users" ADD COLUMN "org_id" UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001';
+ALTER TABLE "users" ALTER COLUMN "org_id" DROP DEFAULT;
+ALTER TABLE "users" ADD FOREIGN KEY ("org_id") REFERENCES "organizations" ("id");
+
+ALTER TABLE "tickets" ADD COLUMN "org_id" UUID;
+UPDATE "tickets" t SET "org_id" = u."org_id" FROM "users" u WHERE u."id" = t."created_by";
+UPDATE "tickets" SET "org_id" = '00000000-0000-0000-0000-000000000001' WHERE "org_id" IS NULL;
+ALTER TABLE "tickets" ALTER COLUMN "org_id" SET NOT NULL;
+ALTER TABLE "tickets" ADD FOREIGN KEY ("org_id") REFERENCES "organizations" ("id");
+
+CREATE INDEX ON "tickets" ("org_id");
+
+-- Saved views are shared by role within the owner's organization only
+ALTER TABLE "saved_views" ADD COLUMN "org_id" UUID;
+UPDATE "saved_views" v SET "org_id" = u."org_id" FROM "users" u WHERE u."id" = v."owner_id";
+ALTER TABLE "saved_views" ALTER COLUMN "org_id" SET NOT NULL;
+ALTER TABLE "saved_views" ADD FOREIGN KEY ("org_id") REFERENCES "organizations" ("id");
+
+-- Organizations, besides their own, that an agent or admin works in
+CREATE TABLE "user_org_access" (
+  "user_id" UUID NOT NULL,
+  "org_id" UUID NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("user_id", "org_id")
+);
+
+ALTER TABLE "user_org_access" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "user_org_access" ADD FOREIGN KEY ("org_id") REFERENCES "organizations" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "user_org_access" ("org_id");
@@ -0,0 +1,5 @@
+ALTER TABLE "tickets" DROP COLUMN IF EXISTS "team_id";
+
+DROP TABLE IF EXISTS "team_members";
+
+DROP TABLE IF EXISTS "teams";
@@ -0,0 +1,33 @@
+-- Teams group agents within an organization. Tickets can be queued to a team
+-- as well as assigned to people, and every member can see the team's queue.
+CREATE TABLE "teams" (
+  "id" UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  "org_id" UUID NOT NULL,
+  "name" varchar NOT NULL,
+  "lead_id" UUID,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  UNIQUE ("org_id", "name")
+);
+
+ALTER TABLE "teams" ADD FOREIGN KEY ("org_id") REFERENCES "organizations" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "teams" ADD FOREIGN KEY ("lead_id") REFERENCES "users" ("id") ON DELETE SET NULL;
+
+CREATE TABLE "team_members" (
+  "team_id" UUID NOT NULL,
+  "user_id" UUID NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("team_id", "user_id")
+);
+
+ALTER TABLE "team_members" ADD FOREIGN KEY ("team_id") REFERENCES "teams" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "team_members" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "team_members" ("user_id");
+
+ALTER TABLE "tickets" ADD COLUMN "team_id" UUID;
+ALTER TABLE "tickets" ADD FOREIGN KEY ("team_id") REFERENCES "teams" ("id") ON DELETE SET NULL;
+
+CREATE INDEX ON "tickets" ("team_id");
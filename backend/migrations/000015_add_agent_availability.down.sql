@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "out_of_office";
+
+DROP TABLE IF EXISTS "agent_availability";
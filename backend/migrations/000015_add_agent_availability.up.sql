@@ -0,0 +1,32 @@
+-- Whether an agent is taking new work, and how much. Agents without a row
+-- are available with no limit on open tickets.
+CREATE TABLE "agent_availability" (
+  "user_id" UUID PRIMARY KEY,
+  "status" varchar NOT NULL DEFAULT 'available',
+  "max_open_tickets" INT NOT NULL DEFAULT 0,
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK ("max_open_tickets" >= 0)
+);
+
+ALTER TABLE "agent_availability" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+-- Planned absences. Tickets assigned to an agent while one is in effect go to
+-- the delegate instead.
+CREATE TABLE "out_of_office" (
+  "id" UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  "user_id" UUID NOT NULL,
+  "starts_at" timestamptz NOT NULL,
+  "ends_at" timestamptz NOT NULL,
+  "delegate_id" UUID,
+  "note" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK ("ends_at" > "starts_at")
+);
+
+ALTER TABLE "out_of_office" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "out_of_office" ADD FOREIGN KEY ("delegate_id") REFERENCES "users" ("id") ON DELETE SET NULL;
+
+CREATE INDEX ON "out_of_office" ("user_id", "ends_at");
+
+CREATE INDEX ON "out_of_office" ("starts_at", "ends_at");
@@ -0,0 +1,9 @@
+ALTER TABLE "teams" DROP COLUMN IF EXISTS "calendar_id";
+
+ALTER TABLE "organizations" DROP COLUMN IF EXISTS "calendar_id";
+
+DROP TABLE IF EXISTS "calendar_holidays";
+
+DROP TABLE IF EXISTS "calendar_hours";
+
+DROP TABLE IF EXISTS "business_calendars";
@@ -0,0 +1,40 @@
+-- Business calendars describe when an organization or a team is working, so
+-- that response and resolution times can ignore nights, weekends and holidays.
+CREATE TABLE "business_calendars" (
+  "id" UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  "org_id" UUID NOT NULL,
+  "name" varchar NOT NULL,
+  "time_zone" varchar NOT NULL DEFAULT 'UTC',
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  UNIQUE ("org_id", "name")
+);
+
+ALTER TABLE "business_calendars" ADD FOREIGN KEY ("org_id") REFERENCES "organizations" ("id") ON DELETE CASCADE;
+
+-- Working hours are minutes after local midnight; weekday 0 is Sunday
+CREATE TABLE "calendar_hours" (
+  "calendar_id" UUID NOT NULL,
+  "weekday" INT NOT NULL CHECK ("weekday" BETWEEN 0 AND 6),
+  "start_minute" INT NOT NULL,
+  "end_minute" INT NOT NULL,
+  PRIMARY KEY ("calendar_id", "weekday", "start_minute"),
+  CHECK ("start_minute" >= 0 AND "start_minute" < "end_minute" AND "end_minute" <= 1440)
+);
+
+ALTER TABLE "calendar_hours" ADD FOREIGN KEY ("calendar_id") REFERENCES "business_calendars" ("id") ON DELETE CASCADE;
+
+CREATE TABLE "calendar_holidays" (
+  "calendar_id" UUID NOT NULL,
+  "day" DATE NOT NULL,
+  "name" varchar NOT NULL DEFAULT '',
+  PRIMARY KEY ("calendar_id", "day")
+);
+
+ALTER TABLE "calendar_holidays" ADD FOREIGN KEY ("calendar_id") REFERENCES "business_calendars" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "organizations" ADD COLUMN "calendar_id" UUID;
+ALTER TABLE "organizations" ADD FOREIGN KEY ("calendar_id") REFERENCES "business_calendars" ("id") ON DELETE SET NULL;
+
+ALTER TABLE "teams" ADD COLUMN "calendar_id" UUID;
+ALTER TABLE "teams" ADD FOREIGN KEY ("calendar_id") REFERENCES "business_calendars" ("id") ON DELETE SET NULL;
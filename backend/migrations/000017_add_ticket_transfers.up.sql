@@ -0,0 +1,35 @@
+-- A transfer hands a ticket from one of its assignees to another agent or to
+-- a team. It stays pending until the receiver accepts or declines it.
+CREATE TABLE "ticket_transfers" (
+  "id" UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  "ticket_id" UUID NOT NULL,
+  "from_user_id" UUID NOT NULL,
+  "to_user_id" UUID,
+  "to_team_id" UUID,
+  "note" varchar NOT NULL DEFAULT '',
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "decided_by" UUID,
+  "reason" varchar NOT NULL DEFAULT '',
+  "decided_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK (("to_user_id" IS NULL) <> ("to_team_id" IS NULL))
+);
+
+ALTER TABLE "ticket_transfers" ADD FOREIGN KEY ("ticket_id") REFERENCES "tickets" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "ticket_transfers" ADD FOREIGN KEY ("from_user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "ticket_transfers" ADD FOREIGN KEY ("to_user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "ticket_transfers" ADD FOREIGN KEY ("to_team_id") REFERENCES "teams" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "ticket_transfers" ADD FOREIGN KEY ("decided_by") REFERENCES "users" ("id") ON DELETE SET NULL;
+
+-- A ticket has at most one transfer waiting at a time
+CREATE UNIQUE INDEX ON "ticket_transfers" ("ticket_id") WHERE "status" = 'pending';
+
+CREATE INDEX ON "ticket_transfers" ("ticket_id", "created_at");
+
+CREATE INDEX ON "ticket_transfers" ("status", "to_user_id");
+
+CREATE INDEX ON "ticket_transfers" ("status", "to_team_id");
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "comment_revisions";
+
+ALTER TABLE "comments" DROP COLUMN IF EXISTS "edited_at";
@@ -0,0 +1,16 @@
+ALTER TABLE "comments" ADD COLUMN "edited_at" timestamptz;
+
+-- Each edit keeps the text the comment had before it
+CREATE TABLE "comment_revisions" (
+  "id" UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  "comment_id" UUID NOT NULL,
+  "description" varchar NOT NULL,
+  "edited_by" UUID,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "comment_revisions" ADD FOREIGN KEY ("comment_id") REFERENCES "comments" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "comment_revisions" ADD FOREIGN KEY ("edited_by") REFERENCES "users" ("id") ON DELETE SET NULL;
+
+CREATE INDEX ON "comment_revisions" ("comment_id", "created_at");
@@ -0,0 +1,5 @@
+-- Internal notes are only shown to agents and admins; public replies are
+-- also shown to the ticket's creator
+ALTER TABLE "comments" ADD COLUMN "visibility" varchar NOT NULL DEFAULT 'public';
+
+ALTER TABLE "comments" ADD CHECK ("visibility" IN ('public', 'internal'));
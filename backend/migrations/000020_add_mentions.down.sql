@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "mentions";
+
+ALTER TABLE "tickets" DROP COLUMN IF EXISTS "readers";
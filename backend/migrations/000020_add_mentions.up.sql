@@ -0,0 +1,30 @@
+-- Readers may see a ticket without having created it or being assigned to
+-- it, e.g. because someone mentioned them on it
+ALTER TABLE "tickets" ADD COLUMN "readers" UUID[] NOT NULL DEFAULT '{}';
+
+-- A mention of a user in a ticket's description, or in one of its comments
+-- when comment_id is set
+CREATE TABLE "mentions" (
+  "id" UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  "ticket_id" UUID NOT NULL,
+  "comment_id" UUID,
+  "user_id" UUID NOT NULL,
+  "created_by" UUID,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "mentions" ADD FOREIGN KEY ("ticket_id") REFERENCES "tickets" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "mentions" ADD FOREIGN KEY ("comment_id") REFERENCES "comments" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "mentions" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "mentions" ADD FOREIGN KEY ("created_by") REFERENCES "users" ("id") ON DELETE SET NULL;
+
+-- A user is mentioned at most once per description and per comment, so
+-- editing the text only notifies the newly mentioned
+CREATE UNIQUE INDEX ON "mentions" ("ticket_id", "user_id") WHERE "comment_id" IS NULL;
+
+CREATE UNIQUE INDEX ON "mentions" ("comment_id", "user_id") WHERE "comment_id" IS NOT NULL;
+
+CREATE INDEX ON "mentions" ("user_id", "created_at");
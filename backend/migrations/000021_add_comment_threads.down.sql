@@ -0,0 +1,5 @@
+ALTER TABLE "comments" DROP COLUMN IF EXISTS "depth";
+
+ALTER TABLE "comments" DROP COLUMN IF EXISTS "thread_id";
+
+ALTER TABLE "comments" DROP COLUMN IF EXISTS "parent_id";
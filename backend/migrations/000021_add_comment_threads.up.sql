@@ -0,0 +1,16 @@
+-- A reply points at the comment it answers and at the first comment of its
+-- thread, so a page of threads can be loaded with all of their replies.
+-- Deleting a comment deletes the replies beneath it.
+ALTER TABLE "comments" ADD COLUMN "parent_id" UUID;
+
+ALTER TABLE "comments" ADD COLUMN "thread_id" UUID;
+
+ALTER TABLE "comments" ADD COLUMN "depth" integer NOT NULL DEFAULT 0;
+
+ALTER TABLE "comments" ADD FOREIGN KEY ("parent_id") REFERENCES "comments" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "comments" ADD FOREIGN KEY ("thread_id") REFERENCES "comments" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "comments" ("ticket_id", "created_at") WHERE "parent_id" IS NULL;
+
+CREATE INDEX ON "comments" ("thread_id", "created_at");
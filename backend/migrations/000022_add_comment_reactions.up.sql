@@ -0,0 +1,13 @@
+-- Emoji reactions on comments; a user adds each emoji to a comment at most
+-- once
+CREATE TABLE "comment_reactions" (
+  "comment_id" UUID NOT NULL,
+  "user_id" UUID NOT NULL,
+  "emoji" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("comment_id", "user_id", "emoji")
+);
+
+ALTER TABLE "comment_reactions" ADD FOREIGN KEY ("comment_id") REFERENCES "comments" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "comment_reactions" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "macros";
+
+ALTER TABLE "tickets" DROP COLUMN IF EXISTS "labels";
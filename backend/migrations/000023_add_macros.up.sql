@@ -0,0 +1,34 @@
+-- Free-form labels on tickets, which macros can add
+ALTER TABLE "tickets" ADD COLUMN "labels" varchar[] NOT NULL DEFAULT '{}';
+
+-- A canned reply, optionally bundled with changes to the ticket. Personal
+-- macros belong to owner_id; shared ones, which admins publish to their
+-- organization, have none. Unset actions leave the ticket as it is.
+CREATE TABLE "macros" (
+  "id" UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  "org_id" UUID NOT NULL,
+  "owner_id" UUID,
+  "created_by" UUID,
+  "name" varchar NOT NULL,
+  "reply" text NOT NULL DEFAULT '',
+  "visibility" varchar NOT NULL DEFAULT 'public',
+  "state" INT,
+  "resolution_code" varchar NOT NULL DEFAULT '',
+  "priority" INT,
+  "assigned_to" UUID[],
+  "labels" varchar[] NOT NULL DEFAULT '{}',
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL
+);
+
+ALTER TABLE "macros" ADD CHECK ("visibility" IN ('public', 'internal'));
+
+ALTER TABLE "macros" ADD FOREIGN KEY ("org_id") REFERENCES "organizations" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "macros" ADD FOREIGN KEY ("owner_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "macros" ADD FOREIGN KEY ("created_by") REFERENCES "users" ("id") ON DELETE SET NULL;
+
+CREATE INDEX ON "macros" ("owner_id");
+
+CREATE INDEX ON "macros" ("org_id") WHERE "owner_id" IS NULL;
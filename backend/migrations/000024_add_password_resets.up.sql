@@ -0,0 +1,19 @@
+-- Refresh tokens carry the version they were issued under; bumping it signs
+-- the user out everywhere, e.g. after a password reset
+ALTER TABLE "users" ADD COLUMN "session_version" INT NOT NULL DEFAULT 0;
+
+-- Single-use tokens mailed to users, e.g. to reset a password. Only a hash
+-- of each token is stored.
+CREATE TABLE "user_tokens" (
+  "id" UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  "user_id" UUID NOT NULL,
+  "purpose" varchar NOT NULL,
+  "token_hash" varchar UNIQUE NOT NULL,
+  "expires_at" timestamptz NOT NULL,
+  "used_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "user_tokens" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "user_tokens" ("user_id", "purpose");
@@ -0,0 +1,5 @@
+-- NULL until the user follows the link mailed when they signed up. Existing
+-- accounts are taken as verified.
+ALTER TABLE "users" ADD COLUMN "email_verified_at" timestamptz;
+
+UPDATE "users" SET "email_verified_at" = "created_at";
@@ -0,0 +1,21 @@
+-- Refresh tokens are rotated on every use. Each login starts a family; the
+-- tokens it is refreshed into share the family, and reusing a rotated token
+-- revokes all of them. Only a hash of each token is stored.
+CREATE TABLE "refresh_tokens" (
+  "id" UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  "user_id" UUID NOT NULL,
+  "family_id" UUID NOT NULL,
+  "token_hash" varchar UNIQUE NOT NULL,
+  "expires_at" timestamptz NOT NULL,
+  "used_at" timestamptz,
+  "revoked_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "refresh_tokens" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "refresh_tokens" ("family_id");
+
+CREATE INDEX ON "refresh_tokens" ("user_id");
+
+CREATE INDEX ON "refresh_tokens" ("expires_at");
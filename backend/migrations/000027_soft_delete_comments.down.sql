@@ -0,0 +1,7 @@
+ALTER TABLE "comment_revisions" DROP CONSTRAINT IF EXISTS "comment_revisions_comment_id_fkey";
+
+ALTER TABLE "comment_revisions" ADD FOREIGN KEY ("comment_id") REFERENCES "comments" ("id") ON DELETE CASCADE;
+
+DELETE FROM "comments" WHERE "deleted_at" IS NOT NULL;
+
+ALTER TABLE "comments" DROP COLUMN IF EXISTS "deleted_at";
@@ -0,0 +1,7 @@
+-- Deleting a comment only marks it deleted, so its revisions are kept. They
+-- no longer go with the comment; deleting a ticket removes them first.
+ALTER TABLE "comments" ADD COLUMN "deleted_at" timestamptz;
+
+ALTER TABLE "comment_revisions" DROP CONSTRAINT "comment_revisions_comment_id_fkey";
+
+ALTER TABLE "comment_revisions" ADD FOREIGN KEY ("comment_id") REFERENCES "comments" ("id") ON DELETE RESTRICT;
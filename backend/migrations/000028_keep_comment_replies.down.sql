@@ -0,0 +1,7 @@
+ALTER TABLE "comments" DROP CONSTRAINT IF EXISTS "comments_parent_id_fkey";
+
+ALTER TABLE "comments" DROP CONSTRAINT IF EXISTS "comments_thread_id_fkey";
+
+ALTER TABLE "comments" ADD FOREIGN KEY ("parent_id") REFERENCES "comments" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "comments" ADD FOREIGN KEY ("thread_id") REFERENCES "comments" ("id") ON DELETE CASCADE;
@@ -0,0 +1,10 @@
+-- Replies outlive the comment they answer; a deleted comment is shown as a
+-- placeholder while it has replies. Comments are only removed with their
+-- ticket, so nothing is left pointing at one that is gone.
+ALTER TABLE "comments" DROP CONSTRAINT "comments_parent_id_fkey";
+
+ALTER TABLE "comments" DROP CONSTRAINT "comments_thread_id_fkey";
+
+ALTER TABLE "comments" ADD FOREIGN KEY ("parent_id") REFERENCES "comments" ("id") ON DELETE SET NULL;
+
+ALTER TABLE "comments" ADD FOREIGN KEY ("thread_id") REFERENCES "comments" ("id") ON DELETE SET NULL;
@@ -0,0 +1,7 @@
+UPDATE "ticket_events" e SET "note" = c."description"
+FROM "comments" c
+WHERE e."kind" = 'comment_deleted' AND e."old_value" = c."id"::text;
+
+DELETE FROM "comment_revisions" r
+USING "comments" c
+WHERE r."comment_id" = c."id" AND r."created_at" = c."deleted_at";
@@ -0,0 +1,13 @@
+-- A deleted comment's final text is kept as its last revision, stamped with
+-- the time it was deleted, instead of in the ticket's history where the
+-- ticket creator could read it.
+INSERT INTO "comment_revisions" ("comment_id", "description", "edited_by", "created_at")
+SELECT c."id", c."description", (
+  SELECT e."actor_id" FROM "ticket_events" e
+  WHERE e."kind" = 'comment_deleted' AND e."old_value" = c."id"::text
+  ORDER BY e."created_at" DESC LIMIT 1
+), c."deleted_at"
+FROM "comments" c
+WHERE c."deleted_at" IS NOT NULL;
+
+UPDATE "ticket_events" SET "note" = '' WHERE "kind" = 'comment_deleted';
@@ -0,0 +1 @@
+ALTER TABLE "saved_views" DROP COLUMN IF EXISTS "shared_team_id";
@@ -0,0 +1,6 @@
+-- A view can be shared with a team as well as with a role
+ALTER TABLE "saved_views" ADD COLUMN "shared_team_id" UUID;
+
+ALTER TABLE "saved_views" ADD FOREIGN KEY ("shared_team_id") REFERENCES "teams" ("id") ON DELETE SET NULL;
+
+CREATE INDEX ON "saved_views" ("shared_team_id");
@@ -5,6 +5,7 @@ import (
 	"flag"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -12,6 +13,18 @@ type userContextKey string
 
 const UserIDKey userContextKey = "user_id"
 const UserRoleKey userContextKey = "user_role"
+const UserOrgKey userContextKey = "user_org"
+
+// UserOrgsKey holds the []uuid.UUID of organizations the caller may access.
+// Repositories leave queries unscoped only when it is absent.
+const UserOrgsKey userContextKey = "user_orgs"
+
+// UserTeamsKey holds the []uuid.UUID of teams the caller is a member of
+const UserTeamsKey userContextKey = "user_teams"
+
+// UserUnverifiedKey is true for callers who haven't verified their email
+// address
+const UserUnverifiedKey userContextKey = "user_unverified"
 
 type Config struct {
 	ADDR          int
@@ -25,6 +38,36 @@ type Config struct {
 	CookieDomain  string
 	CookiePath    string
 	CookieName    string
+	BaseURL       string
+	MailFrom      string
+	// MailDriver is "log", "smtp" or "file"; "file" writes messages to
+	// MailDir
+	MailDriver   string
+	MailDir      string
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	CSATExpiry   time.Duration
+	// AssignmentPolicy is "warn" or "refuse"; see domain.AssignmentPolicy
+	AssignmentPolicy string
+	// ForwardInterval is how often tickets of agents who are out of office
+	// are forwarded to their delegates
+	ForwardInterval time.Duration
+	// CommentEditWindow is how long after posting authors may edit a comment
+	CommentEditWindow time.Duration
+	// MentionPolicy is "grant" or "notify"; see domain.MentionPolicy
+	MentionPolicy string
+	// PasswordResetURL is the page that takes the token from a reset link
+	PasswordResetURL    string
+	PasswordResetExpiry time.Duration
+	// VerificationPolicy is "none", "login" or "tickets"; see
+	// domain.VerificationPolicy
+	VerificationPolicy string
+	VerificationExpiry time.Duration
+	// SignupDomains are the email domains users may sign up with; empty
+	// allows any
+	SignupDomains []string
 }
 
 func LoadConfig() (*Config, error) {
@@ -41,6 +84,24 @@ func LoadConfig() (*Config, error) {
 	config.CookiePath = GetString("CookiePath", "/")
 	config.TokenExpiry = time.Minute * time.Duration(GetInt("TokenExpiry", 15))
 	config.RefreshExpiry = time.Hour * time.Duration(GetInt("RefreshTokenExpiry", 24))
+	config.BaseURL = GetString("BaseURL", "http://localhost:8081")
+	config.MailFrom = GetString("MailFrom", "support@example.com")
+	config.MailDriver = GetString("MailDriver", "log")
+	config.MailDir = GetString("MailDir", "mail")
+	config.SMTPHost = GetString("SMTPHost", "localhost")
+	config.SMTPPort = GetInt("SMTPPort", 587)
+	config.SMTPUsername = GetString("SMTPUsername", "")
+	config.SMTPPassword = GetString("SMTPPassword", "")
+	config.CSATExpiry = time.Hour * time.Duration(GetInt("CSATExpiry", 168))
+	config.AssignmentPolicy = GetString("AssignmentPolicy", "warn")
+	config.ForwardInterval = time.Minute * time.Duration(GetInt("ForwardInterval", 15))
+	config.CommentEditWindow = time.Minute * time.Duration(GetInt("CommentEditWindow", 15))
+	config.MentionPolicy = GetString("MentionPolicy", "grant")
+	config.PasswordResetURL = GetString("PasswordResetURL", "http://localhost:5173/reset-password")
+	config.PasswordResetExpiry = time.Minute * time.Duration(GetInt("PasswordResetExpiry", 60))
+	config.VerificationPolicy = GetString("VerificationPolicy", "tickets")
+	config.VerificationExpiry = time.Hour * time.Duration(GetInt("VerificationExpiry", 48))
+	config.SignupDomains = GetList("SignupDomains")
 	return &config, nil
 }
 
@@ -52,6 +113,17 @@ func GetString(key, fallback string) string {
 	return val
 }
 
+// GetList splits a comma-separated variable, dropping empty items
+func GetList(key string) []string {
+	var list []string
+	for _, item := range strings.Split(GetString(key, ""), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
 func GetInt(key string, fallback int) int {
 	val, ok := os.LookupEnv(key)
 	if !ok {
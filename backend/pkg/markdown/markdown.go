@@ -0,0 +1,76 @@
+// Package markdown renders the Markdown used in ticket descriptions and
+// comments to HTML that is safe to insert into a page.
+//
+// Parsing is done by goldmark, with the GitHub extensions for tables,
+// strikethrough and bare URLs, and the output is sanitized by bluemonday.
+// Raw HTML in the source is not parsed at all, so tags such as <script> and
+// attributes such as onerror are shown as text and never run. Links and
+// images only keep http, https and mailto URLs, or relative ones; anything
+// else, such as javascript: URLs, is dropped.
+package markdown
+
+import (
+	"bytes"
+	"html"
+	"regexp"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/util"
+)
+
+var (
+	md = goldmark.New(
+		goldmark.WithParser(newParser()),
+		goldmark.WithExtensions(
+			extension.NewTable(extension.WithTableCellAlignMethod(extension.TableCellAlignAttribute)),
+			extension.Strikethrough,
+			extension.Linkify,
+		),
+	)
+	policy = newPolicy()
+)
+
+// Render converts Markdown to sanitized HTML
+func Render(src string) string {
+	var b bytes.Buffer
+	if err := md.Convert([]byte(src), &b); err != nil {
+		// Conversion only fails if writing fails, which a buffer doesn't
+		return "<p>" + html.EscapeString(src) + "</p>\n"
+	}
+	return policy.Sanitize(b.String())
+}
+
+// newParser is goldmark's default parser without its raw HTML parsers, so
+// that HTML is escaped like any other text
+func newParser() parser.Parser {
+	without := func(values []util.PrioritizedValue, drop any) []util.PrioritizedValue {
+		kept := values[:0]
+		for _, v := range values {
+			if v.Value != drop {
+				kept = append(kept, v)
+			}
+		}
+		return kept
+	}
+	return parser.NewParser(
+		parser.WithBlockParsers(without(parser.DefaultBlockParsers(), parser.NewHTMLBlockParser())...),
+		parser.WithInlineParsers(without(parser.DefaultInlineParsers(), parser.NewRawHTMLParser())...),
+		parser.WithParagraphTransformers(parser.DefaultParagraphTransformers()...),
+	)
+}
+
+// newPolicy allows the markup goldmark produces for user content. Links get
+// rel="nofollow noreferrer".
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	p.AllowURLSchemes("http", "https", "mailto")
+	p.RequireNoReferrerOnLinks(true)
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[A-Za-z0-9_+#.-]+$`)).OnElements("code")
+	p.AllowAttrs("alt", "title").OnElements("img")
+	p.AllowAttrs("start").Matching(bluemonday.Integer).OnElements("ol")
+	p.AllowAttrs("align").Matching(regexp.MustCompile(`^(?:left|center|right)$`)).OnElements("th", "td")
+	return p
+}
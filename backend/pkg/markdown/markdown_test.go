@@ -0,0 +1,119 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		expected string
+	}{
+		{"Empty", "", ""},
+		{"Paragraphs", "one\ntwo\n\nthree", "<p>one\ntwo</p>\n<p>three</p>\n"},
+		{"Hard break", "one  \ntwo", "<p>one<br>\ntwo</p>\n"},
+		{"Headings", "# Title #\nSub\n---", "<h1>Title</h1>\n<h2>Sub</h2>\n"},
+		{"Emphasis", "*a* **b** _c_ __d__ ~~e~~", "<p><em>a</em> <strong>b</strong> <em>c</em> <strong>d</strong> <del>e</del></p>\n"},
+		{"Nested emphasis", "**bold *and italic***", "<p><strong>bold <em>and italic</em></strong></p>\n"},
+		{"Underscores in words", "snake_case_name and 2*3*4", "<p>snake_case_name and 2<em>3</em>4</p>\n"},
+		{"Unclosed emphasis", "a * b and **c", "<p>a * b and **c</p>\n"},
+		{"Code span", "run `rm -rf <dir>` now", "<p>run <code>rm -rf &lt;dir&gt;</code> now</p>\n"},
+		{"Double backtick code span", "``a ` b``", "<p><code>a ` b</code></p>\n"},
+		{"Escapes", `\*not em\* and \<b\>`, "<p>*not em* and &lt;b&gt;</p>\n"},
+		{
+			"Fenced code",
+			"```go\nfunc main() {\n\tfmt.Println(\"<hi>\")\n}\n```",
+			"<pre><code class=\"language-go\">func main() {\n\tfmt.Println(&#34;&lt;hi&gt;&#34;)\n}\n</code></pre>\n",
+		},
+		{"Unsafe fence language", "```\"><script>\nx\n```", "<pre><code>x\n</code></pre>\n"},
+		{"Unclosed fence", "~~~\nline", "<pre><code>line\n</code></pre>\n"},
+		{
+			"Stack trace",
+			"Crash:\n\n    at Foo.<init>(Foo.java:10)\n    at Bar.run(Bar.java:3)",
+			"<p>Crash:</p>\n<pre><code>at Foo.&lt;init&gt;(Foo.java:10)\nat Bar.run(Bar.java:3)\n</code></pre>\n",
+		},
+		{"Block quote", "> quoted\nlazy\n> > nested", "<blockquote>\n<p>quoted\nlazy</p>\n<blockquote>\n<p>nested</p>\n</blockquote>\n</blockquote>\n"},
+		{"Rule", "a\n\n***\n\nb", "<p>a</p>\n<hr>\n<p>b</p>\n"},
+		{"Tight list", "- one\n- two\n  - nested", "<ul>\n<li>one</li>\n<li>two\n<ul>\n<li>nested</li>\n</ul>\n</li>\n</ul>\n"},
+		{"Loose list", "1. one\n\n2. two", "<ol>\n<li>\n<p>one</p>\n</li>\n<li>\n<p>two</p>\n</li>\n</ol>\n"},
+		{"Ordered list start", "3) three\n4) four", "<ol start=\"3\">\n<li>three</li>\n<li>four</li>\n</ol>\n"},
+		{
+			"Table",
+			"| Name | Count |\n| :--- | ---: |\n| a \\| b | `1` |\n| c |",
+			"<table>\n<thead>\n<tr>\n<th align=\"left\">Name</th>\n<th align=\"right\">Count</th>\n</tr>\n</thead>\n<tbody>\n" +
+				"<tr>\n<td align=\"left\">a | b</td>\n<td align=\"right\"><code>1</code></td>\n</tr>\n" +
+				"<tr>\n<td align=\"left\">c</td>\n<td></td>\n</tr>\n</tbody>\n</table>\n",
+		},
+		{
+			"Link",
+			`see [the *docs*](https://example.com/a_(b) "Docs")`,
+			"<p>see <a href=\"https://example.com/a_(b)\" title=\"Docs\" rel=\"nofollow noreferrer\">the <em>docs</em></a></p>\n",
+		},
+		{"Relative link", "[ticket](/tickets/1#top)", "<p><a href=\"/tickets/1#top\" rel=\"nofollow noreferrer\">ticket</a></p>\n"},
+		{"Mailto link", "<help@example.com>", "<p><a href=\"mailto:help@example.com\" rel=\"nofollow noreferrer\">help@example.com</a></p>\n"},
+		{"Bare URL", "see https://example.com/x?y=1.", "<p>see <a href=\"https://example.com/x?y=1\" rel=\"nofollow noreferrer\">https://example.com/x?y=1</a>.</p>\n"},
+		{"Bare URL in parentheses", "(https://example.com)", "<p>(<a href=\"https://example.com\" rel=\"nofollow noreferrer\">https://example.com</a>)</p>\n"},
+		{"Image", `![a "logo"](https://example.com/l.png)`, "<p><img src=\"https://example.com/l.png\" alt=\"a &#34;logo&#34;\"></p>\n"},
+		{"Relative image", "![logo](/l.png)", "<p><img src=\"/l.png\" alt=\"logo\"></p>\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Render(tt.src); got != tt.expected {
+				t.Errorf("Render(%q) =\n%s\nwant\n%s", tt.src, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRenderSanitizes(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		expected string
+	}{
+		{"Script tag", "<script>alert(1)</script>", "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>\n"},
+		{"Event handler", `<img src=x onerror="alert(1)">`, "<p>&lt;img src=x onerror=&#34;alert(1)&#34;&gt;</p>\n"},
+		{"Javascript link", "[click](javascript:alert(1))", "<p>click</p>\n"},
+		{"Mixed case scheme", "[click](JaVaScRiPt:alert(1))", "<p>click</p>\n"},
+		{"Scheme with control characters", "[click](<java\tscript:alert(1)>)", "<p>click</p>\n"},
+		{"Escaped scheme", `[click](javascript\:alert(1))`, "<p>click</p>\n"},
+		{"Data link", "[click](data:text/html;base64,PHNjcmlwdD4=)", "<p>click</p>\n"},
+		{"Javascript autolink", "<javascript:alert(1)>", "<p>javascript:alert(1)</p>\n"},
+		{"Javascript image", "![x](javascript:alert(1))", "<p><img alt=\"x\"></p>\n"},
+		{"Attribute breakout", `[x](https://example.com/"onmouseover="alert(1))`, "<p><a href=\"https://example.com/%22onmouseover=%22alert(1)\" rel=\"nofollow noreferrer\">x</a></p>\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Render(tt.src)
+			if got != tt.expected {
+				t.Errorf("Render(%q) =\n%s\nwant\n%s", tt.src, got, tt.expected)
+			}
+			if strings.Contains(got, "<script") || strings.Contains(got, "javascript:alert(1)\"") {
+				t.Errorf("Render(%q) = %q; left markup unsanitized", tt.src, got)
+			}
+		})
+	}
+}
+
+// TestRenderLargeInput renders inputs that make naive parsers backtrack, at
+// the longest length a ticket description may have
+func TestRenderLargeInput(t *testing.T) {
+	const size = 20000
+	patterns := []string{"*a ", "_a ", "**a*", "a~~", "`a ", "[", "[a](", "![a](", "[*a](", "> ", "1. "}
+
+	for _, p := range patterns {
+		t.Run(p, func(t *testing.T) {
+			src := strings.Repeat(p, size/len(p))
+			start := time.Now()
+			Render(src)
+			if elapsed := time.Since(start); elapsed > 2*time.Second {
+				t.Errorf("Render took %v on %d bytes of %q", elapsed, len(src), p)
+			}
+		})
+	}
+}
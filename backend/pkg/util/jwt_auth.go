@@ -14,13 +14,41 @@ import (
 	"github.com/nickhildpac/ticket-management-app/pkg/configs"
 )
 
+// Token types go in the typ claim of every token signed with the JWT secret,
+// so that a token of one kind is never accepted as another, such as a survey
+// link's token as an access token
+const (
+	tokenTypeAccess  = "access"
+	tokenTypeRefresh = "refresh"
+	tokenTypeAction  = "action"
+)
+
+var errWrongTokenType = errors.New("wrong token type")
+
 type Claims struct {
 	jwt.RegisteredClaims
+	Type string `json:"typ"`
 	Role string `json:"role"`
+	// Org is the user's own organization and Orgs every organization they
+	// may access, including Org
+	Org  string      `json:"org"`
+	Orgs []uuid.UUID `json:"orgs"`
+	// Teams are the teams the user is a member of
+	Teams []uuid.UUID `json:"teams"`
+	// Unverified is set until the user verifies their email address, so
+	// tokens issued before verification existed count as verified
+	Unverified bool `json:"unverified,omitempty"`
 }
 
+// RefreshClaims identify a refresh token; its hash is stored server-side,
+// so the ID makes every token unique
 type RefreshClaims struct {
 	jwt.RegisteredClaims
+	Type string `json:"typ"`
+	// Version is the user's session version when the token was issued
+	Version int32 `json:"ver"`
+	// Family is shared by the tokens a login is refreshed into
+	Family uuid.UUID `json:"fam"`
 }
 
 type JWTUser struct {
@@ -29,6 +57,16 @@ type JWTUser struct {
 	LastName  string          `json:"last_name"`
 	Email     string          `json:"email"`
 	Role      domain.UserRole `json:"role"`
+	OrgID     uuid.UUID       `json:"org_id"`
+	OrgIDs    []uuid.UUID     `json:"org_ids"`
+	TeamIDs   []uuid.UUID     `json:"team_ids"`
+	// EmailVerified is false until the user follows the link mailed when
+	// they signed up
+	EmailVerified bool `json:"email_verified"`
+	// SessionVersion goes into the refresh token; see domain.User
+	SessionVersion int32 `json:"-"`
+	// FamilyID goes into the refresh token; see domain.RefreshToken
+	FamilyID uuid.UUID `json:"-"`
 }
 type TokenPairs struct {
 	Token        string `json:"access_token"`
@@ -46,7 +84,12 @@ func GenerateTokenPair(conf *configs.Config, user *JWTUser) (TokenPairs, error)
 			NotBefore: jwt.NewNumericDate(time.Now()),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
-		Role: string(user.Role),
+		Type:       tokenTypeAccess,
+		Role:       string(user.Role),
+		Org:        user.OrgID.String(),
+		Orgs:       user.OrgIDs,
+		Teams:      user.TeamIDs,
+		Unverified: !user.EmailVerified,
 	})
 
 	// create a signed token
@@ -58,9 +101,16 @@ func GenerateTokenPair(conf *configs.Config, user *JWTUser) (TokenPairs, error)
 	// create refresh token and set claims
 	refreshToken := jwt.NewWithClaims(jwt.SigningMethodHS256, &RefreshClaims{
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
 			Subject:   user.ID.String(),
+			Issuer:    conf.JWTIssuer,
+			Audience:  jwt.ClaimStrings{conf.JWTAudience},
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(conf.RefreshExpiry)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
+		Type:    tokenTypeRefresh,
+		Version: user.SessionVersion,
+		Family:  user.FamilyID,
 	})
 	// create signed refresh token
 	signedRefreshToken, err := refreshToken.SignedString([]byte(conf.JWTSecret))
@@ -76,6 +126,25 @@ func GenerateTokenPair(conf *configs.Config, user *JWTUser) (TokenPairs, error)
 	return tokenPairs, nil
 }
 
+// VerifyRefreshToken checks the signature, expiry, issuer, audience and type
+// of a refresh token. Whether it was already used is up to the caller.
+func VerifyRefreshToken(conf *configs.Config, token string) (*RefreshClaims, error) {
+	claims := &RefreshClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (any, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method %v", token.Header["alg"])
+		}
+		return []byte(conf.JWTSecret), nil
+	}, jwt.WithIssuer(conf.JWTIssuer), jwt.WithAudience(conf.JWTAudience), jwt.WithExpirationRequired())
+	if err != nil {
+		return nil, err
+	}
+	if claims.Type != tokenTypeRefresh {
+		return nil, errWrongTokenType
+	}
+	return claims, nil
+}
+
 func GetExpiredRefreshCookie(conf *configs.Config) *http.Cookie {
 	return &http.Cookie{
 		Name:     conf.CookieName,
@@ -127,16 +196,16 @@ func GetTokenFromHeaderAndVerify(conf *configs.Config, w http.ResponseWriter, r
 			return nil, fmt.Errorf("unexpected signing method %v", token.Header["alg"])
 		}
 		return []byte(conf.JWTSecret), nil
-	})
+	}, jwt.WithIssuer(conf.JWTIssuer), jwt.WithAudience(conf.JWTAudience), jwt.WithExpirationRequired())
 	if err != nil {
-		if strings.HasPrefix(err.Error(), "token is expired by") {
+		if errors.Is(err, jwt.ErrTokenExpired) {
 			return nil, errors.New("expired token")
 		}
 		return nil, err
 	}
 
-	if claims.Issuer != conf.JWTIssuer {
-		return nil, errors.New("invalid issuer")
+	if claims.Type != tokenTypeAccess {
+		return nil, errWrongTokenType
 	}
 	return claims, nil
 }
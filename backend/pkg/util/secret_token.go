@@ -0,0 +1,26 @@
+package util
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// NewSecretToken returns a random token to hand to a user, and the hash to
+// store in its place
+func NewSecretToken() (token, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token = base64.RawURLEncoding.EncodeToString(b)
+	return token, HashSecretToken(token), nil
+}
+
+// HashSecretToken returns the hash a token from NewSecretToken is stored as.
+// The tokens are random, so a fast unsalted hash is enough.
+func HashSecretToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,47 @@
+-- name: ListApprovalSteps :many
+SELECT * FROM approval_steps WHERE ticket_type = $1 ORDER BY position;
+
+-- name: ListApprovalStepTypes :many
+SELECT DISTINCT ticket_type FROM approval_steps ORDER BY ticket_type;
+
+-- name: ReplaceApprovalSteps :exec
+WITH removed AS (
+    DELETE FROM approval_steps WHERE ticket_type = @ticket_type
+)
+INSERT INTO approval_steps (ticket_type, position, approver_id, approver_role)
+SELECT @ticket_type, s.position, NULLIF(s.approver_id, '00000000-0000-0000-0000-000000000000'::uuid), NULLIF(s.approver_role, '')
+FROM unnest(@approver_ids::uuid[], @approver_roles::text[]) WITH ORDINALITY AS s(approver_id, approver_role, position);
+
+-- name: CreateTicketApprovals :exec
+INSERT INTO ticket_approvals (ticket_id, position, approver_id, approver_role)
+SELECT $1, position, approver_id, approver_role
+FROM approval_steps
+WHERE ticket_type = $2;
+
+-- name: ListTicketApprovals :many
+SELECT * FROM ticket_approvals WHERE ticket_id = @ticket_id AND (sqlc.narg('org_ids')::uuid[] IS NULL OR EXISTS (SELECT 1 FROM tickets t WHERE t.id = ticket_approvals.ticket_id AND t.org_id = ANY(sqlc.narg('org_ids')::uuid[]))) ORDER BY position;
+
+-- name: DecideTicketApproval :one
+UPDATE ticket_approvals
+SET
+    status = @status,
+    decided_by = @decided_by,
+    comment = @comment,
+    decided_at = @decided_at
+WHERE id = @id AND status = 'pending' AND (sqlc.narg('org_ids')::uuid[] IS NULL OR EXISTS (SELECT 1 FROM tickets t WHERE t.id = ticket_approvals.ticket_id AND t.org_id = ANY(sqlc.narg('org_ids')::uuid[])))
+RETURNING *;
+
+-- name: ListPendingApprovals :many
+SELECT a.* FROM ticket_approvals a
+WHERE a.status = 'pending'
+  AND (a.approver_id = @approver_id OR a.approver_role = @approver_role)
+  AND NOT EXISTS (
+    SELECT 1 FROM ticket_approvals p
+    WHERE p.ticket_id = a.ticket_id AND p.position < a.position AND p.status <> 'approved'
+  )
+  AND EXISTS (
+    SELECT 1 FROM tickets t
+    WHERE t.id = a.ticket_id AND t.state = 1
+      AND (sqlc.narg('org_ids')::uuid[] IS NULL OR t.org_id = ANY(sqlc.narg('org_ids')::uuid[]))
+  )
+ORDER BY a.created_at;
@@ -0,0 +1,37 @@
+
+-- name: GetAgentAvailability :one
+SELECT * FROM agent_availability WHERE user_id = @user_id AND (sqlc.narg('org_ids')::uuid[] IS NULL OR EXISTS (SELECT 1 FROM users u WHERE u.id = agent_availability.user_id AND (u.org_id = ANY(sqlc.narg('org_ids')::uuid[]) OR EXISTS (SELECT 1 FROM user_org_access a WHERE a.user_id = u.id AND a.org_id = ANY(sqlc.narg('org_ids')::uuid[]))))) LIMIT 1;
+
+-- name: UpsertAgentAvailability :one
+INSERT INTO agent_availability (user_id, status, max_open_tickets, updated_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (user_id) DO UPDATE
+SET status = EXCLUDED.status, max_open_tickets = EXCLUDED.max_open_tickets, updated_at = EXCLUDED.updated_at
+RETURNING *;
+
+-- name: CountOpenAssignedTickets :one
+SELECT COUNT(*) FROM tickets WHERE assigned_to @> ARRAY[@user_id::uuid] AND state IN (1, 2);
+
+-- name: CreateOutOfOffice :one
+INSERT INTO out_of_office (user_id, starts_at, ends_at, delegate_id, note)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: ListOutOfOffice :many
+SELECT * FROM out_of_office
+WHERE user_id = @user_id AND ends_at > @since AND (sqlc.narg('org_ids')::uuid[] IS NULL OR EXISTS (SELECT 1 FROM users u WHERE u.id = out_of_office.user_id AND (u.org_id = ANY(sqlc.narg('org_ids')::uuid[]) OR EXISTS (SELECT 1 FROM user_org_access a WHERE a.user_id = u.id AND a.org_id = ANY(sqlc.narg('org_ids')::uuid[])))))
+ORDER BY starts_at;
+
+-- name: GetActiveOutOfOffice :one
+SELECT * FROM out_of_office
+WHERE user_id = @user_id AND starts_at <= @at AND ends_at > @at AND (sqlc.narg('org_ids')::uuid[] IS NULL OR EXISTS (SELECT 1 FROM users u WHERE u.id = out_of_office.user_id AND (u.org_id = ANY(sqlc.narg('org_ids')::uuid[]) OR EXISTS (SELECT 1 FROM user_org_access a WHERE a.user_id = u.id AND a.org_id = ANY(sqlc.narg('org_ids')::uuid[])))))
+ORDER BY starts_at DESC
+LIMIT 1;
+
+-- name: ListActiveOutOfOffice :many
+SELECT * FROM out_of_office
+WHERE starts_at <= @at AND ends_at > @at AND delegate_id IS NOT NULL AND (sqlc.narg('org_ids')::uuid[] IS NULL OR EXISTS (SELECT 1 FROM users u WHERE u.id = out_of_office.user_id AND (u.org_id = ANY(sqlc.narg('org_ids')::uuid[]) OR EXISTS (SELECT 1 FROM user_org_access a WHERE a.user_id = u.id AND a.org_id = ANY(sqlc.narg('org_ids')::uuid[])))))
+ORDER BY user_id;
+
+-- name: DeleteOutOfOffice :exec
+DELETE FROM out_of_office WHERE id = @id AND user_id = @user_id AND (sqlc.narg('org_ids')::uuid[] IS NULL OR EXISTS (SELECT 1 FROM users u WHERE u.id = out_of_office.user_id AND (u.org_id = ANY(sqlc.narg('org_ids')::uuid[]) OR EXISTS (SELECT 1 FROM user_org_access a WHERE a.user_id = u.id AND a.org_id = ANY(sqlc.narg('org_ids')::uuid[])))));
@@ -0,0 +1,39 @@
+
+-- name: CreateCalendar :one
+INSERT INTO business_calendars (org_id, name, time_zone, updated_at) VALUES ($1, $2, $3, $4) RETURNING *;
+
+-- name: GetCalendar :one
+SELECT * FROM business_calendars WHERE id = @id AND (sqlc.narg('org_ids')::uuid[] IS NULL OR org_id = ANY(sqlc.narg('org_ids')::uuid[])) LIMIT 1;
+
+-- name: ListCalendars :many
+SELECT * FROM business_calendars WHERE (sqlc.narg('org_ids')::uuid[] IS NULL OR org_id = ANY(sqlc.narg('org_ids')::uuid[])) ORDER BY name;
+
+-- name: UpdateCalendar :one
+UPDATE business_calendars
+SET
+    name = @name,
+    time_zone = @time_zone,
+    updated_at = @updated_at
+WHERE id = @id AND (sqlc.narg('org_ids')::uuid[] IS NULL OR org_id = ANY(sqlc.narg('org_ids')::uuid[]))
+RETURNING *;
+
+-- name: DeleteCalendar :exec
+DELETE FROM business_calendars WHERE id = @id AND (sqlc.narg('org_ids')::uuid[] IS NULL OR org_id = ANY(sqlc.narg('org_ids')::uuid[]));
+
+-- name: ListCalendarHours :many
+SELECT * FROM calendar_hours WHERE calendar_id = $1 ORDER BY weekday, start_minute;
+
+-- name: AddCalendarHours :exec
+INSERT INTO calendar_hours (calendar_id, weekday, start_minute, end_minute) VALUES ($1, $2, $3, $4);
+
+-- name: DeleteCalendarHours :exec
+DELETE FROM calendar_hours WHERE calendar_id = $1;
+
+-- name: ListCalendarHolidays :many
+SELECT * FROM calendar_holidays WHERE calendar_id = $1 ORDER BY day;
+
+-- name: AddCalendarHoliday :exec
+INSERT INTO calendar_holidays (calendar_id, day, name) VALUES ($1, $2, $3);
+
+-- name: DeleteCalendarHolidays :exec
+DELETE FROM calendar_holidays WHERE calendar_id = $1;
@@ -0,0 +1,39 @@
+-- name: CreateChecklistItem :one
+INSERT INTO checklist_items (ticket_id, position, text, required, assignee_id, due_at, updated_at)
+VALUES ($1, (SELECT COALESCE(MAX(position), 0) + 1 FROM checklist_items WHERE ticket_id = $1), $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetChecklistItem :one
+SELECT * FROM checklist_items WHERE id = @id AND (sqlc.narg('org_ids')::uuid[] IS NULL OR EXISTS (SELECT 1 FROM tickets t WHERE t.id = checklist_items.ticket_id AND t.org_id = ANY(sqlc.narg('org_ids')::uuid[]))) LIMIT 1;
+
+-- name: ListChecklistItems :many
+SELECT * FROM checklist_items WHERE ticket_id = @ticket_id AND (sqlc.narg('org_ids')::uuid[] IS NULL OR EXISTS (SELECT 1 FROM tickets t WHERE t.id = checklist_items.ticket_id AND t.org_id = ANY(sqlc.narg('org_ids')::uuid[]))) ORDER BY position;
+
+-- name: UpdateChecklistItem :one
+UPDATE checklist_items
+SET
+    text = @text,
+    done = @done,
+    required = @required,
+    assignee_id = @assignee_id,
+    due_at = @due_at,
+    updated_at = @updated_at
+WHERE id = @id AND (sqlc.narg('org_ids')::uuid[] IS NULL OR EXISTS (SELECT 1 FROM tickets t WHERE t.id = checklist_items.ticket_id AND t.org_id = ANY(sqlc.narg('org_ids')::uuid[])))
+RETURNING *;
+
+-- name: DeleteChecklistItem :exec
+DELETE FROM checklist_items WHERE id = @id AND (sqlc.narg('org_ids')::uuid[] IS NULL OR EXISTS (SELECT 1 FROM tickets t WHERE t.id = checklist_items.ticket_id AND t.org_id = ANY(sqlc.narg('org_ids')::uuid[])));
+
+-- name: ReorderChecklistItems :exec
+UPDATE checklist_items c
+SET position = o.position
+FROM unnest(@ids::uuid[]) WITH ORDINALITY AS o(id, position)
+WHERE c.id = o.id AND c.ticket_id = @ticket_id AND (sqlc.narg('org_ids')::uuid[] IS NULL OR EXISTS (SELECT 1 FROM tickets t WHERE t.id = c.ticket_id AND t.org_id = ANY(sqlc.narg('org_ids')::uuid[])));
+
+-- name: GetChecklistProgress :one
+SELECT
+    COUNT(*)::bigint AS total,
+    COUNT(*) FILTER (WHERE done)::bigint AS done,
+    COUNT(*) FILTER (WHERE required AND NOT done)::bigint AS required_open
+FROM checklist_items
+WHERE ticket_id = @ticket_id AND (sqlc.narg('org_ids')::uuid[] IS NULL OR EXISTS (SELECT 1 FROM tickets t WHERE t.id = checklist_items.ticket_id AND t.org_id = ANY(sqlc.narg('org_ids')::uuid[])));
@@ -1,11 +1,40 @@
 -- name: CreateComment :one
-INSERT INTO comments (description, ticket_id, created_by, updated_at ) VALUES ($1, $2, $3, $4) RETURNING *;
+INSERT INTO comments (description, ticket_id, created_by, updated_at, visibility, parent_id, thread_id, depth) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING *;
 
 -- name: GetComment :one
-SELECT * FROM comments WHERE id = $1 LIMIT 1;
+SELECT * FROM comments WHERE id = @id AND deleted_at IS NULL AND (sqlc.narg('org_ids')::uuid[] IS NULL OR EXISTS (SELECT 1 FROM tickets t WHERE t.id = comments.ticket_id AND t.org_id = ANY(sqlc.narg('org_ids')::uuid[]))) LIMIT 1;
 
 -- name: ListComment :many
-SELECT * FROM comments WHERE ticket_id=$1 ORDER BY created_at LIMIT $2 OFFSET $3;
+SELECT * FROM comments WHERE ticket_id = @ticket_id AND parent_id IS NULL AND (deleted_at IS NULL OR EXISTS (SELECT 1 FROM comments r WHERE r.thread_id = comments.id AND r.deleted_at IS NULL)) AND (sqlc.narg('org_ids')::uuid[] IS NULL OR EXISTS (SELECT 1 FROM tickets t WHERE t.id = comments.ticket_id AND t.org_id = ANY(sqlc.narg('org_ids')::uuid[]))) AND (sqlc.narg('visibility')::varchar IS NULL OR visibility = sqlc.narg('visibility')::varchar) ORDER BY created_at LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
 
 -- name: DeleteComment :exec
-DELETE FROM comments WHERE id = $1;
\ No newline at end of file
+WITH revision AS (
+    INSERT INTO comment_revisions (comment_id, description, edited_by)
+    SELECT id, description, @deleted_by FROM comments WHERE id = @id AND deleted_at IS NULL AND (sqlc.narg('org_ids')::uuid[] IS NULL OR EXISTS (SELECT 1 FROM tickets t WHERE t.id = comments.ticket_id AND t.org_id = ANY(sqlc.narg('org_ids')::uuid[])))
+)
+UPDATE comments SET deleted_at = now() WHERE id = @id AND deleted_at IS NULL AND (sqlc.narg('org_ids')::uuid[] IS NULL OR EXISTS (SELECT 1 FROM tickets t WHERE t.id = comments.ticket_id AND t.org_id = ANY(sqlc.narg('org_ids')::uuid[])));
+
+-- name: UpdateComment :one
+WITH revision AS (
+    INSERT INTO comment_revisions (comment_id, description, edited_by)
+    SELECT id, description, @edited_by FROM comments WHERE id = @id AND deleted_at IS NULL AND (sqlc.narg('org_ids')::uuid[] IS NULL OR EXISTS (SELECT 1 FROM tickets t WHERE t.id = comments.ticket_id AND t.org_id = ANY(sqlc.narg('org_ids')::uuid[])))
+)
+UPDATE comments
+SET
+    description = @description,
+    edited_at = @edited_at,
+    updated_at = @edited_at
+WHERE id = @id AND deleted_at IS NULL AND (sqlc.narg('org_ids')::uuid[] IS NULL OR EXISTS (SELECT 1 FROM tickets t WHERE t.id = comments.ticket_id AND t.org_id = ANY(sqlc.narg('org_ids')::uuid[])))
+RETURNING *;
+
+-- name: ListCommentRevisions :many
+SELECT r.* FROM comment_revisions r JOIN comments c ON c.id = r.comment_id WHERE r.comment_id = @comment_id AND (sqlc.narg('org_ids')::uuid[] IS NULL OR EXISTS (SELECT 1 FROM tickets t WHERE t.id = c.ticket_id AND t.org_id = ANY(sqlc.narg('org_ids')::uuid[]))) ORDER BY r.created_at;
+
+-- name: ListCommentReplies :many
+SELECT * FROM comments WHERE thread_id = ANY(@thread_ids::uuid[]) AND (deleted_at IS NULL OR @include_deleted::bool) AND (sqlc.narg('org_ids')::uuid[] IS NULL OR EXISTS (SELECT 1 FROM tickets t WHERE t.id = comments.ticket_id AND t.org_id = ANY(sqlc.narg('org_ids')::uuid[]))) AND (sqlc.narg('visibility')::varchar IS NULL OR visibility = sqlc.narg('visibility')::varchar) ORDER BY created_at LIMIT sqlc.narg('limit') OFFSET sqlc.arg('offset');
+
+-- name: DeleteTicketCommentRevisions :exec
+DELETE FROM comment_revisions WHERE comment_id IN (SELECT c.id FROM comments c JOIN tickets t ON t.id = c.ticket_id WHERE c.ticket_id = @ticket_id AND (sqlc.narg('org_ids')::uuid[] IS NULL OR t.org_id = ANY(sqlc.narg('org_ids')::uuid[])));
+
+-- name: GetCommentIncludingDeleted :one
+SELECT * FROM comments WHERE id = @id AND (sqlc.narg('org_ids')::uuid[] IS NULL OR EXISTS (SELECT 1 FROM tickets t WHERE t.id = comments.ticket_id AND t.org_id = ANY(sqlc.narg('org_ids')::uuid[]))) LIMIT 1;
@@ -0,0 +1,12 @@
+-- name: AddCommentReaction :exec
+INSERT INTO comment_reactions (comment_id, user_id, emoji) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING;
+
+-- name: DeleteCommentReaction :exec
+DELETE FROM comment_reactions WHERE comment_id = $1 AND user_id = $2 AND emoji = $3;
+
+-- name: ListCommentReactions :many
+SELECT comment_id, emoji, count(*)::int AS count, bool_or(user_id = @user_id)::bool AS reacted
+FROM comment_reactions
+WHERE comment_id = ANY(@comment_ids::uuid[]) AND (sqlc.narg('org_ids')::uuid[] IS NULL OR EXISTS (SELECT 1 FROM comments c JOIN tickets t ON t.id = c.ticket_id WHERE c.id = comment_reactions.comment_id AND t.org_id = ANY(sqlc.narg('org_ids')::uuid[])))
+GROUP BY comment_id, emoji
+ORDER BY comment_id, min(created_at);
@@ -0,0 +1,31 @@
+-- name: UpsertCSATResponse :one
+INSERT INTO csat_responses (ticket_id, assigned_to, rating, comment, updated_at) VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (ticket_id) DO UPDATE
+SET
+    rating = EXCLUDED.rating,
+    comment = CASE WHEN EXCLUDED.comment = '' THEN csat_responses.comment ELSE EXCLUDED.comment END,
+    updated_at = EXCLUDED.updated_at
+RETURNING *;
+
+-- name: GetCSATResponseByTicket :one
+SELECT * FROM csat_responses WHERE ticket_id = @ticket_id AND (sqlc.narg('org_ids')::uuid[] IS NULL OR EXISTS (SELECT 1 FROM tickets t WHERE t.id = csat_responses.ticket_id AND t.org_id = ANY(sqlc.narg('org_ids')::uuid[]))) LIMIT 1;
+
+-- name: GetCSATSummary :one
+SELECT
+    COUNT(*)::bigint AS responses,
+    COALESCE(AVG(rating), 0)::float8 AS average_rating,
+    COUNT(*) FILTER (WHERE rating >= 4)::bigint AS satisfied
+FROM csat_responses
+WHERE (sqlc.narg('org_ids')::uuid[] IS NULL OR EXISTS (SELECT 1 FROM tickets t WHERE t.id = csat_responses.ticket_id AND t.org_id = ANY(sqlc.narg('org_ids')::uuid[])));
+
+-- name: ListCSATByAssignee :many
+SELECT
+    a.assignee_id::uuid AS assignee_id,
+    COUNT(*)::bigint AS responses,
+    AVG(c.rating)::float8 AS average_rating,
+    COUNT(*) FILTER (WHERE c.rating >= 4)::bigint AS satisfied
+FROM csat_responses c
+CROSS JOIN LATERAL unnest(c.assigned_to) AS a(assignee_id)
+WHERE (sqlc.narg('org_ids')::uuid[] IS NULL OR EXISTS (SELECT 1 FROM tickets t WHERE t.id = c.ticket_id AND t.org_id = ANY(sqlc.narg('org_ids')::uuid[])))
+GROUP BY a.assignee_id
+ORDER BY average_rating DESC;
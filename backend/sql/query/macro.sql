@@ -0,0 +1,34 @@
+-- name: CreateMacro :one
+INSERT INTO macros (
+    org_id, owner_id, created_by, name, reply, visibility, state, resolution_code, priority, assigned_to, labels, updated_at
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+RETURNING *;
+
+-- name: GetMacro :one
+SELECT * FROM macros WHERE id = @id AND (sqlc.narg('org_ids')::uuid[] IS NULL OR org_id = ANY(sqlc.narg('org_ids')::uuid[])) LIMIT 1;
+
+-- name: ListMacros :many
+SELECT * FROM macros
+WHERE (owner_id = @owner_id OR (owner_id IS NULL AND org_id = @org_id))
+  AND (sqlc.narg('org_ids')::uuid[] IS NULL OR org_id = ANY(sqlc.narg('org_ids')::uuid[]))
+ORDER BY name, id;
+
+-- name: UpdateMacro :one
+UPDATE macros
+SET
+    owner_id = @owner_id,
+    name = @name,
+    reply = @reply,
+    visibility = @visibility,
+    state = @state,
+    resolution_code = @resolution_code,
+    priority = @priority,
+    assigned_to = @assigned_to,
+    labels = @labels,
+    updated_at = @updated_at
+WHERE id = @id AND (sqlc.narg('org_ids')::uuid[] IS NULL OR org_id = ANY(sqlc.narg('org_ids')::uuid[]))
+RETURNING *;
+
+-- name: DeleteMacro :exec
+DELETE FROM macros WHERE id = @id AND (sqlc.narg('org_ids')::uuid[] IS NULL OR org_id = ANY(sqlc.narg('org_ids')::uuid[]));
@@ -0,0 +1,12 @@
+
+-- name: CreateMentions :many
+INSERT INTO mentions (ticket_id, comment_id, user_id, created_by)
+SELECT @ticket_id::uuid, sqlc.narg('comment_id')::uuid, unnest(@user_ids::uuid[]), sqlc.narg('created_by')::uuid
+ON CONFLICT DO NOTHING
+RETURNING *;
+
+-- name: ListMentionsByUser :many
+SELECT * FROM mentions
+WHERE user_id = @user_id AND (sqlc.narg('org_ids')::uuid[] IS NULL OR EXISTS (SELECT 1 FROM tickets t WHERE t.id = mentions.ticket_id AND t.org_id = ANY(sqlc.narg('org_ids')::uuid[])))
+ORDER BY created_at DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
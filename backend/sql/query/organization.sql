@@ -0,0 +1,23 @@
+-- name: CreateOrganization :one
+INSERT INTO organizations (name, email_domain) VALUES ($1, $2) RETURNING *;
+
+-- name: GetOrganization :one
+SELECT * FROM organizations WHERE id = $1 LIMIT 1;
+
+-- name: GetOrganizationByDomain :one
+SELECT * FROM organizations WHERE email_domain = $1 LIMIT 1;
+
+-- name: ListOrganizations :many
+SELECT * FROM organizations ORDER BY name;
+
+-- name: GrantOrgAccess :exec
+INSERT INTO user_org_access (user_id, org_id) VALUES ($1, $2) ON CONFLICT DO NOTHING;
+
+-- name: RevokeOrgAccess :exec
+DELETE FROM user_org_access WHERE user_id = $1 AND org_id = $2;
+
+-- name: ListUserOrgAccess :many
+SELECT org_id FROM user_org_access WHERE user_id = $1 ORDER BY org_id;
+
+-- name: SetOrganizationCalendar :one
+UPDATE organizations SET calendar_id = sqlc.narg('calendar_id') WHERE id = @id RETURNING *;
@@ -0,0 +1,15 @@
+-- name: ListPriorityMatrix :many
+SELECT * FROM priority_matrix ORDER BY impact, urgency;
+
+-- name: UpsertPriorityMatrixEntry :exec
+INSERT INTO priority_matrix (impact, urgency, priority, updated_at) VALUES ($1, $2, $3, now())
+ON CONFLICT (impact, urgency) DO UPDATE SET priority = EXCLUDED.priority, updated_at = EXCLUDED.updated_at;
+
+-- name: ReapplyPriorityMatrix :exec
+UPDATE tickets t
+SET priority = m.priority
+FROM priority_matrix m
+WHERE t.impact = m.impact
+  AND t.urgency = m.urgency
+  AND NOT t.priority_overridden
+  AND t.state IN (1, 2);
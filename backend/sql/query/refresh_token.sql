@@ -0,0 +1,29 @@
+
+-- name: CreateRefreshToken :one
+INSERT INTO refresh_tokens (
+    user_id, family_id, token_hash, expires_at
+)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetRefreshTokenByHash :one
+SELECT * FROM refresh_tokens WHERE token_hash = @token_hash LIMIT 1;
+
+-- name: UseRefreshToken :one
+UPDATE refresh_tokens
+SET used_at = now()
+WHERE id = @id AND used_at IS NULL AND revoked_at IS NULL
+RETURNING *;
+
+-- name: RevokeRefreshTokenFamily :exec
+UPDATE refresh_tokens
+SET revoked_at = now()
+WHERE family_id = @family_id AND revoked_at IS NULL;
+
+-- name: RevokeUserRefreshTokens :exec
+UPDATE refresh_tokens
+SET revoked_at = now()
+WHERE user_id = @user_id AND revoked_at IS NULL;
+
+-- name: DeleteExpiredRefreshTokens :exec
+DELETE FROM refresh_tokens WHERE expires_at < now();
@@ -0,0 +1,39 @@
+-- name: CreateSavedView :one
+INSERT INTO saved_views (
+    owner_id, name, states, priorities, assignee_id, assigned_to_me, unassigned,
+    creator_id, created_by_me, search, sort, columns, shared_role, updated_at, org_id, shared_team_id
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+RETURNING *;
+
+-- name: GetSavedView :one
+SELECT * FROM saved_views WHERE id = @id AND (sqlc.narg('org_ids')::uuid[] IS NULL OR org_id = ANY(sqlc.narg('org_ids')::uuid[])) LIMIT 1;
+
+-- name: ListSavedViews :many
+SELECT * FROM saved_views
+WHERE (owner_id = @owner_id OR (shared_role = @shared_role AND org_id = @org_id) OR shared_team_id = ANY(@team_ids::uuid[]))
+  AND (sqlc.narg('org_ids')::uuid[] IS NULL OR org_id = ANY(sqlc.narg('org_ids')::uuid[]))
+ORDER BY name, id;
+
+-- name: UpdateSavedView :one
+UPDATE saved_views
+SET
+    name = @name,
+    states = @states,
+    priorities = @priorities,
+    assignee_id = @assignee_id,
+    assigned_to_me = @assigned_to_me,
+    unassigned = @unassigned,
+    creator_id = @creator_id,
+    created_by_me = @created_by_me,
+    search = @search,
+    sort = @sort,
+    columns = @columns,
+    shared_role = @shared_role,
+    shared_team_id = @shared_team_id,
+    updated_at = @updated_at
+WHERE id = @id AND (sqlc.narg('org_ids')::uuid[] IS NULL OR org_id = ANY(sqlc.narg('org_ids')::uuid[]))
+RETURNING *;
+
+-- name: DeleteSavedView :exec
+DELETE FROM saved_views WHERE id = @id AND (sqlc.narg('org_ids')::uuid[] IS NULL OR org_id = ANY(sqlc.narg('org_ids')::uuid[]));
@@ -0,0 +1,35 @@
+-- name: CreateTeam :one
+INSERT INTO teams (org_id, name, lead_id, updated_at) VALUES ($1, $2, $3, $4) RETURNING *;
+
+-- name: GetTeam :one
+SELECT * FROM teams WHERE id = @id AND (sqlc.narg('org_ids')::uuid[] IS NULL OR org_id = ANY(sqlc.narg('org_ids')::uuid[])) LIMIT 1;
+
+-- name: ListTeams :many
+SELECT * FROM teams WHERE (sqlc.narg('org_ids')::uuid[] IS NULL OR org_id = ANY(sqlc.narg('org_ids')::uuid[])) ORDER BY name;
+
+-- name: UpdateTeam :one
+UPDATE teams
+SET
+    name = @name,
+    lead_id = sqlc.narg('lead_id'),
+    updated_at = @updated_at
+WHERE id = @id AND (sqlc.narg('org_ids')::uuid[] IS NULL OR org_id = ANY(sqlc.narg('org_ids')::uuid[]))
+RETURNING *;
+
+-- name: DeleteTeam :exec
+DELETE FROM teams WHERE id = @id AND (sqlc.narg('org_ids')::uuid[] IS NULL OR org_id = ANY(sqlc.narg('org_ids')::uuid[]));
+
+-- name: AddTeamMember :exec
+INSERT INTO team_members (team_id, user_id) VALUES ($1, $2) ON CONFLICT DO NOTHING;
+
+-- name: RemoveTeamMember :exec
+DELETE FROM team_members WHERE team_id = @team_id AND user_id = @user_id AND (sqlc.narg('org_ids')::uuid[] IS NULL OR EXISTS (SELECT 1 FROM teams t WHERE t.id = team_members.team_id AND t.org_id = ANY(sqlc.narg('org_ids')::uuid[])));
+
+-- name: ListTeamMembers :many
+SELECT user_id FROM team_members WHERE team_id = @team_id AND (sqlc.narg('org_ids')::uuid[] IS NULL OR EXISTS (SELECT 1 FROM teams t WHERE t.id = team_members.team_id AND t.org_id = ANY(sqlc.narg('org_ids')::uuid[]))) ORDER BY created_at;
+
+-- name: ListUserTeams :many
+SELECT team_id FROM team_members WHERE user_id = $1 ORDER BY team_id;
+
+-- name: SetTeamCalendar :one
+UPDATE teams SET calendar_id = sqlc.narg('calendar_id'), updated_at = @updated_at WHERE id = @id AND (sqlc.narg('org_ids')::uuid[] IS NULL OR org_id = ANY(sqlc.narg('org_ids')::uuid[])) RETURNING *;
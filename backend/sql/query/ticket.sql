@@ -1,39 +1,122 @@
 -- name: CreateTicket :one
-INSERT INTO tickets (title, description, created_by, updated_at ) VALUES ($1, $2, $3, $4) RETURNING *;
+INSERT INTO tickets (title, description, created_by, updated_at, impact, urgency, priority, rank, type, org_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, (SELECT org_id FROM users WHERE id = $3))
+RETURNING *;
 
 -- name: GetTicket :one
-SELECT * FROM tickets WHERE id = $1 LIMIT 1;
+SELECT * FROM tickets WHERE id = @id AND (sqlc.narg('org_ids')::uuid[] IS NULL OR org_id = ANY(sqlc.narg('org_ids')::uuid[])) LIMIT 1;
 
 -- name: ListTickets :many
-SELECT * FROM tickets WHERE created_by=$1 ORDER BY id LIMIT $2 OFFSET $3;
+SELECT * FROM tickets WHERE created_by = @created_by AND (sqlc.narg('org_ids')::uuid[] IS NULL OR org_id = ANY(sqlc.narg('org_ids')::uuid[])) ORDER BY id LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
 
 -- name: ListAllTickets :many
-SELECT * FROM tickets ORDER BY id LIMIT $1 OFFSET $2;
+SELECT * FROM tickets WHERE (sqlc.narg('org_ids')::uuid[] IS NULL OR org_id = ANY(sqlc.narg('org_ids')::uuid[])) ORDER BY id LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
 
 -- name: ListTicketsAssigned :many
-SELECT * FROM tickets WHERE assigned_to @> ARRAY[$1]::uuid[] ORDER BY id LIMIT $2 OFFSET $3;
+SELECT * FROM tickets WHERE assigned_to @> ARRAY[@assignee::uuid] AND (sqlc.narg('org_ids')::uuid[] IS NULL OR org_id = ANY(sqlc.narg('org_ids')::uuid[])) ORDER BY id LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
 
 -- name: DeleteTicket :exec
-DELETE FROM tickets WHERE id = $1;
+DELETE FROM tickets WHERE id = @id AND (sqlc.narg('org_ids')::uuid[] IS NULL OR org_id = ANY(sqlc.narg('org_ids')::uuid[]));
 
 -- name: GetTicketsByCreator :many
 SELECT * FROM tickets
-WHERE created_by = $1
+WHERE created_by = @created_by AND (sqlc.narg('org_ids')::uuid[] IS NULL OR org_id = ANY(sqlc.narg('org_ids')::uuid[]))
 ORDER BY created_at DESC;
 
 -- name: GetTicketsByAssignee :many
 SELECT * FROM tickets
-WHERE assigned_to @> ARRAY[$1]::uuid[]
+WHERE assigned_to @> ARRAY[@assignee::uuid] AND (sqlc.narg('org_ids')::uuid[] IS NULL OR org_id = ANY(sqlc.narg('org_ids')::uuid[]))
 ORDER BY created_at DESC;
 
 -- name: UpdateTicket :one
 UPDATE tickets
-SET 
-    title = $2,
-    description = $3,
-    state = $4,
-    priority = $5,
-    assigned_to = $6,
-    updated_at = $7
-WHERE id = $1
+SET
+    title = @title,
+    description = @description,
+    state = @state,
+    priority = @priority,
+    assigned_to = @assigned_to,
+    updated_at = @updated_at,
+    resolution_code = @resolution_code,
+    state_reason = @state_reason,
+    impact = @impact,
+    urgency = @urgency,
+    priority_overridden = @priority_overridden,
+    rank = @rank,
+    team_id = sqlc.narg('team_id'),
+    labels = @labels
+WHERE id = @id AND (sqlc.narg('org_ids')::uuid[] IS NULL OR org_id = ANY(sqlc.narg('org_ids')::uuid[]))
 RETURNING *;
+
+-- name: CountTicketsByResolutionCode :many
+SELECT resolution_code, COUNT(*)::bigint AS tickets
+FROM tickets
+WHERE resolution_code <> '' AND (sqlc.narg('org_ids')::uuid[] IS NULL OR org_id = ANY(sqlc.narg('org_ids')::uuid[]))
+GROUP BY resolution_code
+ORDER BY tickets DESC;
+
+-- name: FindSimilarTickets :many
+SELECT sqlc.embed(tickets),
+    GREATEST(similarity(title, @title::text), similarity(description, @description::text))::float8 AS score
+FROM tickets
+WHERE state IN (1, 2)
+  AND (title % @title::text OR description % @description::text)
+  AND (sqlc.narg('org_ids')::uuid[] IS NULL OR org_id = ANY(sqlc.narg('org_ids')::uuid[]))
+ORDER BY score DESC
+LIMIT @max_results;
+
+-- name: ListFilteredTickets :many
+SELECT * FROM tickets
+WHERE (sqlc.narg('scope_creator')::uuid IS NULL OR created_by = sqlc.narg('scope_creator'))
+  AND (sqlc.narg('scope_assignee')::uuid IS NULL OR assigned_to @> ARRAY[sqlc.narg('scope_assignee')::uuid] OR team_id = ANY(sqlc.narg('scope_teams')::uuid[]))
+  AND (sqlc.narg('org_ids')::uuid[] IS NULL OR org_id = ANY(sqlc.narg('org_ids')::uuid[]))
+  AND (cardinality(@states::int[]) = 0 OR state = ANY(@states::int[]))
+  AND (cardinality(@priorities::int[]) = 0 OR priority = ANY(@priorities::int[]))
+  AND (sqlc.narg('assignee')::uuid IS NULL OR assigned_to @> ARRAY[sqlc.narg('assignee')::uuid])
+  AND (NOT @unassigned::bool OR cardinality(COALESCE(assigned_to, '{}')) = 0)
+  AND (sqlc.narg('creator')::uuid IS NULL OR created_by = sqlc.narg('creator'))
+  AND (@search::text = '' OR title ILIKE '%' || @search::text || '%' OR description ILIKE '%' || @search::text || '%')
+ORDER BY
+  CASE WHEN @sort::text = 'priority' THEN priority END ASC,
+  CASE WHEN @sort::text = '-priority' THEN priority END DESC,
+  CASE WHEN @sort::text = 'created_at' THEN created_at END ASC,
+  CASE WHEN @sort::text = '-created_at' THEN created_at END DESC,
+  CASE WHEN @sort::text = 'updated_at' THEN updated_at END ASC,
+  CASE WHEN @sort::text = '-updated_at' THEN updated_at END DESC,
+  CASE WHEN @sort::text = 'rank' THEN rank END ASC,
+  id
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: GetLastTicketRank :one
+SELECT COALESCE(MAX(rank), '')::text FROM tickets WHERE state = @state AND (sqlc.narg('org_ids')::uuid[] IS NULL OR org_id = ANY(sqlc.narg('org_ids')::uuid[]));
+
+-- name: ListTeamTickets :many
+SELECT * FROM tickets
+WHERE team_id = ANY(@team_ids::uuid[]) AND (sqlc.narg('org_ids')::uuid[] IS NULL OR org_id = ANY(sqlc.narg('org_ids')::uuid[]))
+ORDER BY priority, created_at
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: ForwardAssignedTickets :many
+UPDATE tickets
+SET
+    assigned_to = CASE WHEN @delegate_id::uuid = ANY(assigned_to) THEN array_remove(assigned_to, @user_id::uuid)
+                       ELSE array_replace(assigned_to, @user_id::uuid, @delegate_id::uuid) END,
+    updated_at = @updated_at
+WHERE assigned_to @> ARRAY[@user_id::uuid] AND state IN (1, 2) AND (sqlc.narg('org_ids')::uuid[] IS NULL OR org_id = ANY(sqlc.narg('org_ids')::uuid[]))
+RETURNING id;
+
+-- name: HandOffTicket :one
+UPDATE tickets
+SET
+    assigned_to = CASE WHEN @to_user_id::uuid = ANY(assigned_to) THEN array_remove(assigned_to, @from_user_id::uuid)
+                       WHEN @from_user_id::uuid = ANY(assigned_to) THEN array_replace(assigned_to, @from_user_id::uuid, @to_user_id::uuid)
+                       ELSE array_append(assigned_to, @to_user_id::uuid) END,
+    team_id = COALESCE(sqlc.narg('team_id'), team_id),
+    updated_at = @updated_at
+WHERE id = @id AND (sqlc.narg('org_ids')::uuid[] IS NULL OR org_id = ANY(sqlc.narg('org_ids')::uuid[]))
+RETURNING *;
+
+-- name: AddTicketReaders :exec
+UPDATE tickets
+SET readers = ARRAY(SELECT DISTINCT unnest(readers || @user_ids::uuid[]))
+WHERE id = @id AND (sqlc.narg('org_ids')::uuid[] IS NULL OR org_id = ANY(sqlc.narg('org_ids')::uuid[]));
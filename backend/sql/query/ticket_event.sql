@@ -0,0 +1,5 @@
+-- name: CreateTicketEvent :one
+INSERT INTO ticket_events (ticket_id, actor_id, kind, old_value, new_value, reason, note) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING *;
+
+-- name: ListTicketEvents :many
+SELECT * FROM ticket_events WHERE ticket_id = @ticket_id AND (sqlc.narg('org_ids')::uuid[] IS NULL OR EXISTS (SELECT 1 FROM tickets t WHERE t.id = ticket_events.ticket_id AND t.org_id = ANY(sqlc.narg('org_ids')::uuid[]))) ORDER BY created_at LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
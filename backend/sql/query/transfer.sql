@@ -0,0 +1,29 @@
+
+-- name: CreateTicketTransfer :one
+INSERT INTO ticket_transfers (ticket_id, from_user_id, to_user_id, to_team_id, note) VALUES ($1, $2, $3, $4, $5) RETURNING *;
+
+-- name: GetTicketTransfer :one
+SELECT * FROM ticket_transfers WHERE id = @id AND (sqlc.narg('org_ids')::uuid[] IS NULL OR EXISTS (SELECT 1 FROM tickets t WHERE t.id = ticket_transfers.ticket_id AND t.org_id = ANY(sqlc.narg('org_ids')::uuid[]))) LIMIT 1;
+
+-- name: GetPendingTicketTransfer :one
+SELECT * FROM ticket_transfers WHERE ticket_id = @ticket_id AND status = 'pending' AND (sqlc.narg('org_ids')::uuid[] IS NULL OR EXISTS (SELECT 1 FROM tickets t WHERE t.id = ticket_transfers.ticket_id AND t.org_id = ANY(sqlc.narg('org_ids')::uuid[]))) LIMIT 1;
+
+-- name: ListTicketTransfers :many
+SELECT * FROM ticket_transfers WHERE ticket_id = @ticket_id AND (sqlc.narg('org_ids')::uuid[] IS NULL OR EXISTS (SELECT 1 FROM tickets t WHERE t.id = ticket_transfers.ticket_id AND t.org_id = ANY(sqlc.narg('org_ids')::uuid[]))) ORDER BY created_at;
+
+-- name: DecideTicketTransfer :one
+UPDATE ticket_transfers
+SET
+    status = @status,
+    decided_by = @decided_by,
+    reason = @reason,
+    decided_at = @decided_at
+WHERE id = @id AND status = 'pending' AND (sqlc.narg('org_ids')::uuid[] IS NULL OR EXISTS (SELECT 1 FROM tickets t WHERE t.id = ticket_transfers.ticket_id AND t.org_id = ANY(sqlc.narg('org_ids')::uuid[])))
+RETURNING *;
+
+-- name: ListIncomingTransfers :many
+SELECT * FROM ticket_transfers
+WHERE status = 'pending'
+  AND (to_user_id = @user_id OR to_team_id = ANY(@team_ids::uuid[]))
+  AND (sqlc.narg('org_ids')::uuid[] IS NULL OR EXISTS (SELECT 1 FROM tickets t WHERE t.id = ticket_transfers.ticket_id AND t.org_id = ANY(sqlc.narg('org_ids')::uuid[])))
+ORDER BY created_at;
@@ -4,14 +4,16 @@ INSERT INTO users (
     first_name,
     last_name,
     email,
-    updated_at
+    updated_at,
+    org_id
 ) VALUES (
-    $1, $2, $3, $4, $5
+    $1, $2, $3, $4, $5, $6
 ) RETURNING *;
 
 -- name: GetUser :one
 SELECT * FROM users
-WHERE id = $1 LIMIT 1;
+WHERE id = @id AND (sqlc.narg('org_ids')::uuid[] IS NULL OR org_id = ANY(sqlc.narg('org_ids')::uuid[]) OR EXISTS (SELECT 1 FROM user_org_access a WHERE a.user_id = users.id AND a.org_id = ANY(sqlc.narg('org_ids')::uuid[])))
+LIMIT 1;
 
 -- name: GetUserByEmail :one
 SELECT * FROM users
@@ -19,19 +21,51 @@ WHERE email = $1 LIMIT 1;
 
 -- name: ListUsers :many
 SELECT * FROM users
+WHERE (sqlc.narg('org_ids')::uuid[] IS NULL OR org_id = ANY(sqlc.narg('org_ids')::uuid[]) OR EXISTS (SELECT 1 FROM user_org_access a WHERE a.user_id = users.id AND a.org_id = ANY(sqlc.narg('org_ids')::uuid[])))
 ORDER BY created_at DESC
-LIMIT $1 OFFSET $2;
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
 
 -- name: GetAllUsers :many
 SELECT id, first_name, last_name, email FROM users
+WHERE (sqlc.narg('org_ids')::uuid[] IS NULL OR org_id = ANY(sqlc.narg('org_ids')::uuid[]) OR EXISTS (SELECT 1 FROM user_org_access a WHERE a.user_id = users.id AND a.org_id = ANY(sqlc.narg('org_ids')::uuid[])))
 ORDER BY created_at DESC;
 
 -- name: UpdateUser :one
 UPDATE users
-SET email = $2, first_name = $3, last_name = $4, role = $5, updated_at = $6
-WHERE id = $1
+SET email = @email, first_name = @first_name, last_name = @last_name, role = @role, updated_at = @updated_at
+WHERE id = @id AND (sqlc.narg('org_ids')::uuid[] IS NULL OR org_id = ANY(sqlc.narg('org_ids')::uuid[]) OR EXISTS (SELECT 1 FROM user_org_access a WHERE a.user_id = users.id AND a.org_id = ANY(sqlc.narg('org_ids')::uuid[])))
 RETURNING *;
 
 -- name: DeleteUser :exec
 DELETE FROM users
-WHERE id = $1;
\ No newline at end of file
+WHERE id = @id AND (sqlc.narg('org_ids')::uuid[] IS NULL OR org_id = ANY(sqlc.narg('org_ids')::uuid[]) OR EXISTS (SELECT 1 FROM user_org_access a WHERE a.user_id = users.id AND a.org_id = ANY(sqlc.narg('org_ids')::uuid[])));
+
+-- name: UpdateUserOrg :one
+UPDATE users
+SET org_id = $2, updated_at = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: ListMentionableUsers :many
+SELECT * FROM users
+WHERE (org_id = @org_id OR (role IN ('agent', 'admin') AND EXISTS (SELECT 1 FROM user_org_access a WHERE a.user_id = users.id AND a.org_id = @org_id)))
+  AND (lower(email) = ANY(@emails::text[]) OR lower(split_part(email, '@', 1)) = ANY(@names::text[]));
+
+-- name: SearchUsers :many
+SELECT id, first_name, last_name, email FROM users
+WHERE (sqlc.narg('org_ids')::uuid[] IS NULL OR org_id = ANY(sqlc.narg('org_ids')::uuid[]) OR EXISTS (SELECT 1 FROM user_org_access a WHERE a.user_id = users.id AND a.org_id = ANY(sqlc.narg('org_ids')::uuid[])))
+  AND (email ILIKE @pattern OR first_name ILIKE @pattern OR last_name ILIKE @pattern OR first_name || ' ' || last_name ILIKE @pattern)
+ORDER BY first_name, last_name
+LIMIT @max_results;
+
+-- name: UpdateUserPassword :one
+UPDATE users
+SET hashed_password = @hashed_password, session_version = session_version + 1, updated_at = @updated_at
+WHERE id = @id
+RETURNING *;
+
+-- name: VerifyUserEmail :one
+UPDATE users
+SET email_verified_at = @email_verified_at
+WHERE id = @id
+RETURNING *;
@@ -0,0 +1,18 @@
+
+-- name: CreateUserToken :one
+INSERT INTO user_tokens (
+    user_id, purpose, token_hash, expires_at
+)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: ConsumeUserToken :one
+UPDATE user_tokens
+SET used_at = now()
+WHERE token_hash = @token_hash AND purpose = @purpose AND used_at IS NULL AND expires_at > now()
+RETURNING *;
+
+-- name: RevokeUserTokens :exec
+UPDATE user_tokens
+SET used_at = now()
+WHERE user_id = @user_id AND purpose = @purpose AND used_at IS NULL;